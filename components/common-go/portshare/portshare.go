@@ -0,0 +1,106 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Package portshare implements share tokens: signed, expiring tokens which grant access
+// to a single private workspace port. Supervisor signs them with the port share secret
+// of the workspace, which ws-proxy knows from ws-manager, such that ws-proxy can verify
+// them without asking anyone.
+package portshare
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryParam is the name of the query parameter which carries a share token in a share link
+const QueryParam = "gitpod_share"
+
+var (
+	// ErrInvalid is returned for tokens which are malformed or not signed with the secret
+	ErrInvalid = errors.New("invalid share token")
+	// ErrExpired is returned for tokens which are no longer valid
+	ErrExpired = errors.New("share token expired")
+)
+
+// Token grants access to a workspace port until it expires
+type Token struct {
+	Port    uint32
+	Expires time.Time
+	// ReadOnly tokens grant GET and HEAD requests only
+	ReadOnly bool
+}
+
+// Sign produces the string representation of a token, i.e. <port>.<expires>.<mode>.<signature>
+func Sign(secret string, token Token) string {
+	mode := "rw"
+	if token.ReadOnly {
+		mode = "ro"
+	}
+	payload := fmt.Sprintf("%d.%d.%s", token.Port, token.Expires.Unix(), mode)
+	return payload + "." + signature(secret, payload)
+}
+
+// Verify parses a token and checks its signature and expiry
+func Verify(secret string, value string, now time.Time) (*Token, error) {
+	if secret == "" {
+		return nil, ErrInvalid
+	}
+	idx := strings.LastIndex(value, ".")
+	if idx < 0 {
+		return nil, ErrInvalid
+	}
+	payload, sig := value[:idx], value[idx+1:]
+	if !hmac.Equal([]byte(sig), []byte(signature(secret, payload))) {
+		return nil, ErrInvalid
+	}
+
+	segs := strings.Split(payload, ".")
+	if len(segs) != 3 {
+		return nil, ErrInvalid
+	}
+	port, err := strconv.ParseUint(segs[0], 10, 16)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	expires, err := strconv.ParseInt(segs[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	if segs[2] != "ro" && segs[2] != "rw" {
+		return nil, ErrInvalid
+	}
+	token := &Token{
+		Port:     uint32(port),
+		Expires:  time.Unix(expires, 0),
+		ReadOnly: segs[2] == "ro",
+	}
+	if !now.Before(token.Expires) {
+		return nil, ErrExpired
+	}
+	return token, nil
+}
+
+// Allows returns true if the token grants a request with the given method to the port
+func (t *Token) Allows(port uint32, method string) bool {
+	if t.Port != port {
+		return false
+	}
+	if t.ReadOnly {
+		return method == http.MethodGet || method == http.MethodHead
+	}
+	return true
+}
+
+func signature(secret string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
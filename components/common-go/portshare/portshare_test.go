@@ -0,0 +1,109 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package portshare_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/gitpod-io/gitpod/common-go/portshare"
+)
+
+func TestVerify(t *testing.T) {
+	const secret = "secret"
+	var (
+		now     = time.Unix(1630000000, 0)
+		expires = now.Add(2 * time.Hour)
+		valid   = portshare.Sign(secret, portshare.Token{Port: 8080, Expires: expires, ReadOnly: true})
+	)
+
+	tests := []struct {
+		Name        string
+		Secret      string
+		Value       string
+		Expectation *portshare.Token
+		Error       error
+	}{
+		{
+			Name:        "valid",
+			Secret:      secret,
+			Value:       valid,
+			Expectation: &portshare.Token{Port: 8080, Expires: expires, ReadOnly: true},
+		},
+		{
+			Name:   "expired",
+			Secret: secret,
+			Value:  portshare.Sign(secret, portshare.Token{Port: 8080, Expires: now}),
+			Error:  portshare.ErrExpired,
+		},
+		{
+			Name:   "other secret",
+			Secret: "other",
+			Value:  valid,
+			Error:  portshare.ErrInvalid,
+		},
+		{
+			Name:   "no secret",
+			Value:  portshare.Sign("", portshare.Token{Port: 8080, Expires: expires}),
+			Error:  portshare.ErrInvalid,
+		},
+		{
+			Name:   "tampered mode",
+			Secret: secret,
+			Value:  strings.Replace(valid, ".ro.", ".rw.", 1),
+			Error:  portshare.ErrInvalid,
+		},
+		{
+			Name:   "tampered port",
+			Secret: secret,
+			Value:  "3000" + strings.TrimPrefix(valid, "8080"),
+			Error:  portshare.ErrInvalid,
+		},
+		{
+			Name:   "malformed",
+			Secret: secret,
+			Value:  "not a token",
+			Error:  portshare.ErrInvalid,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			token, err := portshare.Verify(test.Secret, test.Value, now)
+			if err != test.Error {
+				t.Fatalf("unexpected error: %v, expected %v", err, test.Error)
+			}
+			if diff := cmp.Diff(test.Expectation, token); diff != "" {
+				t.Errorf("unexpected token (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Token       portshare.Token
+		Port        uint32
+		Method      string
+		Expectation bool
+	}{
+		{Name: "read-write post", Token: portshare.Token{Port: 8080}, Port: 8080, Method: http.MethodPost, Expectation: true},
+		{Name: "read-only get", Token: portshare.Token{Port: 8080, ReadOnly: true}, Port: 8080, Method: http.MethodGet, Expectation: true},
+		{Name: "read-only head", Token: portshare.Token{Port: 8080, ReadOnly: true}, Port: 8080, Method: http.MethodHead, Expectation: true},
+		{Name: "read-only post", Token: portshare.Token{Port: 8080, ReadOnly: true}, Port: 8080, Method: http.MethodPost, Expectation: false},
+		{Name: "other port", Token: portshare.Token{Port: 8080}, Port: 3000, Method: http.MethodGet, Expectation: false},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if act := test.Token.Allows(test.Port, test.Method); act != test.Expectation {
+				t.Errorf("unexpected result: %v, expected %v", act, test.Expectation)
+			}
+		})
+	}
+}
@@ -20,13 +20,48 @@ type StorageConfig struct {
 	// MinIOConfig configures the MinIO remote storage
 	MinIOConfig MinIOConfig `json:"minio"`
 
+	// FileSystemConfig configures the local filesystem remote storage
+	FileSystemConfig FileSystemConfig `json:"fs"`
+
 	// BackupTrail maintains a number of backups for the same workspace
 	BackupTrail struct {
 		Enabled   bool `json:"enabled"`
 		MaxLength int  `json:"maxLength"`
 	} `json:"backupTrail"`
 
+	// BackupRetention keeps a history of past backups for each workspace
+	BackupRetention BackupRetentionConfig `json:"backupRetention"`
+
 	BlobQuota int64 `json:"blobQuota"`
+
+	// Encryption configures client-side encryption of workspace backups and snapshots
+	Encryption EncryptionConfig `json:"encryption"`
+}
+
+// EncryptionConfig configures client-side encryption of workspace content
+type EncryptionConfig struct {
+	// Enabled encrypts backups and snapshots before they leave the node. Content which was encrypted before
+	// can still be restored with encryption disabled as long as the key-encryption key is configured.
+	Enabled bool `json:"enabled"`
+
+	// KeyEncryptionKeyFile points to a file containing the base64 encoded 32 byte key-encryption key
+	KeyEncryptionKeyFile string `json:"keyEncryptionKeyFile"`
+}
+
+// BackupRetentionConfig configures the backup history of workspaces and which of the historic backups are kept.
+// A backup is kept if any of the rules keeps it. If no rule is configured, all backups are kept.
+type BackupRetentionConfig struct {
+	// Enabled adds a copy of every regular workspace backup to the workspace's backup history
+	Enabled bool `json:"enabled"`
+
+	// KeepLast is the number of most recent backups to keep
+	KeepLast int `json:"keepLast,omitempty"`
+
+	// Hourly is the number of hours for which the most recent backup of the hour is kept
+	Hourly int `json:"hourly,omitempty"`
+
+	// Daily is the number of days for which the most recent backup of the day is kept
+	Daily int `json:"daily,omitempty"`
 }
 
 // Stage represents the deployment environment in which we're operating
@@ -53,6 +88,9 @@ const (
 	// MinIOStorage stores workspaces in a MinIO/S3 storage
 	MinIOStorage RemoteStorageType = "minio"
 
+	// FileSystemStorage stores workspaces in a local or NFS-mounted directory
+	FileSystemStorage RemoteStorageType = "fs"
+
 	// NullStorage does not synchronize workspaces at all
 	NullStorage RemoteStorageType = ""
 )
@@ -102,3 +140,19 @@ type MinIOConfig struct {
 	Region         string `json:"region"`
 	ParallelUpload uint   `json:"parallelUpload,omitempty"`
 }
+
+// FileSystemConfig configures the local filesystem remote storage backend
+type FileSystemConfig struct {
+	// BasePath is the directory in which all buckets are stored. When used from more than one node
+	// this directory must be shared, e.g. using NFS.
+	BasePath string `json:"basePath"`
+
+	// URL is the base URL under which the storage HTTP handler is reachable. Signed URLs are relative to this URL.
+	URL string `json:"url"`
+
+	// SigningKeyFile points to a file containing the secret used to sign URLs
+	SigningKeyFile string `json:"signingKeyFile"`
+
+	// Addr is the address the storage HTTP handler listens on. Leave empty to not serve signed URLs.
+	Addr string `json:"address,omitempty"`
+}
@@ -46,3 +46,25 @@ type WorkspaceContentLayer struct {
 	// Workspace instance ID this content layer came from
 	InstanceID string `json:"instanceID"`
 }
+
+const (
+	// ContentTypeChunkIndex is the content type for a JSON serialized WorkspaceChunkIndex
+	ContentTypeChunkIndex = "application/vnd.gitpod.ws.chunks.v1+json"
+)
+
+// WorkspaceChunkIndex describes a workspace backup tarball which was split into content-defined chunks.
+// Concatenating the chunks in order yields the original tarball.
+type WorkspaceChunkIndex struct {
+	// Digest is the digest of the complete tarball
+	Digest digest.Digest `json:"digest"`
+	// Size is the size of the complete tarball in bytes
+	Size int64 `json:"size"`
+
+	Chunks []WorkspaceChunk `json:"chunks"`
+}
+
+// WorkspaceChunk describes a single chunk of a chunked workspace backup
+type WorkspaceChunk struct {
+	Digest digest.Digest `json:"digest"`
+	Size   int64         `json:"size"`
+}
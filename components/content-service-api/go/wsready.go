@@ -23,4 +23,35 @@ const (
 // WorkspaceReadyMessage describes the content of a workspace-ready file in a workspace
 type WorkspaceReadyMessage struct {
 	Source WorkspaceInitSource `json:"source"`
+
+	// Initializer reports on the individual initializer of a composite initializer, if the workspace was initialized by one
+	Initializer []InitializerReport `json:"initializer,omitempty"`
+
+	// LFSBytes is the number of Git LFS bytes fetched during the workspace initialization
+	LFSBytes int64 `json:"lfsBytes,omitempty"`
+}
+
+// InitializerReport describes how a single initializer within a composite initializer fared
+type InitializerReport struct {
+	// Kind is the kind of initializer, e.g. git or prebuild
+	Kind string `json:"kind"`
+
+	// Location is the checkout location of the initializer relative to the workspace root.
+	// An empty location means the initializer operated on the whole workspace.
+	Location string `json:"location,omitempty"`
+
+	// Source is the source the initializer produced content from. Empty if the initializer failed.
+	Source WorkspaceInitSource `json:"source,omitempty"`
+
+	// DurationMillis is the time it took the initializer to run in milliseconds
+	DurationMillis int64 `json:"durationMillis"`
+
+	// BestEffort is true if the failure of the initializer did not fail the workspace initialization
+	BestEffort bool `json:"bestEffort,omitempty"`
+
+	// Error is the reason the initializer failed. Empty if the initializer succeeded.
+	Error string `json:"error,omitempty"`
+
+	// LFSBytes is the number of Git LFS bytes the initializer fetched
+	LFSBytes int64 `json:"lfsBytes,omitempty"`
 }
@@ -90,6 +90,10 @@ export class CompositeInitializer extends jspb.Message {
     getInitializerList(): Array<WorkspaceInitializer>;
     setInitializerList(value: Array<WorkspaceInitializer>): CompositeInitializer;
     addInitializer(value?: WorkspaceInitializer, index?: number): WorkspaceInitializer;
+    clearPolicyList(): void;
+    getPolicyList(): Array<InitializerPolicy>;
+    setPolicyList(value: Array<InitializerPolicy>): CompositeInitializer;
+    addPolicy(value: InitializerPolicy, index?: number): InitializerPolicy;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): CompositeInitializer.AsObject;
@@ -104,6 +108,7 @@ export class CompositeInitializer extends jspb.Message {
 export namespace CompositeInitializer {
     export type AsObject = {
         initializerList: Array<WorkspaceInitializer.AsObject>,
+        policyList: Array<InitializerPolicy>,
     }
 }
 
@@ -193,6 +198,14 @@ export class GitInitializer extends jspb.Message {
     clearConfig(): void;
     getConfig(): GitConfig | undefined;
     setConfig(value?: GitConfig): GitInitializer;
+    getCloneDepth(): number;
+    setCloneDepth(value: number): GitInitializer;
+    getCloneFilter(): CloneFilter;
+    setCloneFilter(value: CloneFilter): GitInitializer;
+    clearSparseCheckoutPatternsList(): void;
+    getSparseCheckoutPatternsList(): Array<string>;
+    setSparseCheckoutPatternsList(value: Array<string>): GitInitializer;
+    addSparseCheckoutPatterns(value: string, index?: number): string;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): GitInitializer.AsObject;
@@ -212,6 +225,9 @@ export namespace GitInitializer {
         cloneTaget: string,
         checkoutLocation: string,
         config?: GitConfig.AsObject,
+        cloneDepth: number,
+        cloneFilter: CloneFilter,
+        sparseCheckoutPatternsList: Array<string>,
     }
 }
 
@@ -227,6 +243,12 @@ export class GitConfig extends jspb.Message {
     setAuthPassword(value: string): GitConfig;
     getAuthOts(): string;
     setAuthOts(value: string): GitConfig;
+    getLfs(): GitLFSMode;
+    setLfs(value: GitLFSMode): GitConfig;
+    clearLfsIncludePatternsList(): void;
+    getLfsIncludePatternsList(): Array<string>;
+    setLfsIncludePatternsList(value: Array<string>): GitConfig;
+    addLfsIncludePatterns(value: string, index?: number): string;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): GitConfig.AsObject;
@@ -246,6 +268,8 @@ export namespace GitConfig {
         authUser: string,
         authPassword: string,
         authOts: string,
+        lfs: GitLFSMode,
+        lfsIncludePatternsList: Array<string>,
     }
 }
 
@@ -299,6 +323,10 @@ export namespace PrebuildInitializer {
 }
 
 export class FromBackupInitializer extends jspb.Message {
+    getBackupId(): string;
+    setBackupId(value: string): FromBackupInitializer;
+    getTimestamp(): number;
+    setTimestamp(value: number): FromBackupInitializer;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): FromBackupInitializer.AsObject;
@@ -312,6 +340,8 @@ export class FromBackupInitializer extends jspb.Message {
 
 export namespace FromBackupInitializer {
     export type AsObject = {
+        backupId: string,
+        timestamp: number,
     }
 }
 
@@ -362,6 +392,17 @@ export namespace GitStatus {
     }
 }
 
+export enum InitializerPolicy {
+    REQUIRED = 0,
+    BEST_EFFORT = 1,
+}
+
+export enum CloneFilter {
+    NO_FILTER = 0,
+    BLOB_NONE = 1,
+    TREE_ZERO = 2,
+}
+
 export enum CloneTargetMode {
     REMOTE_HEAD = 0,
     REMOTE_COMMIT = 1,
@@ -369,6 +410,12 @@ export enum CloneTargetMode {
     LOCAL_BRANCH = 3,
 }
 
+export enum GitLFSMode {
+    LFS_SKIP = 0,
+    LFS_FETCH_ALL = 1,
+    LFS_FETCH_INCLUDE = 2,
+}
+
 export enum GitAuthMethod {
     NO_AUTH = 0,
     BASIC_AUTH = 1,
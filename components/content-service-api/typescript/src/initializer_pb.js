@@ -21,6 +21,7 @@ var jspb = require('google-protobuf');
 var goog = jspb;
 var global = Function('return this')();
 
+goog.exportSymbol('proto.contentservice.CloneFilter', null, global);
 goog.exportSymbol('proto.contentservice.CloneTargetMode', null, global);
 goog.exportSymbol('proto.contentservice.CompositeInitializer', null, global);
 goog.exportSymbol('proto.contentservice.EmptyInitializer', null, global);
@@ -30,7 +31,9 @@ goog.exportSymbol('proto.contentservice.FromBackupInitializer', null, global);
 goog.exportSymbol('proto.contentservice.GitAuthMethod', null, global);
 goog.exportSymbol('proto.contentservice.GitConfig', null, global);
 goog.exportSymbol('proto.contentservice.GitInitializer', null, global);
+goog.exportSymbol('proto.contentservice.GitLFSMode', null, global);
 goog.exportSymbol('proto.contentservice.GitStatus', null, global);
+goog.exportSymbol('proto.contentservice.InitializerPolicy', null, global);
 goog.exportSymbol('proto.contentservice.PrebuildInitializer', null, global);
 goog.exportSymbol('proto.contentservice.SnapshotInitializer', null, global);
 goog.exportSymbol('proto.contentservice.WorkspaceInitializer', null, global);
@@ -151,7 +154,7 @@ if (goog.DEBUG && !COMPILED) {
  * @constructor
  */
 proto.contentservice.GitInitializer = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.contentservice.GitInitializer.repeatedFields_, null);
 };
 goog.inherits(proto.contentservice.GitInitializer, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
@@ -172,7 +175,7 @@ if (goog.DEBUG && !COMPILED) {
  * @constructor
  */
 proto.contentservice.GitConfig = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.contentservice.GitConfig.repeatedFields_, null);
 };
 goog.inherits(proto.contentservice.GitConfig, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
@@ -760,7 +763,7 @@ proto.contentservice.WorkspaceInitializer.prototype.hasBackup = function() {
  * @private {!Array<number>}
  * @const
  */
-proto.contentservice.CompositeInitializer.repeatedFields_ = [1];
+proto.contentservice.CompositeInitializer.repeatedFields_ = [1,2];
 
 
 
@@ -794,7 +797,8 @@ proto.contentservice.CompositeInitializer.prototype.toObject = function(opt_incl
 proto.contentservice.CompositeInitializer.toObject = function(includeInstance, msg) {
   var f, obj = {
     initializerList: jspb.Message.toObjectList(msg.getInitializerList(),
-    proto.contentservice.WorkspaceInitializer.toObject, includeInstance)
+    proto.contentservice.WorkspaceInitializer.toObject, includeInstance),
+    policyList: (f = jspb.Message.getRepeatedField(msg, 2)) == null ? undefined : f
   };
 
   if (includeInstance) {
@@ -836,6 +840,12 @@ proto.contentservice.CompositeInitializer.deserializeBinaryFromReader = function
       reader.readMessage(value,proto.contentservice.WorkspaceInitializer.deserializeBinaryFromReader);
       msg.addInitializer(value);
       break;
+    case 2:
+      var values = /** @type {!Array<!proto.contentservice.InitializerPolicy>} */ (reader.isDelimited() ? reader.readPackedEnum() : [reader.readEnum()]);
+      for (var i = 0; i < values.length; i++) {
+        msg.addPolicy(values[i]);
+      }
+      break;
     default:
       reader.skipField();
       break;
@@ -873,6 +883,13 @@ proto.contentservice.CompositeInitializer.serializeBinaryToWriter = function(mes
       proto.contentservice.WorkspaceInitializer.serializeBinaryToWriter
     );
   }
+  f = message.getPolicyList();
+  if (f.length > 0) {
+    writer.writePackedEnum(
+      2,
+      f
+    );
+  }
 };
 
 
@@ -914,6 +931,43 @@ proto.contentservice.CompositeInitializer.prototype.clearInitializerList = funct
 };
 
 
+/**
+ * repeated InitializerPolicy policy = 2;
+ * @return {!Array<!proto.contentservice.InitializerPolicy>}
+ */
+proto.contentservice.CompositeInitializer.prototype.getPolicyList = function() {
+  return /** @type {!Array<!proto.contentservice.InitializerPolicy>} */ (jspb.Message.getRepeatedField(this, 2));
+};
+
+
+/**
+ * @param {!Array<!proto.contentservice.InitializerPolicy>} value
+ * @return {!proto.contentservice.CompositeInitializer} returns this
+ */
+proto.contentservice.CompositeInitializer.prototype.setPolicyList = function(value) {
+  return jspb.Message.setField(this, 2, value || []);
+};
+
+
+/**
+ * @param {!proto.contentservice.InitializerPolicy} value
+ * @param {number=} opt_index
+ * @return {!proto.contentservice.CompositeInitializer} returns this
+ */
+proto.contentservice.CompositeInitializer.prototype.addPolicy = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 2, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.contentservice.CompositeInitializer} returns this
+ */
+proto.contentservice.CompositeInitializer.prototype.clearPolicyList = function() {
+  return this.setPolicyList([]);
+};
+
+
 
 /**
  * List of repeated fields within this message type.
@@ -1398,6 +1452,15 @@ proto.contentservice.EmptyInitializer.serializeBinaryToWriter = function(message
 
 
 
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.contentservice.GitInitializer.repeatedFields_ = [9];
+
+
+
 if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
  * Creates an object representation of this proto.
@@ -1432,7 +1495,10 @@ proto.contentservice.GitInitializer.toObject = function(includeInstance, msg) {
     targetMode: jspb.Message.getFieldWithDefault(msg, 3, 0),
     cloneTaget: jspb.Message.getFieldWithDefault(msg, 4, ""),
     checkoutLocation: jspb.Message.getFieldWithDefault(msg, 5, ""),
-    config: (f = msg.getConfig()) && proto.contentservice.GitConfig.toObject(includeInstance, f)
+    config: (f = msg.getConfig()) && proto.contentservice.GitConfig.toObject(includeInstance, f),
+    cloneDepth: jspb.Message.getFieldWithDefault(msg, 7, 0),
+    cloneFilter: jspb.Message.getFieldWithDefault(msg, 8, 0),
+    sparseCheckoutPatternsList: (f = jspb.Message.getRepeatedField(msg, 9)) == null ? undefined : f
   };
 
   if (includeInstance) {
@@ -1494,6 +1560,18 @@ proto.contentservice.GitInitializer.deserializeBinaryFromReader = function(msg,
       reader.readMessage(value,proto.contentservice.GitConfig.deserializeBinaryFromReader);
       msg.setConfig(value);
       break;
+    case 7:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setCloneDepth(value);
+      break;
+    case 8:
+      var value = /** @type {!proto.contentservice.CloneFilter} */ (reader.readEnum());
+      msg.setCloneFilter(value);
+      break;
+    case 9:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addSparseCheckoutPatterns(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1566,6 +1644,27 @@ proto.contentservice.GitInitializer.serializeBinaryToWriter = function(message,
       proto.contentservice.GitConfig.serializeBinaryToWriter
     );
   }
+  f = message.getCloneDepth();
+  if (f !== 0) {
+    writer.writeInt32(
+      7,
+      f
+    );
+  }
+  f = message.getCloneFilter();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      8,
+      f
+    );
+  }
+  f = message.getSparseCheckoutPatternsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      9,
+      f
+    );
+  }
 };
 
 
@@ -1696,6 +1795,86 @@ proto.contentservice.GitInitializer.prototype.hasConfig = function() {
 };
 
 
+/**
+ * optional int32 clone_depth = 7;
+ * @return {number}
+ */
+proto.contentservice.GitInitializer.prototype.getCloneDepth = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 7, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.contentservice.GitInitializer} returns this
+ */
+proto.contentservice.GitInitializer.prototype.setCloneDepth = function(value) {
+  return jspb.Message.setProto3IntField(this, 7, value);
+};
+
+
+/**
+ * optional CloneFilter clone_filter = 8;
+ * @return {!proto.contentservice.CloneFilter}
+ */
+proto.contentservice.GitInitializer.prototype.getCloneFilter = function() {
+  return /** @type {!proto.contentservice.CloneFilter} */ (jspb.Message.getFieldWithDefault(this, 8, 0));
+};
+
+
+/**
+ * @param {!proto.contentservice.CloneFilter} value
+ * @return {!proto.contentservice.GitInitializer} returns this
+ */
+proto.contentservice.GitInitializer.prototype.setCloneFilter = function(value) {
+  return jspb.Message.setProto3EnumField(this, 8, value);
+};
+
+
+/**
+ * repeated string sparse_checkout_patterns = 9;
+ * @return {!Array<string>}
+ */
+proto.contentservice.GitInitializer.prototype.getSparseCheckoutPatternsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 9));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.contentservice.GitInitializer} returns this
+ */
+proto.contentservice.GitInitializer.prototype.setSparseCheckoutPatternsList = function(value) {
+  return jspb.Message.setField(this, 9, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.contentservice.GitInitializer} returns this
+ */
+proto.contentservice.GitInitializer.prototype.addSparseCheckoutPatterns = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 9, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.contentservice.GitInitializer} returns this
+ */
+proto.contentservice.GitInitializer.prototype.clearSparseCheckoutPatternsList = function() {
+  return this.setSparseCheckoutPatternsList([]);
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.contentservice.GitConfig.repeatedFields_ = [7];
 
 
 
@@ -1732,7 +1911,9 @@ proto.contentservice.GitConfig.toObject = function(includeInstance, msg) {
     authentication: jspb.Message.getFieldWithDefault(msg, 2, 0),
     authUser: jspb.Message.getFieldWithDefault(msg, 3, ""),
     authPassword: jspb.Message.getFieldWithDefault(msg, 4, ""),
-    authOts: jspb.Message.getFieldWithDefault(msg, 5, "")
+    authOts: jspb.Message.getFieldWithDefault(msg, 5, ""),
+    lfs: jspb.Message.getFieldWithDefault(msg, 6, 0),
+    lfsIncludePatternsList: (f = jspb.Message.getRepeatedField(msg, 7)) == null ? undefined : f
   };
 
   if (includeInstance) {
@@ -1791,6 +1972,14 @@ proto.contentservice.GitConfig.deserializeBinaryFromReader = function(msg, reade
       var value = /** @type {string} */ (reader.readString());
       msg.setAuthOts(value);
       break;
+    case 6:
+      var value = /** @type {!proto.contentservice.GitLFSMode} */ (reader.readEnum());
+      msg.setLfs(value);
+      break;
+    case 7:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addLfsIncludePatterns(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1852,6 +2041,20 @@ proto.contentservice.GitConfig.serializeBinaryToWriter = function(message, write
       f
     );
   }
+  f = message.getLfs();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      6,
+      f
+    );
+  }
+  f = message.getLfsIncludePatternsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      7,
+      f
+    );
+  }
 };
 
 
@@ -1949,6 +2152,61 @@ proto.contentservice.GitConfig.prototype.setAuthOts = function(value) {
 };
 
 
+/**
+ * optional GitLFSMode lfs = 6;
+ * @return {!proto.contentservice.GitLFSMode}
+ */
+proto.contentservice.GitConfig.prototype.getLfs = function() {
+  return /** @type {!proto.contentservice.GitLFSMode} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+
+/**
+ * @param {!proto.contentservice.GitLFSMode} value
+ * @return {!proto.contentservice.GitConfig} returns this
+ */
+proto.contentservice.GitConfig.prototype.setLfs = function(value) {
+  return jspb.Message.setProto3EnumField(this, 6, value);
+};
+
+
+/**
+ * repeated string lfs_include_patterns = 7;
+ * @return {!Array<string>}
+ */
+proto.contentservice.GitConfig.prototype.getLfsIncludePatternsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 7));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.contentservice.GitConfig} returns this
+ */
+proto.contentservice.GitConfig.prototype.setLfsIncludePatternsList = function(value) {
+  return jspb.Message.setField(this, 7, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.contentservice.GitConfig} returns this
+ */
+proto.contentservice.GitConfig.prototype.addLfsIncludePatterns = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 7, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.contentservice.GitConfig} returns this
+ */
+proto.contentservice.GitConfig.prototype.clearLfsIncludePatternsList = function() {
+  return this.setLfsIncludePatternsList([]);
+};
+
+
 
 
 
@@ -2313,7 +2571,8 @@ proto.contentservice.FromBackupInitializer.prototype.toObject = function(opt_inc
  */
 proto.contentservice.FromBackupInitializer.toObject = function(includeInstance, msg) {
   var f, obj = {
-
+    backupId: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    timestamp: jspb.Message.getFieldWithDefault(msg, 2, 0)
   };
 
   if (includeInstance) {
@@ -2350,6 +2609,14 @@ proto.contentservice.FromBackupInitializer.deserializeBinaryFromReader = functio
     }
     var field = reader.getFieldNumber();
     switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setBackupId(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setTimestamp(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -2379,6 +2646,56 @@ proto.contentservice.FromBackupInitializer.prototype.serializeBinary = function(
  */
 proto.contentservice.FromBackupInitializer.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
+  f = message.getBackupId();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getTimestamp();
+  if (f !== 0) {
+    writer.writeInt64(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string backup_id = 1;
+ * @return {string}
+ */
+proto.contentservice.FromBackupInitializer.prototype.getBackupId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.FromBackupInitializer} returns this
+ */
+proto.contentservice.FromBackupInitializer.prototype.setBackupId = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional int64 timestamp = 2;
+ * @return {number}
+ */
+proto.contentservice.FromBackupInitializer.prototype.getTimestamp = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.contentservice.FromBackupInitializer} returns this
+ */
+proto.contentservice.FromBackupInitializer.prototype.setTimestamp = function(value) {
+  return jspb.Message.setProto3IntField(this, 2, value);
 };
 
 
@@ -2786,6 +3103,23 @@ proto.contentservice.GitStatus.prototype.setTotalUnpushedCommits = function(valu
 };
 
 
+/**
+ * @enum {number}
+ */
+proto.contentservice.InitializerPolicy = {
+  REQUIRED: 0,
+  BEST_EFFORT: 1
+};
+
+/**
+ * @enum {number}
+ */
+proto.contentservice.CloneFilter = {
+  NO_FILTER: 0,
+  BLOB_NONE: 1,
+  TREE_ZERO: 2
+};
+
 /**
  * @enum {number}
  */
@@ -2796,6 +3130,15 @@ proto.contentservice.CloneTargetMode = {
   LOCAL_BRANCH: 3
 };
 
+/**
+ * @enum {number}
+ */
+proto.contentservice.GitLFSMode = {
+  LFS_SKIP: 0,
+  LFS_FETCH_ALL: 1,
+  LFS_FETCH_INCLUDE: 2
+};
+
 /**
  * @enum {number}
  */
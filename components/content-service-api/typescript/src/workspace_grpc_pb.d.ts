@@ -16,6 +16,7 @@ import * as workspace_pb from "./workspace_pb";
 interface IWorkspaceServiceService extends grpc.ServiceDefinition<grpc.UntypedServiceImplementation> {
     workspaceDownloadURL: IWorkspaceServiceService_IWorkspaceDownloadURL;
     deleteWorkspace: IWorkspaceServiceService_IDeleteWorkspace;
+    listBackups: IWorkspaceServiceService_IListBackups;
 }
 
 interface IWorkspaceServiceService_IWorkspaceDownloadURL extends grpc.MethodDefinition<workspace_pb.WorkspaceDownloadURLRequest, workspace_pb.WorkspaceDownloadURLResponse> {
@@ -36,12 +37,22 @@ interface IWorkspaceServiceService_IDeleteWorkspace extends grpc.MethodDefinitio
     responseSerialize: grpc.serialize<workspace_pb.DeleteWorkspaceResponse>;
     responseDeserialize: grpc.deserialize<workspace_pb.DeleteWorkspaceResponse>;
 }
+interface IWorkspaceServiceService_IListBackups extends grpc.MethodDefinition<workspace_pb.ListBackupsRequest, workspace_pb.ListBackupsResponse> {
+    path: "/contentservice.WorkspaceService/ListBackups";
+    requestStream: false;
+    responseStream: false;
+    requestSerialize: grpc.serialize<workspace_pb.ListBackupsRequest>;
+    requestDeserialize: grpc.deserialize<workspace_pb.ListBackupsRequest>;
+    responseSerialize: grpc.serialize<workspace_pb.ListBackupsResponse>;
+    responseDeserialize: grpc.deserialize<workspace_pb.ListBackupsResponse>;
+}
 
 export const WorkspaceServiceService: IWorkspaceServiceService;
 
 export interface IWorkspaceServiceServer extends grpc.UntypedServiceImplementation {
     workspaceDownloadURL: grpc.handleUnaryCall<workspace_pb.WorkspaceDownloadURLRequest, workspace_pb.WorkspaceDownloadURLResponse>;
     deleteWorkspace: grpc.handleUnaryCall<workspace_pb.DeleteWorkspaceRequest, workspace_pb.DeleteWorkspaceResponse>;
+    listBackups: grpc.handleUnaryCall<workspace_pb.ListBackupsRequest, workspace_pb.ListBackupsResponse>;
 }
 
 export interface IWorkspaceServiceClient {
@@ -51,6 +62,9 @@ export interface IWorkspaceServiceClient {
     deleteWorkspace(request: workspace_pb.DeleteWorkspaceRequest, callback: (error: grpc.ServiceError | null, response: workspace_pb.DeleteWorkspaceResponse) => void): grpc.ClientUnaryCall;
     deleteWorkspace(request: workspace_pb.DeleteWorkspaceRequest, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: workspace_pb.DeleteWorkspaceResponse) => void): grpc.ClientUnaryCall;
     deleteWorkspace(request: workspace_pb.DeleteWorkspaceRequest, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: workspace_pb.DeleteWorkspaceResponse) => void): grpc.ClientUnaryCall;
+    listBackups(request: workspace_pb.ListBackupsRequest, callback: (error: grpc.ServiceError | null, response: workspace_pb.ListBackupsResponse) => void): grpc.ClientUnaryCall;
+    listBackups(request: workspace_pb.ListBackupsRequest, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: workspace_pb.ListBackupsResponse) => void): grpc.ClientUnaryCall;
+    listBackups(request: workspace_pb.ListBackupsRequest, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: workspace_pb.ListBackupsResponse) => void): grpc.ClientUnaryCall;
 }
 
 export class WorkspaceServiceClient extends grpc.Client implements IWorkspaceServiceClient {
@@ -61,4 +75,7 @@ export class WorkspaceServiceClient extends grpc.Client implements IWorkspaceSer
     public deleteWorkspace(request: workspace_pb.DeleteWorkspaceRequest, callback: (error: grpc.ServiceError | null, response: workspace_pb.DeleteWorkspaceResponse) => void): grpc.ClientUnaryCall;
     public deleteWorkspace(request: workspace_pb.DeleteWorkspaceRequest, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: workspace_pb.DeleteWorkspaceResponse) => void): grpc.ClientUnaryCall;
     public deleteWorkspace(request: workspace_pb.DeleteWorkspaceRequest, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: workspace_pb.DeleteWorkspaceResponse) => void): grpc.ClientUnaryCall;
+    public listBackups(request: workspace_pb.ListBackupsRequest, callback: (error: grpc.ServiceError | null, response: workspace_pb.ListBackupsResponse) => void): grpc.ClientUnaryCall;
+    public listBackups(request: workspace_pb.ListBackupsRequest, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: workspace_pb.ListBackupsResponse) => void): grpc.ClientUnaryCall;
+    public listBackups(request: workspace_pb.ListBackupsRequest, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: workspace_pb.ListBackupsResponse) => void): grpc.ClientUnaryCall;
 }
@@ -31,6 +31,28 @@ function deserialize_contentservice_DeleteWorkspaceResponse(buffer_arg) {
   return workspace_pb.DeleteWorkspaceResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_contentservice_ListBackupsRequest(arg) {
+  if (!(arg instanceof workspace_pb.ListBackupsRequest)) {
+    throw new Error('Expected argument of type contentservice.ListBackupsRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_contentservice_ListBackupsRequest(buffer_arg) {
+  return workspace_pb.ListBackupsRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_contentservice_ListBackupsResponse(arg) {
+  if (!(arg instanceof workspace_pb.ListBackupsResponse)) {
+    throw new Error('Expected argument of type contentservice.ListBackupsResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_contentservice_ListBackupsResponse(buffer_arg) {
+  return workspace_pb.ListBackupsResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_contentservice_WorkspaceDownloadURLRequest(arg) {
   if (!(arg instanceof workspace_pb.WorkspaceDownloadURLRequest)) {
     throw new Error('Expected argument of type contentservice.WorkspaceDownloadURLRequest');
@@ -79,6 +101,18 @@ deleteWorkspace: {
     responseSerialize: serialize_contentservice_DeleteWorkspaceResponse,
     responseDeserialize: deserialize_contentservice_DeleteWorkspaceResponse,
   },
+  // ListBackups lists the backups from the backup history and trail of a workspace, most recent backup first
+listBackups: {
+    path: '/contentservice.WorkspaceService/ListBackups',
+    requestStream: false,
+    responseStream: false,
+    requestType: workspace_pb.ListBackupsRequest,
+    responseType: workspace_pb.ListBackupsResponse,
+    requestSerialize: serialize_contentservice_ListBackupsRequest,
+    requestDeserialize: deserialize_contentservice_ListBackupsRequest,
+    responseSerialize: serialize_contentservice_ListBackupsResponse,
+    responseDeserialize: deserialize_contentservice_ListBackupsResponse,
+  },
 };
 
 exports.WorkspaceServiceClient = grpc.makeGenericClientConstructor(WorkspaceServiceService);
@@ -97,3 +97,71 @@ export namespace DeleteWorkspaceResponse {
     export type AsObject = {
     }
 }
+
+export class ListBackupsRequest extends jspb.Message {
+    getOwnerId(): string;
+    setOwnerId(value: string): ListBackupsRequest;
+    getWorkspaceId(): string;
+    setWorkspaceId(value: string): ListBackupsRequest;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): ListBackupsRequest.AsObject;
+    static toObject(includeInstance: boolean, msg: ListBackupsRequest): ListBackupsRequest.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: ListBackupsRequest, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): ListBackupsRequest;
+    static deserializeBinaryFromReader(message: ListBackupsRequest, reader: jspb.BinaryReader): ListBackupsRequest;
+}
+
+export namespace ListBackupsRequest {
+    export type AsObject = {
+        ownerId: string,
+        workspaceId: string,
+    }
+}
+
+export class ListBackupsResponse extends jspb.Message {
+    clearBackupsList(): void;
+    getBackupsList(): Array<WorkspaceBackup>;
+    setBackupsList(value: Array<WorkspaceBackup>): ListBackupsResponse;
+    addBackups(value?: WorkspaceBackup, index?: number): WorkspaceBackup;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): ListBackupsResponse.AsObject;
+    static toObject(includeInstance: boolean, msg: ListBackupsResponse): ListBackupsResponse.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: ListBackupsResponse, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): ListBackupsResponse;
+    static deserializeBinaryFromReader(message: ListBackupsResponse, reader: jspb.BinaryReader): ListBackupsResponse;
+}
+
+export namespace ListBackupsResponse {
+    export type AsObject = {
+        backupsList: Array<WorkspaceBackup.AsObject>,
+    }
+}
+
+export class WorkspaceBackup extends jspb.Message {
+    getId(): string;
+    setId(value: string): WorkspaceBackup;
+    getCreated(): number;
+    setCreated(value: number): WorkspaceBackup;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): WorkspaceBackup.AsObject;
+    static toObject(includeInstance: boolean, msg: WorkspaceBackup): WorkspaceBackup.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: WorkspaceBackup, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): WorkspaceBackup;
+    static deserializeBinaryFromReader(message: WorkspaceBackup, reader: jspb.BinaryReader): WorkspaceBackup;
+}
+
+export namespace WorkspaceBackup {
+    export type AsObject = {
+        id: string,
+        created: number,
+    }
+}
@@ -23,6 +23,9 @@ var global = Function('return this')();
 
 goog.exportSymbol('proto.contentservice.DeleteWorkspaceRequest', null, global);
 goog.exportSymbol('proto.contentservice.DeleteWorkspaceResponse', null, global);
+goog.exportSymbol('proto.contentservice.ListBackupsRequest', null, global);
+goog.exportSymbol('proto.contentservice.ListBackupsResponse', null, global);
+goog.exportSymbol('proto.contentservice.WorkspaceBackup', null, global);
 goog.exportSymbol('proto.contentservice.WorkspaceDownloadURLRequest', null, global);
 goog.exportSymbol('proto.contentservice.WorkspaceDownloadURLResponse', null, global);
 /**
@@ -109,6 +112,69 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.contentservice.DeleteWorkspaceResponse.displayName = 'proto.contentservice.DeleteWorkspaceResponse';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.contentservice.ListBackupsRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.contentservice.ListBackupsRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.contentservice.ListBackupsRequest.displayName = 'proto.contentservice.ListBackupsRequest';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.contentservice.ListBackupsResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.contentservice.ListBackupsResponse.repeatedFields_, null);
+};
+goog.inherits(proto.contentservice.ListBackupsResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.contentservice.ListBackupsResponse.displayName = 'proto.contentservice.ListBackupsResponse';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.contentservice.WorkspaceBackup = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.contentservice.WorkspaceBackup, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.contentservice.WorkspaceBackup.displayName = 'proto.contentservice.WorkspaceBackup';
+}
 
 
 
@@ -690,4 +756,480 @@ proto.contentservice.DeleteWorkspaceResponse.serializeBinaryToWriter = function(
 };
 
 
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.contentservice.ListBackupsRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.contentservice.ListBackupsRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.contentservice.ListBackupsRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.ListBackupsRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    ownerId: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    workspaceId: jspb.Message.getFieldWithDefault(msg, 2, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.contentservice.ListBackupsRequest}
+ */
+proto.contentservice.ListBackupsRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.contentservice.ListBackupsRequest;
+  return proto.contentservice.ListBackupsRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.contentservice.ListBackupsRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.contentservice.ListBackupsRequest}
+ */
+proto.contentservice.ListBackupsRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setOwnerId(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setWorkspaceId(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.contentservice.ListBackupsRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.contentservice.ListBackupsRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.contentservice.ListBackupsRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.ListBackupsRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getOwnerId();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getWorkspaceId();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string owner_id = 1;
+ * @return {string}
+ */
+proto.contentservice.ListBackupsRequest.prototype.getOwnerId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.ListBackupsRequest} returns this
+ */
+proto.contentservice.ListBackupsRequest.prototype.setOwnerId = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string workspace_id = 2;
+ * @return {string}
+ */
+proto.contentservice.ListBackupsRequest.prototype.getWorkspaceId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.ListBackupsRequest} returns this
+ */
+proto.contentservice.ListBackupsRequest.prototype.setWorkspaceId = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.contentservice.ListBackupsResponse.repeatedFields_ = [1];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.contentservice.ListBackupsResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.contentservice.ListBackupsResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.contentservice.ListBackupsResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.ListBackupsResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    backupsList: jspb.Message.toObjectList(msg.getBackupsList(),
+    proto.contentservice.WorkspaceBackup.toObject, includeInstance)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.contentservice.ListBackupsResponse}
+ */
+proto.contentservice.ListBackupsResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.contentservice.ListBackupsResponse;
+  return proto.contentservice.ListBackupsResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.contentservice.ListBackupsResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.contentservice.ListBackupsResponse}
+ */
+proto.contentservice.ListBackupsResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.contentservice.WorkspaceBackup;
+      reader.readMessage(value,proto.contentservice.WorkspaceBackup.deserializeBinaryFromReader);
+      msg.addBackups(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.contentservice.ListBackupsResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.contentservice.ListBackupsResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.contentservice.ListBackupsResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.ListBackupsResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getBackupsList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      1,
+      f,
+      proto.contentservice.WorkspaceBackup.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * repeated WorkspaceBackup backups = 1;
+ * @return {!Array<!proto.contentservice.WorkspaceBackup>}
+ */
+proto.contentservice.ListBackupsResponse.prototype.getBackupsList = function() {
+  return /** @type{!Array<!proto.contentservice.WorkspaceBackup>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.contentservice.WorkspaceBackup, 1));
+};
+
+
+/**
+ * @param {!Array<!proto.contentservice.WorkspaceBackup>} value
+ * @return {!proto.contentservice.ListBackupsResponse} returns this
+*/
+proto.contentservice.ListBackupsResponse.prototype.setBackupsList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 1, value);
+};
+
+
+/**
+ * @param {!proto.contentservice.WorkspaceBackup=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.contentservice.WorkspaceBackup}
+ */
+proto.contentservice.ListBackupsResponse.prototype.addBackups = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 1, opt_value, proto.contentservice.WorkspaceBackup, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.contentservice.ListBackupsResponse} returns this
+ */
+proto.contentservice.ListBackupsResponse.prototype.clearBackupsList = function() {
+  return this.setBackupsList([]);
+};
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.contentservice.WorkspaceBackup.prototype.toObject = function(opt_includeInstance) {
+  return proto.contentservice.WorkspaceBackup.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.contentservice.WorkspaceBackup} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.WorkspaceBackup.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    id: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    created: jspb.Message.getFieldWithDefault(msg, 2, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.contentservice.WorkspaceBackup}
+ */
+proto.contentservice.WorkspaceBackup.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.contentservice.WorkspaceBackup;
+  return proto.contentservice.WorkspaceBackup.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.contentservice.WorkspaceBackup} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.contentservice.WorkspaceBackup}
+ */
+proto.contentservice.WorkspaceBackup.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setId(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setCreated(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.contentservice.WorkspaceBackup.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.contentservice.WorkspaceBackup.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.contentservice.WorkspaceBackup} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.WorkspaceBackup.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getId();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getCreated();
+  if (f !== 0) {
+    writer.writeInt64(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string id = 1;
+ * @return {string}
+ */
+proto.contentservice.WorkspaceBackup.prototype.getId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.WorkspaceBackup} returns this
+ */
+proto.contentservice.WorkspaceBackup.prototype.setId = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional int64 created = 2;
+ * @return {number}
+ */
+proto.contentservice.WorkspaceBackup.prototype.getCreated = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.contentservice.WorkspaceBackup} returns this
+ */
+proto.contentservice.WorkspaceBackup.prototype.setCreated = function(value) {
+  return jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
 goog.object.extend(exports, proto.contentservice);
@@ -15,6 +15,7 @@ func main() {
 
 	ts, err := bel.Extract(api.WorkspaceReadyMessage{},
 		bel.WithEnumerations(handler),
+		bel.FollowStructs,
 	)
 	if err != nil {
 		panic(err)
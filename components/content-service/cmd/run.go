@@ -21,7 +21,9 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/common-go/pprof"
 	"github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/api/config"
 	"github.com/gitpod-io/gitpod/content-service/pkg/service"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 )
 
 // runCmd starts the content service
@@ -98,6 +100,21 @@ var runCmd = &cobra.Command{
 		}()
 		log.WithField("addr", cfg.Service.Addr).Info("started gRPC server")
 
+		if fsCfg := cfg.Storage.FileSystemConfig; cfg.Storage.Kind == config.FileSystemStorage && fsCfg.Addr != "" {
+			handler, err := storage.NewFileSystemHandler(fsCfg)
+			if err != nil {
+				log.WithError(err).Fatal("cannot create filesystem storage handler")
+			}
+
+			go func() {
+				err := http.ListenAndServe(fsCfg.Addr, handler)
+				if err != nil {
+					log.WithError(err).Fatal("filesystem storage server failed")
+				}
+			}()
+			log.WithField("addr", fsCfg.Addr).Info("started filesystem storage server")
+		}
+
 		if cfg.Prometheus.Addr != "" {
 			reg.MustRegister(
 				prometheus.NewGoCollector(),
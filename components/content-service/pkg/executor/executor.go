@@ -20,12 +20,27 @@ type config struct {
 	URLs       map[string]string `json:"urls,omitempty"`
 	Req        json.RawMessage   `json:"req,omitempty"`
 	FromBackup string            `json:"fromBackupURL,omitempty"`
+
+	FromChunkedBackup map[string]string `json:"fromChunkedBackupURLs,omitempty"`
+
+	DataKeys storage.DataKeys `json:"dataKeys,omitempty"`
 }
 
-// PrepareFromBackup produces executor config to restore a backup
-func PrepareFromBackup(url string) ([]byte, error) {
+// PrepareFromBackup produces executor config to restore a backup. The data keys decrypt the backup
+// if it is encrypted and can be nil otherwise.
+func PrepareFromBackup(url string, keys storage.DataKeys) ([]byte, error) {
 	return json.Marshal(config{
 		FromBackup: url,
+		DataKeys:   keys,
+	})
+}
+
+// PrepareFromChunkedBackup produces executor config to restore a chunked backup. The URLs map the
+// chunk index and chunk names to their download URL.
+func PrepareFromChunkedBackup(urls map[string]string, keys storage.DataKeys) ([]byte, error) {
+	return json.Marshal(config{
+		FromChunkedBackup: urls,
+		DataKeys:          keys,
 	})
 }
 
@@ -51,18 +66,26 @@ func Execute(ctx context.Context, destination string, cfgin io.Reader, forceGitU
 		return "", err
 	}
 
+	var keyring storage.Keyring
+	if len(cfg.DataKeys) > 0 {
+		keyring = cfg.DataKeys
+	}
+
 	var (
 		rs  storage.DirectDownloader
 		ilr initializer.Initializer
 	)
-	if cfg.FromBackup == "" {
+	if len(cfg.FromChunkedBackup) > 0 {
+		rs = &storage.NamedURLDownloader{URLs: cfg.FromChunkedBackup, Keyring: keyring}
+		ilr = &initializer.EmptyInitializer{}
+	} else if cfg.FromBackup == "" {
 		var req csapi.WorkspaceInitializer
 		err = protojson.Unmarshal(cfg.Req, &req)
 		if err != nil {
 			return "", err
 		}
 
-		rs = &storage.NamedURLDownloader{URLs: cfg.URLs}
+		rs = &storage.NamedURLDownloader{URLs: cfg.URLs, Keyring: keyring}
 		ilr, err = initializer.NewFromRequest(ctx, destination, rs, &req, initializer.NewFromRequestOpts{
 			ForceGitpodUserForGit: forceGitUser,
 		})
@@ -74,6 +97,7 @@ func Execute(ctx context.Context, destination string, cfgin io.Reader, forceGitU
 			URLs: map[string]string{
 				storage.DefaultBackup: cfg.FromBackup,
 			},
+			Keyring: keyring,
 		}
 		ilr = &initializer.EmptyInitializer{}
 	}
@@ -83,7 +107,7 @@ func Execute(ctx context.Context, destination string, cfgin io.Reader, forceGitU
 		return "", err
 	}
 
-	err = initializer.PlaceWorkspaceReadyFile(ctx, destination, src, initializer.GitpodUID, initializer.GitpodGID)
+	err = initializer.PlaceWorkspaceReadyFile(ctx, destination, src, ilr, initializer.GitpodUID, initializer.GitpodGID)
 	if err != nil {
 		return src, err
 	}
@@ -12,6 +12,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/opentracing/opentracing-go"
@@ -51,6 +52,20 @@ const (
 	BasicAuth AuthMethod = "basic-auth"
 )
 
+// LFSMode determines which Git LFS objects are fetched
+type LFSMode string
+
+const (
+	// LFSSkip leaves all Git LFS pointers unresolved
+	LFSSkip LFSMode = ""
+
+	// LFSFetchAll fetches all Git LFS objects of the checked out revision
+	LFSFetchAll LFSMode = "all"
+
+	// LFSFetchInclude fetches the Git LFS objects of the checked out revision which match the LFS include patterns
+	LFSFetchInclude LFSMode = "include"
+)
+
 // CachingAuthProvider caches the first non-erroneous response of the delegate auth provider
 func CachingAuthProvider(d AuthProvider) AuthProvider {
 	var (
@@ -92,6 +107,26 @@ type Client struct {
 
 	// UpstreamCloneURI is the fork upstream of a repository
 	UpstreamRemoteURI string
+
+	// CloneDepth truncates the history of a clone to this many commits. Zero clones the full history.
+	CloneDepth int
+
+	// CloneFilter makes a clone a partial clone using this filter spec, e.g. blob:none
+	CloneFilter string
+
+	// SparseCheckoutPatterns restricts the working copy to these directories (cone mode)
+	SparseCheckoutPatterns []string
+
+	// ReferenceRepository is a local repository a clone borrows objects from to reduce the amount of data
+	// fetched from the remote (see git clone --reference). The clone does not depend on the reference repository
+	// once it's done. Clones ignore a reference repository which does not exist.
+	ReferenceRepository string
+
+	// LFS determines which Git LFS objects LFSPull fetches
+	LFS LFSMode
+
+	// LFSIncludePatterns selects the Git LFS objects fetched in LFSFetchInclude mode
+	LFSIncludePatterns []string
 }
 
 // Status describes the status of a Git repo/working copy akin to "git status"
@@ -145,6 +180,16 @@ func (e OpFailedError) Error() string {
 	return fmt.Sprintf("git %s %s failed (%v): %v", e.Subcommand, strings.Join(e.Args, " "), e.ExecErr, e.Output)
 }
 
+// checkoutSubcommands are the git subcommands which check out files, i.e. would smudge Git LFS pointers
+var checkoutSubcommands = map[string]bool{
+	"clone":           true,
+	"checkout":        true,
+	"reset":           true,
+	"sparse-checkout": true,
+	"stash":           true,
+	"submodule":       true,
+}
+
 // GitWithOutput starts git and returns the stdout of the process. This function returns once git is started,
 // not after it finishd. Once the returned reader returned io.EOF, the command is finished.
 func (c *Client) GitWithOutput(ctx context.Context, subcommand string, args ...string) (out []byte, err error) {
@@ -173,6 +218,10 @@ func (c *Client) GitWithOutput(ctx context.Context, subcommand string, args ...s
 	fullArgs = append(fullArgs, args...)
 
 	env = append(env, fmt.Sprintf("PATH=%s", os.Getenv("PATH")))
+	if checkoutSubcommands[subcommand] {
+		// Git LFS objects are never fetched implicitly during checkout, but as the LFS mode says using LFSPull
+		env = append(env, "GIT_LFS_SKIP_SMUDGE=1")
+	}
 	if os.Getenv("http_proxy") != "" {
 		env = append(env, fmt.Sprintf("http_proxy=%s", os.Getenv("http_proxy")))
 	}
@@ -272,16 +321,113 @@ func (c *Client) Clone(ctx context.Context) (err error) {
 		args = append(args, strings.TrimSpace(key)+"="+strings.TrimSpace(value))
 	}
 
-	// TODO(cw): re-introduce this when we have a better story for repo forking
-	// args = append(args, "--filter=blob:none")
+	if c.CloneDepth > 0 {
+		// --depth implies --single-branch, but we might want to check out any remote branch later
+		args = append(args, "--depth", strconv.Itoa(c.CloneDepth), "--no-single-branch")
+	}
+	if c.CloneFilter != "" {
+		args = append(args, "--filter="+c.CloneFilter)
+	}
+	if c.ReferenceRepository != "" {
+		args = append(args, "--reference-if-able", c.ReferenceRepository, "--dissociate")
+	}
+	if len(c.SparseCheckoutPatterns) > 0 {
+		// the working copy is populated once the sparse checkout is set up and the clone target is realized
+		args = append(args, "--no-checkout")
+	}
 
 	args = append(args, ".")
 
-	return c.Git(ctx, "clone", args...)
+	err = c.Git(ctx, "clone", args...)
+	if err != nil {
+		return err
+	}
+
+	return c.SparseCheckout(ctx)
+}
+
+// SparseCheckout restricts the working copy to the sparse checkout patterns. Does nothing if there are no patterns.
+func (c *Client) SparseCheckout(ctx context.Context) (err error) {
+	if len(c.SparseCheckoutPatterns) == 0 {
+		return nil
+	}
+
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sparseCheckout")
+	span.SetTag("patterns", c.SparseCheckoutPatterns)
+	defer tracing.FinishSpan(span, &err)
+
+	if err := c.Git(ctx, "sparse-checkout", "init", "--cone"); err != nil {
+		return err
+	}
+	return c.Git(ctx, "sparse-checkout", append([]string{"set"}, c.SparseCheckoutPatterns...)...)
+}
+
+// LFSPull fetches the Git LFS objects of the checked out revision which the LFS mode selects, and replaces
+// their pointers in the working copy. Returns the number of bytes fetched. Does nothing in LFSSkip mode.
+func (c *Client) LFSPull(ctx context.Context) (fetched int64, err error) {
+	if c.LFS == LFSSkip {
+		return 0, nil
+	}
+
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "lfsPull")
+	span.SetTag("mode", c.LFS)
+	defer tracing.FinishSpan(span, &err)
+
+	args := []string{"pull"}
+	switch c.LFS {
+	case LFSFetchAll:
+	case LFSFetchInclude:
+		if len(c.LFSIncludePatterns) == 0 {
+			return 0, xerrors.Errorf("LFS mode %s requires include patterns", c.LFS)
+		}
+		args = append(args, "--include="+strings.Join(c.LFSIncludePatterns, ","))
+	default:
+		return 0, xerrors.Errorf("unknown LFS mode: %s", c.LFS)
+	}
+
+	objects := filepath.Join(c.Location, ".git", "lfs", "objects")
+	before, err := dirSize(objects)
+	if err != nil {
+		return 0, err
+	}
+	err = c.Git(ctx, "lfs", args...)
+	if err != nil {
+		return 0, err
+	}
+	after, err := dirSize(objects)
+	if err != nil {
+		return 0, err
+	}
+
+	fetched = after - before
+	span.SetTag("fetchedBytes", fetched)
+	return fetched, nil
+}
+
+// dirSize returns the total size of all regular files in a directory. A directory which does not exist has size zero.
+func dirSize(dir string) (size int64, err error) {
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return
 }
 
 // Fetch runs git fetch
 func (c *Client) Fetch(ctx context.Context) (err error) {
+	if c.CloneDepth > 0 {
+		return c.Git(ctx, "fetch", "--depth", strconv.Itoa(c.CloneDepth))
+	}
 	return c.Git(ctx, "fetch")
 }
 
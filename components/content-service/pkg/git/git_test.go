@@ -8,6 +8,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -280,3 +281,162 @@ func initFromRemote(ctx context.Context, c *Client) error {
 
 	return nil
 }
+
+func TestClone(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Depth         int
+		Filter        string
+		Sparse        []string
+		Reference     bool
+		ExpectedCount string
+		ExpectedFiles []string
+	}{
+		{Name: "full clone", ExpectedCount: "3", ExpectedFiles: []string{"a/file", "b/file", "c/file"}},
+		{Name: "shallow clone", Depth: 1, ExpectedCount: "1", ExpectedFiles: []string{"a/file", "b/file", "c/file"}},
+		{Name: "partial clone", Filter: "blob:none", ExpectedCount: "3", ExpectedFiles: []string{"a/file", "b/file", "c/file"}},
+		{Name: "sparse checkout", Depth: 1, Filter: "blob:none", Sparse: []string{"a", "c"}, ExpectedCount: "1", ExpectedFiles: []string{"a/file", "c/file"}},
+		{Name: "reference repository", Reference: true, ExpectedCount: "3", ExpectedFiles: []string{"a/file", "b/file", "c/file"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			remote, err := newGitClient(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := remote.Git(ctx, "init"); err != nil {
+				t.Fatal(err)
+			}
+			if err := remote.Git(ctx, "config", "--local", "uploadpack.allowFilter", "true"); err != nil {
+				t.Fatal(err)
+			}
+			for _, dir := range []string{"a", "b", "c"} {
+				if err := os.MkdirAll(filepath.Join(remote.Location, dir), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(remote.Location, dir, "file"), []byte(dir), 0644); err != nil {
+					t.Fatal(err)
+				}
+				if err := remote.Git(ctx, "add", dir); err != nil {
+					t.Fatal(err)
+				}
+				if err := remote.Git(ctx, "-c", "user.email=foo@bar.com", "-c", "user.name=foo bar", "commit", "-m", dir); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			client, err := newGitClient(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// depth and filter are ignored for local clones which don't use the file:// transport
+			client.RemoteURI = "file://" + remote.Location
+			client.CloneDepth = test.Depth
+			client.CloneFilter = test.Filter
+			client.SparseCheckoutPatterns = test.Sparse
+			if test.Reference {
+				client.ReferenceRepository = remote.Location
+			}
+			if err := client.Clone(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := os.Stat(filepath.Join(client.Location, ".git", "objects", "info", "alternates")); err == nil {
+				t.Errorf("clone depends on its reference repository")
+			}
+			if err := client.Git(ctx, "reset", "--hard", "origin/HEAD"); err != nil {
+				t.Fatal(err)
+			}
+
+			out, err := client.GitWithOutput(ctx, "rev-list", "--count", "HEAD")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count := strings.TrimSpace(string(out)); count != test.ExpectedCount {
+				t.Errorf("unexpected history length: got %s, expected %s", count, test.ExpectedCount)
+			}
+			if test.Filter != "" {
+				out, err := client.GitWithOutput(ctx, "config", "remote.origin.partialclonefilter")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if filter := strings.TrimSpace(string(out)); filter != test.Filter {
+					t.Errorf("unexpected partial clone filter: got %s, expected %s", filter, test.Filter)
+				}
+			}
+
+			var files []string
+			for _, dir := range []string{"a", "b", "c"} {
+				if _, err := os.Stat(filepath.Join(client.Location, dir, "file")); err == nil {
+					files = append(files, dir+"/file")
+				}
+			}
+			if diff := cmp.Diff(test.ExpectedFiles, files); diff != "" {
+				t.Errorf("unexpected working copy (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLFSPull(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Mode          LFSMode
+		Patterns      []string
+		ExpectedBytes int64
+		ExpectError   bool
+	}{
+		{Name: "skip", Mode: LFSSkip},
+		{Name: "include without patterns", Mode: LFSFetchInclude, ExpectError: true},
+		{Name: "unknown mode", Mode: LFSMode("foobar"), ExpectError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			client, err := newGitClient(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client.LFS = test.Mode
+			client.LFSIncludePatterns = test.Patterns
+
+			fetched, err := client.LFSPull(ctx)
+			if (err != nil) != test.ExpectError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fetched != test.ExpectedBytes {
+				t.Errorf("unexpected fetched bytes: got %d, expected %d", fetched, test.ExpectedBytes)
+			}
+		})
+	}
+}
+
+func TestDirSize(t *testing.T) {
+	dir, err := os.MkdirTemp("", "dirsize")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if size, err := dirSize(filepath.Join(dir, "does-not-exist")); err != nil || size != 0 {
+		t.Errorf("unexpected size of non-existent directory: %d (%v)", size, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "a", "b"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{"a/file", "a/b/file"} {
+		if err := os.WriteFile(filepath.Join(dir, f), []byte("12345"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if size, err := dirSize(dir); err != nil || size != 10 {
+		t.Errorf("unexpected directory size: got %d, expected 10 (%v)", size, err)
+	}
+}
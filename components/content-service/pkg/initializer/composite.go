@@ -0,0 +1,203 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package initializer
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+)
+
+// CompositeInitializer runs a collection of initializer. Initializer whose checkout locations overlap
+// run in the order they're provided, all other initializer run concurrently.
+type CompositeInitializer struct {
+	// Location is the workspace root. Checkout locations are reported relative to it.
+	Location string
+
+	Initializer []Initializer
+
+	// BestEffort marks initializer whose failure does not fail the composite initializer,
+	// i.e. BestEffort[i] applies to Initializer[i]. Initializer without entry are required.
+	BestEffort []bool
+
+	report []csapi.InitializerReport
+}
+
+// Run calls run on all child initializers and fails if any required child initializer fails
+func (e *CompositeInitializer) Run(ctx context.Context, mappings []archive.IDMapping) (src csapi.WorkspaceInitSource, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "CompositeInitializer.Run")
+	defer tracing.FinishSpan(span, &err)
+
+	var (
+		n      = len(e.Initializer)
+		locs   = make([]string, n)
+		done   = make([]chan struct{}, n)
+		errs   = make([]error, n)
+		report = make([]csapi.InitializerReport, n)
+		wg     sync.WaitGroup
+	)
+	for i, init := range e.Initializer {
+		locs[i] = checkoutLocation(init)
+		done[i] = make(chan struct{})
+		report[i] = csapi.InitializerReport{
+			Kind:       initializerKind(init),
+			Location:   e.relativeLocation(locs[i]),
+			BestEffort: i < len(e.BestEffort) && e.BestEffort[i],
+		}
+
+		// initializer which write to the same part of the workspace must not run concurrently
+		var deps []chan struct{}
+		for j := 0; j < i; j++ {
+			if locationsOverlap(locs[i], locs[j]) {
+				deps = append(deps, done[j])
+			}
+		}
+
+		wg.Add(1)
+		go func(i int, init Initializer, deps []chan struct{}) {
+			defer wg.Done()
+			defer close(done[i])
+
+			for _, dep := range deps {
+				<-dep
+			}
+
+			start := time.Now()
+			src, err := init.Run(ctx, mappings)
+			report[i].DurationMillis = time.Since(start).Milliseconds()
+			if err != nil {
+				errs[i] = err
+				report[i].Error = err.Error()
+				return
+			}
+			report[i].Source = src
+			report[i].LFSBytes = LFSBytes(init)
+		}(i, init, deps)
+	}
+	wg.Wait()
+	e.report = report
+	span.LogKV("report", report)
+
+	var (
+		failed  []string
+		lastErr error
+	)
+	src = csapi.WorkspaceInitFromOther
+	for i, err := range errs {
+		if err == nil {
+			src = combineInitSource(src, report[i].Source)
+			continue
+		}
+		if report[i].BestEffort {
+			log.WithError(err).WithField("kind", report[i].Kind).WithField("location", report[i].Location).Warn("best-effort initializer failed")
+			continue
+		}
+		failed = append(failed, fmt.Sprintf("%s initializer %d: %v", report[i].Kind, i, err))
+		lastErr = err
+	}
+	switch len(failed) {
+	case 0:
+		return src, nil
+	case 1:
+		return src, xerrors.Errorf("composite initializer failed: %w", lastErr)
+	default:
+		return src, xerrors.Errorf("%d initializer failed: %s", len(failed), strings.Join(failed, "; "))
+	}
+}
+
+// Report returns how the individual initializer fared during the last run
+func (e *CompositeInitializer) Report() []csapi.InitializerReport {
+	return e.report
+}
+
+func (e *CompositeInitializer) relativeLocation(loc string) string {
+	if loc == "" || e.Location == "" {
+		return loc
+	}
+	rel, err := filepath.Rel(e.Location, loc)
+	if err != nil || rel == "." {
+		return ""
+	}
+	return rel
+}
+
+// InitializerReport returns the report of a composite initializer after it ran. All other initializer have no report.
+func InitializerReport(i Initializer) []csapi.InitializerReport {
+	c, ok := i.(*CompositeInitializer)
+	if !ok {
+		return nil
+	}
+	return c.Report()
+}
+
+// checkoutLocator is implemented by initializer which write to a part of the workspace only
+type checkoutLocator interface {
+	// checkoutLocation returns the location the initializer writes to.
+	// An empty location means the initializer can write anywhere in the workspace.
+	checkoutLocation() string
+}
+
+func checkoutLocation(init Initializer) string {
+	l, ok := init.(checkoutLocator)
+	if !ok {
+		return ""
+	}
+	return l.checkoutLocation()
+}
+
+// locationsOverlap returns true if one location contains the other
+func locationsOverlap(a, b string) bool {
+	if a == "" || b == "" {
+		return true
+	}
+	contains := func(parent, child string) bool {
+		rel, err := filepath.Rel(parent, child)
+		return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
+	}
+	return contains(a, b) || contains(b, a)
+}
+
+func initializerKind(init Initializer) string {
+	switch init.(type) {
+	case *EmptyInitializer:
+		return "empty"
+	case *CompositeInitializer:
+		return "composite"
+	case *GitInitializer:
+		return "git"
+	case *PrebuildInitializer:
+		return "prebuild"
+	case *SnapshotInitializer:
+		return "snapshot"
+	case *fileDownloadInitializer:
+		return "download"
+	case *fromBackupInitializer:
+		return "backup"
+	default:
+		return "other"
+	}
+}
+
+// combineInitSource combines the init sources of two initializer: a prebuild takes precedence over a backup,
+// which takes precedence over all other sources.
+func combineInitSource(a, b csapi.WorkspaceInitSource) csapi.WorkspaceInitSource {
+	for _, s := range []csapi.WorkspaceInitSource{csapi.WorkspaceInitFromPrebuild, csapi.WorkspaceInitFromBackup} {
+		if a == s || b == s {
+			return s
+		}
+	}
+	return csapi.WorkspaceInitFromOther
+}
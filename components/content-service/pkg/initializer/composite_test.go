@@ -0,0 +1,182 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package initializer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+)
+
+type recordingInitializer struct {
+	Name     string
+	Location string
+	Source   csapi.WorkspaceInitSource
+	Err      error
+	Delay    time.Duration
+
+	mu  *sync.Mutex
+	log *[]string
+}
+
+func (r *recordingInitializer) checkoutLocation() string {
+	return r.Location
+}
+
+func (r *recordingInitializer) Run(ctx context.Context, mappings []archive.IDMapping) (csapi.WorkspaceInitSource, error) {
+	r.mu.Lock()
+	*r.log = append(*r.log, "start "+r.Name)
+	r.mu.Unlock()
+
+	time.Sleep(r.Delay)
+
+	r.mu.Lock()
+	*r.log = append(*r.log, "end "+r.Name)
+	r.mu.Unlock()
+	return r.Source, r.Err
+}
+
+func TestCompositeInitializer(t *testing.T) {
+	type child struct {
+		Name       string
+		Location   string
+		Source     csapi.WorkspaceInitSource
+		Fail       bool
+		BestEffort bool
+	}
+	tests := []struct {
+		Name           string
+		Children       []child
+		ExpectedLog    []string
+		Concurrent     bool
+		ExpectedSource csapi.WorkspaceInitSource
+		ExpectError    bool
+	}{
+		{
+			Name: "distinct locations run concurrently",
+			Children: []child{
+				{Name: "a", Location: "/workspace/a", Source: csapi.WorkspaceInitFromOther},
+				{Name: "b", Location: "/workspace/b", Source: csapi.WorkspaceInitFromOther},
+			},
+			Concurrent:     true,
+			ExpectedSource: csapi.WorkspaceInitFromOther,
+		},
+		{
+			Name: "overlapping locations run in order",
+			Children: []child{
+				{Name: "a", Location: "/workspace/a", Source: csapi.WorkspaceInitFromOther},
+				{Name: "b", Location: "/workspace/a/b", Source: csapi.WorkspaceInitFromPrebuild},
+			},
+			ExpectedLog:    []string{"start a", "end a", "start b", "end b"},
+			ExpectedSource: csapi.WorkspaceInitFromPrebuild,
+		},
+		{
+			Name: "required failure",
+			Children: []child{
+				{Name: "a", Location: "/workspace/a", Source: csapi.WorkspaceInitFromOther},
+				{Name: "b", Location: "/workspace/b", Fail: true},
+			},
+			ExpectError:    true,
+			ExpectedSource: csapi.WorkspaceInitFromOther,
+		},
+		{
+			Name: "best-effort failure",
+			Children: []child{
+				{Name: "a", Location: "/workspace/a", Source: csapi.WorkspaceInitFromBackup},
+				{Name: "b", Location: "/workspace/b", Fail: true, BestEffort: true},
+			},
+			ExpectedSource: csapi.WorkspaceInitFromBackup,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				mu   sync.Mutex
+				log  []string
+				comp = &CompositeInitializer{Location: "/workspace"}
+			)
+			for i, c := range test.Children {
+				var err error
+				if c.Fail {
+					err = xerrors.Errorf("%s failed", c.Name)
+				}
+				comp.Initializer = append(comp.Initializer, &recordingInitializer{
+					Name:     c.Name,
+					Location: c.Location,
+					Source:   c.Source,
+					Err:      err,
+					Delay:    time.Duration(i+1) * 50 * time.Millisecond,
+					mu:       &mu,
+					log:      &log,
+				})
+				comp.BestEffort = append(comp.BestEffort, c.BestEffort)
+			}
+
+			src, err := comp.Run(context.Background(), nil)
+			if (err != nil) != test.ExpectError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if src != test.ExpectedSource {
+				t.Errorf("unexpected init source: got %s, expected %s", src, test.ExpectedSource)
+			}
+			if test.Concurrent && (len(log) != 4 || log[1][:5] != "start") {
+				t.Errorf("initializer did not run concurrently: %v", log)
+			}
+			if test.ExpectedLog != nil {
+				if len(log) != len(test.ExpectedLog) {
+					t.Fatalf("unexpected run order: got %v, expected %v", log, test.ExpectedLog)
+				}
+				for i := range log {
+					if log[i] != test.ExpectedLog[i] {
+						t.Fatalf("unexpected run order: got %v, expected %v", log, test.ExpectedLog)
+					}
+				}
+			}
+
+			report := comp.Report()
+			if len(report) != len(test.Children) {
+				t.Fatalf("unexpected report: %v", report)
+			}
+			for i, c := range test.Children {
+				if c.Fail != (report[i].Error != "") {
+					t.Errorf("child %s: unexpected error in report: %q", c.Name, report[i].Error)
+				}
+				if report[i].BestEffort != c.BestEffort {
+					t.Errorf("child %s: unexpected best-effort flag in report", c.Name)
+				}
+				if report[i].DurationMillis <= 0 {
+					t.Errorf("child %s: no duration in report", c.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestLocationsOverlap(t *testing.T) {
+	tests := []struct {
+		A, B     string
+		Expected bool
+	}{
+		{"", "/workspace/a", true},
+		{"/workspace/a", "/workspace/a", true},
+		{"/workspace/a", "/workspace/a/b", true},
+		{"/workspace/a/b", "/workspace/a", true},
+		{"/workspace/a", "/workspace/b", false},
+		{"/workspace/a", "/workspace/ab", false},
+		{"/workspace", "/workspace/a", true},
+	}
+	for _, test := range tests {
+		if act := locationsOverlap(test.A, test.B); act != test.Expected {
+			t.Errorf("locationsOverlap(%q, %q) = %v, expected %v", test.A, test.B, act, test.Expected)
+		}
+	}
+}
@@ -44,6 +44,10 @@ type fileDownloadInitializer struct {
 	RetryTimeout   time.Duration
 }
 
+func (ws *fileDownloadInitializer) checkoutLocation() string {
+	return ws.TargetLocation
+}
+
 // Run initializes the workspace
 func (ws *fileDownloadInitializer) Run(ctx context.Context, mappings []archive.IDMapping) (src csapi.WorkspaceInitSource, err error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "FileDownloadInitializer.Run")
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 
@@ -52,6 +53,13 @@ type GitInitializer struct {
 
 	// If true, the Git initializer will chown(gitpod) after the clone
 	Chown bool
+
+	// lfsBytes is the number of Git LFS bytes fetched during the last run
+	lfsBytes int64
+}
+
+func (ws *GitInitializer) checkoutLocation() string {
+	return ws.Location
 }
 
 // Run initializes the workspace using Git
@@ -116,6 +124,9 @@ func (ws *GitInitializer) Run(ctx context.Context, mappings []archive.IDMapping)
 	if err := ws.realizeCloneTarget(ctx); err != nil {
 		return src, xerrors.Errorf("git initializer: %w", err)
 	}
+	if ws.lfsBytes, err = ws.LFSPull(ctx); err != nil {
+		return src, xerrors.Errorf("git initializer: %w", err)
+	}
 	if err := ws.UpdateRemote(ctx); err != nil {
 		return src, xerrors.Errorf("git initializer: %w", err)
 	}
@@ -127,6 +138,28 @@ func (ws *GitInitializer) Run(ctx context.Context, mappings []archive.IDMapping)
 	return
 }
 
+// LFSBytes returns the number of Git LFS bytes an initializer fetched during its last run.
+// Composite initializer report the sum of their children.
+func LFSBytes(i Initializer) int64 {
+	switch init := i.(type) {
+	case *GitInitializer:
+		return init.lfsBytes
+	case *PrebuildInitializer:
+		if init.Git == nil {
+			return 0
+		}
+		return init.Git.lfsBytes
+	case *CompositeInitializer:
+		var total int64
+		for _, c := range init.Initializer {
+			total += LFSBytes(c)
+		}
+		return total
+	default:
+		return 0
+	}
+}
+
 // realizeCloneTarget ensures the clone target is checked out
 func (ws *GitInitializer) realizeCloneTarget(ctx context.Context) (err error) {
 	//nolint:ineffassign
@@ -148,6 +181,12 @@ func (ws *GitInitializer) realizeCloneTarget(ctx context.Context) (err error) {
 			return err
 		}
 	} else if ws.TargetMode == RemoteCommit {
+		if ws.CloneDepth > 0 {
+			// the commit is likely not part of a shallow clone's history
+			if err := ws.Git(ctx, "fetch", "--depth", strconv.Itoa(ws.CloneDepth), "origin", ws.CloneTarget); err != nil {
+				return err
+			}
+		}
 		// checkout specific commit
 		if err := ws.Git(ctx, "checkout", ws.CloneTarget); err != nil {
 			return err
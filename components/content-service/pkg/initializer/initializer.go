@@ -56,20 +56,6 @@ func (e *EmptyInitializer) Run(ctx context.Context, mappings []archive.IDMapping
 	return csapi.WorkspaceInitFromOther, nil
 }
 
-// CompositeInitializer does nothing
-type CompositeInitializer struct {
-	Initializer []Initializer
-}
-
-// Run calls run on all child initializers
-func (e *CompositeInitializer) Run(ctx context.Context, mappings []archive.IDMapping) (csapi.WorkspaceInitSource, error) {
-	_, ctx = opentracing.StartSpanFromContext(ctx, "CompositeInitializer.Run")
-	for _, init := range e.Initializer {
-		init.Run(ctx, mappings)
-	}
-	return csapi.WorkspaceInitFromOther, nil
-}
-
 // NewFromRequestOpts configures the initializer produced from a content init request
 type NewFromRequestOpts struct {
 	// ForceGitpodUserForGit forces gitpod:gitpod ownership on all files produced by the Git initializer.
@@ -77,6 +63,10 @@ type NewFromRequestOpts struct {
 	// Git content is forced to the Gitpod user. All other content (backup, prebuild, snapshot) will already
 	// have the correct user.
 	ForceGitpodUserForGit bool
+
+	// GitReferences maps Git remote URIs to local repositories which clones of that remote borrow objects from.
+	// See git.Client.ReferenceRepository.
+	GitReferences map[string]string
 }
 
 // NewFromRequest picks the initializer from the request but does not execute it.
@@ -92,22 +82,29 @@ func NewFromRequest(ctx context.Context, loc string, rs storage.DirectDownloader
 	if _, ok := spec.(*csapi.WorkspaceInitializer_Empty); ok {
 		initializer = &EmptyInitializer{}
 	} else if ir, ok := spec.(*csapi.WorkspaceInitializer_Composite); ok {
+		if len(ir.Composite.Policy) > len(ir.Composite.Initializer) {
+			return nil, status.Error(codes.InvalidArgument, "composite initializer has more policies than initializer")
+		}
 		initializers := make([]Initializer, len(ir.Composite.Initializer))
+		bestEffort := make([]bool, len(ir.Composite.Initializer))
 		for i, init := range ir.Composite.Initializer {
 			initializers[i], err = NewFromRequest(ctx, loc, rs, init, opts)
 			if err != nil {
 				return nil, err
 			}
+			bestEffort[i] = i < len(ir.Composite.Policy) && ir.Composite.Policy[i] == csapi.InitializerPolicy_BEST_EFFORT
 		}
 		initializer = &CompositeInitializer{
+			Location:    loc,
 			Initializer: initializers,
+			BestEffort:  bestEffort,
 		}
 	} else if ir, ok := spec.(*csapi.WorkspaceInitializer_Git); ok {
 		if ir.Git == nil {
 			return nil, status.Error(codes.InvalidArgument, "missing Git initializer spec")
 		}
 
-		initializer, err = newGitInitializer(ctx, loc, ir.Git, opts.ForceGitpodUserForGit)
+		initializer, err = newGitInitializer(ctx, loc, ir.Git, opts)
 	} else if ir, ok := spec.(*csapi.WorkspaceInitializer_Prebuild); ok {
 		if ir.Prebuild == nil {
 			return nil, status.Error(codes.InvalidArgument, "missing prebuild initializer spec")
@@ -116,7 +113,7 @@ func NewFromRequest(ctx context.Context, loc string, rs storage.DirectDownloader
 			return nil, status.Error(codes.InvalidArgument, "missing prebuild Git initializer spec")
 		}
 
-		gitinit, err := newGitInitializer(ctx, loc, ir.Prebuild.Git, opts.ForceGitpodUserForGit)
+		gitinit, err := newGitInitializer(ctx, loc, ir.Prebuild.Git, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -172,19 +169,59 @@ func newFileDownloadInitializer(loc string, req *csapi.FileDownloadInitializer)
 
 // newFromBackupInitializer creates a backup restoration initializer for a request
 func newFromBackupInitializer(loc string, rs storage.DirectDownloader, req *csapi.FromBackupInitializer) (*fromBackupInitializer, error) {
+	if req.BackupId != "" {
+		if _, _, err := storage.BackupObjects(req.BackupId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	var pointInTime time.Time
+	if req.BackupId == "" && req.Timestamp > 0 {
+		pointInTime = time.Unix(req.Timestamp, 0)
+	}
 	return &fromBackupInitializer{
 		Location:      loc,
 		RemoteStorage: rs,
+		BackupID:      req.BackupId,
+		PointInTime:   pointInTime,
 	}, nil
 }
 
 type fromBackupInitializer struct {
 	Location      string
 	RemoteStorage storage.DirectDownloader
+
+	// BackupID selects a backup from the backup history or trail. If empty, the most recent backup is restored.
+	BackupID string
+	// PointInTime, if not zero, selects the most recent backup from the backup history or trail made before that time.
+	PointInTime time.Time
 }
 
 func (bi *fromBackupInitializer) Run(ctx context.Context, mappings []archive.IDMapping) (src csapi.WorkspaceInitSource, err error) {
-	hasBackup, err := bi.RemoteStorage.Download(ctx, bi.Location, storage.DefaultBackup, mappings)
+	backupID := bi.BackupID
+	if !bi.PointInTime.IsZero() {
+		backupID, err = bi.resolvePointInTime(ctx)
+		if err != nil {
+			return src, err
+		}
+	}
+	chunkIndex, full, err := storage.BackupObjects(backupID)
+	if err != nil {
+		return src, err
+	}
+
+	// chunked backups take precedence over the regular backup
+	if chunkIndex != "" {
+		hasBackup, err := storage.DownloadChunked(ctx, bi.RemoteStorage, bi.Location, chunkIndex, mappings)
+		if err != nil {
+			return src, xerrors.Errorf("cannot restore chunked backup: %w", err)
+		}
+		if hasBackup {
+			return csapi.WorkspaceInitFromBackup, nil
+		}
+	}
+
+	hasBackup, err := bi.RemoteStorage.Download(ctx, bi.Location, full, mappings)
 	if !hasBackup {
 		return src, xerrors.Errorf("no backup found")
 	}
@@ -195,9 +232,32 @@ func (bi *fromBackupInitializer) Run(ctx context.Context, mappings []archive.IDM
 	return csapi.WorkspaceInitFromBackup, nil
 }
 
+// selectsBackup returns true if this initializer restores a backup from the backup history or trail rather than the current one
+func (bi *fromBackupInitializer) selectsBackup() bool {
+	return bi.BackupID != "" || !bi.PointInTime.IsZero()
+}
+
+// resolvePointInTime finds the ID of the backup to restore for the point in time this initializer was asked to restore
+func (bi *fromBackupInitializer) resolvePointInTime(ctx context.Context) (backupID string, err error) {
+	rs, ok := bi.RemoteStorage.(storage.DirectAccess)
+	if !ok {
+		return "", xerrors.Errorf("remote storage cannot list backups")
+	}
+	backups, err := storage.ListDirectBackups(ctx, rs)
+	if err != nil {
+		return "", err
+	}
+	backup, ok := storage.BackupAt(backups, bi.PointInTime)
+	if !ok {
+		return "", xerrors.Errorf("no backup found before %s", bi.PointInTime.UTC().Format(time.RFC3339))
+	}
+	log.WithField("backupID", backup.ID).WithField("pointInTime", bi.PointInTime).Info("restoring backup from point in time")
+	return backup.ID, nil
+}
+
 // newGitInitializer creates a Git initializer based on the request.
 // Returns gRPC errors.
-func newGitInitializer(ctx context.Context, loc string, req *csapi.GitInitializer, forceGitpodUser bool) (*GitInitializer, error) {
+func newGitInitializer(ctx context.Context, loc string, req *csapi.GitInitializer, opts NewFromRequestOpts) (*GitInitializer, error) {
 	if req.Config == nil {
 		return nil, status.Error(codes.InvalidArgument, "Git initializer misses config")
 	}
@@ -216,6 +276,45 @@ func newGitInitializer(ctx context.Context, loc string, req *csapi.GitInitialize
 		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid target mode: %v", req.TargetMode))
 	}
 
+	if req.CloneDepth < 0 {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid clone depth: %d", req.CloneDepth))
+	}
+	var cloneFilter string
+	switch req.CloneFilter {
+	case csapi.CloneFilter_NO_FILTER:
+	case csapi.CloneFilter_BLOB_NONE:
+		cloneFilter = "blob:none"
+	case csapi.CloneFilter_TREE_ZERO:
+		cloneFilter = "tree:0"
+	default:
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid clone filter: %v", req.CloneFilter))
+	}
+	for _, p := range req.SparseCheckoutPatterns {
+		if p == "" || filepath.IsAbs(p) || p == ".." || strings.HasPrefix(p, "../") || strings.HasPrefix(p, "-") {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid sparse checkout pattern: %q", p))
+		}
+	}
+
+	var lfsMode git.LFSMode
+	switch req.Config.Lfs {
+	case csapi.GitLFSMode_LFS_SKIP:
+		lfsMode = git.LFSSkip
+	case csapi.GitLFSMode_LFS_FETCH_ALL:
+		lfsMode = git.LFSFetchAll
+	case csapi.GitLFSMode_LFS_FETCH_INCLUDE:
+		if len(req.Config.LfsIncludePatterns) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "Git LFS include mode requires include patterns")
+		}
+		lfsMode = git.LFSFetchInclude
+	default:
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid Git LFS mode: %v", req.Config.Lfs))
+	}
+	for _, p := range req.Config.LfsIncludePatterns {
+		if p == "" || strings.Contains(p, ",") {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid Git LFS include pattern: %q", p))
+		}
+	}
+
 	var authMethod = git.BasicAuth
 	if req.Config.Authentication == csapi.GitAuthMethod_NO_AUTH {
 		authMethod = git.NoAuth
@@ -251,10 +350,17 @@ func newGitInitializer(ctx context.Context, loc string, req *csapi.GitInitialize
 			Config:            req.Config.CustomConfig,
 			AuthMethod:        authMethod,
 			AuthProvider:      authProvider,
+
+			CloneDepth:             int(req.CloneDepth),
+			CloneFilter:            cloneFilter,
+			SparseCheckoutPatterns: req.SparseCheckoutPatterns,
+			ReferenceRepository:    opts.GitReferences[req.RemoteUri],
+			LFS:                    lfsMode,
+			LFSIncludePatterns:     req.Config.LfsIncludePatterns,
 		},
 		TargetMode:  targetMode,
 		CloneTarget: req.CloneTaget,
-		Chown:       forceGitpodUser,
+		Chown:       opts.ForceGitpodUserForGit,
 	}, nil
 }
 
@@ -410,10 +516,19 @@ func InitializeWorkspace(ctx context.Context, location string, remoteStorage sto
 		}
 	}
 
-	// Run the initializer
-	hasBackup, err := remoteStorage.Download(ctx, location, storage.DefaultBackup, cfg.mappings)
-	if err != nil {
-		return src, xerrors.Errorf("cannot restore backup: %w", err)
+	// Run the initializer. The current backup takes precedence, unless the initializer restores a specific backup.
+	var hasBackup bool
+	if bi, ok := cfg.Initializer.(*fromBackupInitializer); !ok || !bi.selectsBackup() {
+		hasBackup, err = storage.DownloadChunked(ctx, remoteStorage, location, storage.DefaultBackupChunkIndex, cfg.mappings)
+		if err != nil {
+			return src, xerrors.Errorf("cannot restore chunked backup: %w", err)
+		}
+		if !hasBackup {
+			hasBackup, err = remoteStorage.Download(ctx, location, storage.DefaultBackup, cfg.mappings)
+			if err != nil {
+				return src, xerrors.Errorf("cannot restore backup: %w", err)
+			}
+		}
 	}
 
 	span.SetTag("hasBackup", hasBackup)
@@ -429,16 +544,21 @@ func InitializeWorkspace(ctx context.Context, location string, remoteStorage sto
 	return
 }
 
-// PlaceWorkspaceReadyFile writes a file in the workspace which indicates that the workspace has been initialized
-func PlaceWorkspaceReadyFile(ctx context.Context, wspath string, initsrc csapi.WorkspaceInitSource, uid, gid int) (err error) {
+// PlaceWorkspaceReadyFile writes a file in the workspace which indicates that the workspace has been initialized.
+// The report of a composite initializer (see InitializerReport) and the Git LFS bytes the initializer fetched
+// (see LFSBytes) are included in the ready file if present.
+func PlaceWorkspaceReadyFile(ctx context.Context, wspath string, initsrc csapi.WorkspaceInitSource, ilr Initializer, uid, gid int) (err error) {
 	//nolint:ineffassign,staticcheck
 	span, ctx := opentracing.StartSpanFromContext(ctx, "placeWorkspaceReadyFile")
 	span.SetTag("source", initsrc)
 	defer tracing.FinishSpan(span, &err)
 
 	content := csapi.WorkspaceReadyMessage{
-		Source: initsrc,
+		Source:      initsrc,
+		Initializer: InitializerReport(ilr),
+		LFSBytes:    LFSBytes(ilr),
 	}
+	span.SetTag("lfsBytes", content.LFSBytes)
 	fc, err := json.Marshal(content)
 	if err != nil {
 		return xerrors.Errorf("cannot marshal workspace ready message: %w", err)
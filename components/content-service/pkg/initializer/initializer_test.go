@@ -0,0 +1,131 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package initializer
+
+import (
+	"archive/tar"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+func TestFromBackupPointInTime(t *testing.T) {
+	var (
+		ctx = context.Background()
+		t0  = time.Unix(1630000000, 0)
+		t1  = t0.Add(1 * time.Hour)
+	)
+	keyfile := filepath.Join(t.TempDir(), "key")
+	err := os.WriteFile(keyfile, []byte("not-so-secret\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs, err := storage.NewDirectAccess(&config.StorageConfig{
+		Kind:  config.FileSystemStorage,
+		Stage: config.StageDevStaging,
+		FileSystemConfig: config.FileSystemConfig{
+			BasePath:       t.TempDir(),
+			URL:            "http://localhost/storage",
+			SigningKeyFile: keyfile,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rs.Init(ctx, "owner", "workspace", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	upload := func(name, content string) {
+		fn := filepath.Join(t.TempDir(), "backup.tar")
+		writeTestTarball(t, fn, content)
+		_, _, err := rs.Upload(ctx, fn, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	upload(storage.HistoricBackupName(t0, storage.DefaultBackup), "t0")
+	upload(storage.HistoricBackupName(t1, storage.DefaultBackup), "t1")
+	upload(storage.DefaultBackup, "current")
+
+	tests := []struct {
+		Name          string
+		Req           *csapi.FromBackupInitializer
+		Expectation   string
+		ExpectedError bool
+	}{
+		{Name: "current backup", Req: &csapi.FromBackupInitializer{}, Expectation: "current"},
+		{Name: "backup ID", Req: &csapi.FromBackupInitializer{BackupId: "history/" + formatNano(t0)}, Expectation: "t0"},
+		{Name: "exact point in time", Req: &csapi.FromBackupInitializer{Timestamp: t1.Unix()}, Expectation: "t1"},
+		{Name: "between backups", Req: &csapi.FromBackupInitializer{Timestamp: t1.Add(-1 * time.Minute).Unix()}, Expectation: "t0"},
+		{Name: "before first backup", Req: &csapi.FromBackupInitializer{Timestamp: t0.Add(-1 * time.Minute).Unix()}, ExpectedError: true},
+		{Name: "unknown backup ID", Req: &csapi.FromBackupInitializer{BackupId: "history/42"}, ExpectedError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			loc := t.TempDir()
+			ini, err := newFromBackupInitializer(loc, rs, test.Req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = InitializeWorkspace(ctx, loc, rs, WithInitializer(ini), WithChown(os.Getuid(), os.Getgid()))
+			if test.ExpectedError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			act, err := os.ReadFile(filepath.Join(loc, "backup.txt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(act) != test.Expectation {
+				t.Errorf("restored the wrong backup: expected %q, got %q", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func formatNano(t time.Time) string {
+	return filepath.Base(filepath.Dir(storage.HistoricBackupName(t, storage.DefaultBackup)))
+}
+
+func writeTestTarball(t *testing.T, fn, content string) {
+	f, err := os.Create(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	err = tw.WriteHeader(&tar.Header{
+		Name: "backup.txt",
+		Mode: 0644,
+		Size: int64(len(content)),
+		Uid:  os.Getuid(),
+		Gid:  os.Getgid(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tw.Write([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -30,6 +30,14 @@ type PrebuildInitializer struct {
 	Prebuild *SnapshotInitializer
 }
 
+func (p *PrebuildInitializer) checkoutLocation() string {
+	// the prebuild snapshot can contain anything in the workspace
+	if p.Prebuild != nil || p.Git == nil {
+		return ""
+	}
+	return p.Git.Location
+}
+
 // Run runs the prebuild initializer
 func (p *PrebuildInitializer) Run(ctx context.Context, mappings []archive.IDMapping) (src csapi.WorkspaceInitSource, err error) {
 	//nolint:ineffassign
@@ -102,10 +110,19 @@ func (p *PrebuildInitializer) Run(ctx context.Context, mappings []archive.IDMapp
 		if err != nil {
 			return src, xerrors.Errorf("prebuild initializer: %w", err)
 		}
+		// the prebuild may have been taken with a different (or without a) sparse checkout
+		err = p.Git.SparseCheckout(ctx)
+		if err != nil {
+			return src, xerrors.Errorf("prebuild initializer: %w", err)
+		}
 		err = p.Git.realizeCloneTarget(ctx)
 		if err != nil {
 			return src, xerrors.Errorf("prebuild initializer: %w", err)
 		}
+		p.Git.lfsBytes, err = p.Git.LFSPull(ctx)
+		if err != nil {
+			return src, xerrors.Errorf("prebuild initializer: %w", err)
+		}
 
 		// If any of these cleanup operations fail that's no reason to fail ws initialization.
 		// It just results in a slightly degraded state.
@@ -13,9 +13,12 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/opencontainers/go-digest"
 	"github.com/opentracing/opentracing-go"
+	"golang.org/x/sync/errgroup"
 	"golang.org/x/xerrors"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
@@ -32,6 +35,10 @@ const (
 	// these formats duplicate naming conventions embedded in the remote storage implementations or ws-daemon.
 	fmtWorkspaceManifest = "workspaces/%s/wsfull.json"
 	fmtLegacyBackupName  = "workspaces/%s/full.tar"
+	fmtChunkIndexName    = "workspaces/%s/wschunks.json"
+
+	// maxParallelChunkSigning is the number of chunk download URLs we sign concurrently
+	maxParallelChunkSigning = 16
 )
 
 // NewProvider produces a new content layer provider
@@ -40,9 +47,15 @@ func NewProvider(cfg *config.StorageConfig) (*Provider, error) {
 	if err != nil {
 		return nil, err
 	}
+	kr, err := storage.NewKeyring(cfg.Encryption)
+	if err != nil {
+		return nil, err
+	}
 	return &Provider{
-		Storage: s,
-		Client:  &http.Client{},
+		Storage:         s,
+		Client:          &http.Client{},
+		Keyring:         kr,
+		BackupRetention: cfg.BackupRetention,
 	}, nil
 }
 
@@ -50,6 +63,25 @@ func NewProvider(cfg *config.StorageConfig) (*Provider, error) {
 type Provider struct {
 	Storage storage.PresignedAccess
 	Client  *http.Client
+
+	// Keyring provides the data keys which decrypt encrypted backups. Can be nil.
+	Keyring storage.Keyring
+
+	// BackupRetention determines which backups of a workspace's backup history are kept
+	BackupRetention config.BackupRetentionConfig
+}
+
+// dataKeys returns the owner's data key for the content descriptor, so that the workspace can decrypt its own backup.
+// Returns nil if no keyring is configured.
+func (s *Provider) dataKeys(owner string) (storage.DataKeys, error) {
+	if s.Keyring == nil {
+		return nil, nil
+	}
+	key, _, err := s.Keyring.DataKey(owner)
+	if err != nil {
+		return nil, err
+	}
+	return storage.DataKeys{owner: key}, nil
 }
 
 var errUnsupportedContentType = xerrors.Errorf("unsupported workspace content type")
@@ -132,6 +164,12 @@ func (s *Provider) GetContentLayer(ctx context.Context, owner, workspaceID strin
 		}
 	}()
 
+	// a backup from the backup history or trail takes precedence over the current backup of the workspace
+	if bi := initializer.GetBackup(); bi != nil && (bi.BackupId != "" || bi.Timestamp > 0) {
+		l, err = s.getBackupContentLayer(ctx, owner, workspaceID, bi)
+		return l, nil, err
+	}
+
 	// check if workspace has an FWB
 	var (
 		bucket = s.Storage.Bucket(owner)
@@ -149,8 +187,34 @@ func (s *Provider) GetContentLayer(ctx context.Context, owner, workspaceID strin
 		return l, manifest, err
 	}
 
-	// check if legacy workspace backup is present
+	// check if a chunked workspace backup is present
 	var layer *Layer
+	urls, err := s.signChunkedBackup(ctx, bucket, workspaceID, fmt.Sprintf(fmtChunkIndexName, workspaceID))
+	if err != nil && err != storage.ErrNotFound {
+		return nil, nil, err
+	}
+	if err == nil {
+		span.LogKV("backup found", "chunked workspace backup", "chunks", len(urls)-1)
+
+		keys, err := s.dataKeys(owner)
+		if err != nil {
+			return nil, nil, err
+		}
+		cdesc, err := executor.PrepareFromChunkedBackup(urls, keys)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		layer, err = contentDescriptorToLayer(cdesc)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		l = []Layer{*layer}
+		return l, manifest, nil
+	}
+
+	// check if legacy workspace backup is present
 	info, err := s.Storage.SignDownload(ctx, bucket, fmt.Sprintf(fmtLegacyBackupName, workspaceID), &storage.SignedURLOptions{})
 	if err != nil && !xerrors.Is(err, storage.ErrNotFound) {
 		return nil, nil, err
@@ -158,7 +222,11 @@ func (s *Provider) GetContentLayer(ctx context.Context, owner, workspaceID strin
 	if err == nil {
 		span.LogKV("backup found", "legacy workspace backup")
 
-		cdesc, err := executor.PrepareFromBackup(info.URL)
+		keys, err := s.dataKeys(owner)
+		if err != nil {
+			return nil, nil, err
+		}
+		cdesc, err := executor.PrepareFromBackup(info.URL, keys)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -213,6 +281,152 @@ func (s *Provider) GetContentLayer(ctx context.Context, owner, workspaceID strin
 	return nil, nil, xerrors.Errorf("no backup or valid initializer present")
 }
 
+// signChunkedBackup produces download URLs for the chunk index and all chunks of a chunked workspace backup.
+// Returns ErrNotFound if the workspace has no chunked backup.
+func (s *Provider) signChunkedBackup(ctx context.Context, bucket, workspaceID, indexObj string) (urls map[string]string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "signChunkedBackup")
+	defer func() {
+		lerr := err
+		if lerr == storage.ErrNotFound {
+			span.LogKV("found", false)
+			lerr = nil
+		}
+		tracing.FinishSpan(span, &lerr)
+	}()
+
+	info, err := s.Storage.SignDownload(ctx, bucket, indexObj, &storage.SignedURLOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", info.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, xerrors.Errorf("cannot get %s: status %d", info.URL, resp.StatusCode)
+	}
+	body, err := storage.Decrypt(resp.Body, s.Keyring)
+	if err != nil {
+		return nil, err
+	}
+	var idx csapi.WorkspaceChunkIndex
+	err = json.NewDecoder(body).Decode(&idx)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal chunk index: %w", err)
+	}
+
+	names := make(map[string]struct{}, len(idx.Chunks))
+	for _, c := range idx.Chunks {
+		names[storage.BackupChunkName(c.Digest)] = struct{}{}
+	}
+
+	var (
+		mu   sync.Mutex
+		sema = make(chan struct{}, maxParallelChunkSigning)
+		eg   errgroup.Group
+	)
+	urls = map[string]string{storage.DefaultBackupChunkIndex: info.URL}
+	for name := range names {
+		name := name
+		eg.Go(func() error {
+			sema <- struct{}{}
+			defer func() { <-sema }()
+
+			info, err := s.Storage.SignDownload(ctx, bucket, s.Storage.BackupObject(workspaceID, name), &storage.SignedURLOptions{})
+			if err == storage.ErrNotFound {
+				return xerrors.Errorf("chunk %s is missing", name)
+			}
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			urls[name] = info.URL
+			mu.Unlock()
+			return nil
+		})
+	}
+	err = eg.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	return urls, nil
+}
+
+// getBackupContentLayer provides the content layer for a backup from the backup history or trail of a workspace
+func (s *Provider) getBackupContentLayer(ctx context.Context, owner, workspaceID string, bi *csapi.FromBackupInitializer) (l []Layer, err error) {
+	span, ctx := tracing.FromContext(ctx, "getBackupContentLayer")
+	defer tracing.FinishSpan(span, &err)
+
+	backupID := bi.BackupId
+	if backupID == "" {
+		backups, err := storage.ListBackups(ctx, s.Storage, owner, workspaceID)
+		if err != nil {
+			return nil, err
+		}
+		pointInTime := time.Unix(bi.Timestamp, 0)
+		backup, ok := storage.BackupAt(backups, pointInTime)
+		if !ok {
+			return nil, xerrors.Errorf("no backup found before %s", pointInTime.UTC().Format(time.RFC3339))
+		}
+		backupID = backup.ID
+	}
+	span.LogKV("backupID", backupID)
+
+	chunkIndex, full, err := storage.BackupObjects(backupID)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := s.dataKeys(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		bucket = s.Storage.Bucket(owner)
+		cdesc  []byte
+	)
+	if chunkIndex != "" {
+		urls, err := s.signChunkedBackup(ctx, bucket, workspaceID, s.Storage.BackupObject(workspaceID, chunkIndex))
+		if err != nil && err != storage.ErrNotFound {
+			return nil, err
+		}
+		if err == nil {
+			cdesc, err = executor.PrepareFromChunkedBackup(urls, keys)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if cdesc == nil {
+		info, err := s.Storage.SignDownload(ctx, bucket, s.Storage.BackupObject(workspaceID, full), &storage.SignedURLOptions{})
+		if xerrors.Is(err, storage.ErrNotFound) {
+			return nil, xerrors.Errorf("backup %s not found", backupID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		cdesc, err = executor.PrepareFromBackup(info.URL, keys)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	layer, err := contentDescriptorToLayer(cdesc)
+	if err != nil {
+		return nil, err
+	}
+	return []Layer{*layer}, nil
+}
+
 func (s *Provider) getSnapshotContentLayer(ctx context.Context, sp *csapi.SnapshotInitializer) (l []Layer, manifest *csapi.WorkspaceContentManifest, err error) {
 	span, ctx := tracing.FromContext(ctx, "getSnapshotContentLayer")
 	defer tracing.FinishSpan(span, &err)
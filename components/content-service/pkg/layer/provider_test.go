@@ -40,6 +40,7 @@ func TestGetContentLayer(t *testing.T) {
 		ContentManifestType string
 		ContentManifest     *csapi.WorkspaceContentManifest
 		Backup              *storage.DownloadInfo
+		ChunkIndex          *csapi.WorkspaceChunkIndex
 		Initializer         *csapi.WorkspaceInitializer
 	}{
 		{
@@ -64,6 +65,18 @@ func TestGetContentLayer(t *testing.T) {
 				URL: "https://somewhere-else.com/backup.tar",
 			},
 		},
+		{
+			Name: "chunked backup",
+			ChunkIndex: &csapi.WorkspaceChunkIndex{
+				Digest: digest.NewDigestFromHex("sha256", "606c898987d799dd1fed7e39fa59c2adfd6fb1a4635a060ba6fab00f86bc050d"),
+				Size:   3 * 1024,
+				Chunks: []csapi.WorkspaceChunk{
+					{Digest: digest.FromString("chunk-a"), Size: 1024},
+					{Digest: digest.FromString("chunk-b"), Size: 1024},
+					{Digest: digest.FromString("chunk-a"), Size: 1024},
+				},
+			},
+		},
 		{
 			Name:                "full workspace backup",
 			ContentManifestType: csapi.ContentTypeManifest,
@@ -90,8 +103,10 @@ func TestGetContentLayer(t *testing.T) {
 		t.Run(test.Name, func(t *testing.T) {
 			var (
 				mf   []byte
+				idx  []byte
 				err  error
 				objs = make(map[string]*storage.DownloadInfo)
+				s    = &testStorage{Objs: objs}
 			)
 			if test.ContentManifest != nil {
 				mf, err = json.Marshal(test.ContentManifest)
@@ -121,8 +136,26 @@ func TestGetContentLayer(t *testing.T) {
 			if test.Backup != nil {
 				objs[fmt.Sprintf(fmtLegacyBackupName, workspaceID)] = test.Backup
 			}
+			if test.ChunkIndex != nil {
+				idx, err = json.Marshal(test.ChunkIndex)
+				if err != nil {
+					t.Fatal(err)
+					return
+				}
+
+				objs[fmt.Sprintf(fmtChunkIndexName, workspaceID)] = &storage.DownloadInfo{
+					Meta: storage.ObjectMeta{ContentType: csapi.ContentTypeChunkIndex},
+					Size: int64(len(idx)),
+					URL:  "http://chunk-index",
+				}
+				for _, c := range test.ChunkIndex.Chunks {
+					obj := s.BackupObject(workspaceID, storage.BackupChunkName(c.Digest))
+					objs[obj] = &storage.DownloadInfo{
+						URL: fmt.Sprintf("http://some-storage-system/%s", obj),
+					}
+				}
+			}
 
-			s := &testStorage{Objs: objs}
 			p := &Provider{
 				Storage: s,
 				Client: &http.Client{
@@ -134,6 +167,12 @@ func TestGetContentLayer(t *testing.T) {
 								Header:     make(http.Header),
 								Body:       io.NopCloser(bytes.NewReader(mf)),
 							}
+						case "http://chunk-index":
+							return &http.Response{
+								StatusCode: http.StatusOK,
+								Header:     make(http.Header),
+								Body:       io.NopCloser(bytes.NewReader(idx)),
+							}
 						default:
 							return &http.Response{
 								StatusCode: http.StatusNotFound,
@@ -224,6 +263,10 @@ func (s *testStorage) DiskUsage(ctx context.Context, bucket string, prefix strin
 	return 0, nil
 }
 
+func (s *testStorage) ListObjects(ctx context.Context, bucket string, prefix string) ([]string, error) {
+	return nil, nil
+}
+
 func (s *testStorage) SignDownload(ctx context.Context, bucket, obj string, options *storage.SignedURLOptions) (info *storage.DownloadInfo, err error) {
 	info, ok := s.Objs[obj]
 	if !ok || info == nil {
@@ -245,7 +288,7 @@ func (s *testStorage) DeleteBucket(ctx context.Context, bucket string) error {
 }
 
 func (*testStorage) BackupObject(workspaceID string, name string) string {
-	return ""
+	return fmt.Sprintf("workspaces/%s/%s", workspaceID, name)
 }
 
 func (*testStorage) InstanceObject(workspaceID string, instanceID string, name string) string {
@@ -89,27 +89,63 @@ func (cs *WorkspaceService) DeleteWorkspace(ctx context.Context, req *api.Delete
 		return &api.DeleteWorkspaceResponse{}, nil
 	}
 
-	blobName := cs.s.BackupObject(req.WorkspaceId, storage.DefaultBackup)
-	err = cs.s.DeleteObject(ctx, cs.s.Bucket(req.OwnerId), &storage.DeleteObjectQuery{Name: blobName})
-	if err != nil {
+	// Every backup artifact is deleted, no matter which of them exist: a workspace might have chunked backups only.
+	// Chunks belong to the workspace they were uploaded for, hence deleting them cannot break the backups of other workspaces.
+	// The chunk index goes before the chunks, such that there never is an index referencing deleted chunks.
+	var (
+		bucket  = cs.s.Bucket(req.OwnerId)
+		queries = []*storage.DeleteObjectQuery{
+			{Name: cs.s.BackupObject(req.WorkspaceId, storage.DefaultBackup)},
+			{Name: cs.s.BackupObject(req.WorkspaceId, storage.DefaultBackupChunkIndex)},
+			{Prefix: cs.s.BackupObject(req.WorkspaceId, "trail-")},
+			{Prefix: cs.s.BackupObject(req.WorkspaceId, storage.BackupHistoryPrefix)},
+			{Prefix: cs.s.BackupObject(req.WorkspaceId, storage.BackupChunkPrefix)},
+		}
+	)
+	for _, q := range queries {
+		obj := q.Name
+		if obj == "" {
+			obj = q.Prefix
+		}
+		err = cs.s.DeleteObject(ctx, bucket, q)
 		if errors.Is(err, storage.ErrNotFound) {
-			log.WithError(err).Debug("deleting workspace backup: NotFound, ", blobName)
-			return &api.DeleteWorkspaceResponse{}, nil
+			log.WithError(err).Debug("deleting workspace backup: NotFound, ", obj)
+			continue
 		}
-		log.WithError(err).Error("error deleting workspace backup: ", blobName)
-		return nil, status.Error(codes.Unknown, err.Error())
+		if err != nil {
+			log.WithError(err).Error("error deleting workspace backup: ", obj)
+			return nil, status.Error(codes.Unknown, err.Error())
+		}
+	}
+
+	return &api.DeleteWorkspaceResponse{}, nil
+}
+
+// ListBackups lists the backups from the backup history and trail of a workspace, most recent backup first
+func (cs *WorkspaceService) ListBackups(ctx context.Context, req *api.ListBackupsRequest) (resp *api.ListBackupsResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ListBackups")
+	span.SetTag("user", req.OwnerId)
+	span.SetTag("workspaceId", req.WorkspaceId)
+	defer tracing.FinishSpan(span, &err)
+
+	if req.OwnerId == "" || req.WorkspaceId == "" {
+		return nil, status.Error(codes.InvalidArgument, "owner ID and workspace ID are required")
 	}
 
-	trailPrefix := cs.s.BackupObject(req.WorkspaceId, "trail-")
-	err = cs.s.DeleteObject(ctx, cs.s.Bucket(req.OwnerId), &storage.DeleteObjectQuery{Prefix: trailPrefix})
+	backups, err := storage.ListBackups(ctx, cs.s, req.OwnerId, req.WorkspaceId)
 	if err != nil {
-		if errors.Is(err, storage.ErrNotFound) {
-			log.WithError(err).Debug("deleting workspace backup: NotFound, ", trailPrefix)
-			return &api.DeleteWorkspaceResponse{}, nil
-		}
-		log.WithError(err).Error("error deleting workspace backup: ", trailPrefix)
+		log.WithFields(log.OWI(req.OwnerId, req.WorkspaceId, "")).WithError(err).Error("error listing workspace backups")
 		return nil, status.Error(codes.Unknown, err.Error())
 	}
 
-	return &api.DeleteWorkspaceResponse{}, nil
+	resp = &api.ListBackupsResponse{
+		Backups: make([]*api.WorkspaceBackup, 0, len(backups)),
+	}
+	for _, b := range backups {
+		resp.Backups = append(resp.Backups, &api.WorkspaceBackup{
+			Id:      b.ID,
+			Created: b.Created.Unix(),
+		})
+	}
+	return resp, nil
 }
@@ -0,0 +1,76 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+func TestDeleteWorkspaceChunkedOnly(t *testing.T) {
+	ctx := context.Background()
+	keyfile := filepath.Join(t.TempDir(), "key")
+	err := os.WriteFile(keyfile, []byte("not-so-secret\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.StorageConfig{
+		Kind:  config.FileSystemStorage,
+		Stage: config.StageDevStaging,
+		FileSystemConfig: config.FileSystemConfig{
+			BasePath:       t.TempDir(),
+			URL:            "http://localhost/storage",
+			SigningKeyFile: keyfile,
+		},
+	}
+	rs, err := storage.NewDirectAccess(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rs.Init(ctx, "owner", "workspace", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join(t.TempDir(), "content")
+	err = os.WriteFile(src, []byte("content"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the workspace has chunked backups only, i.e. no full.tar
+	for _, name := range []string{
+		storage.DefaultBackupChunkIndex,
+		storage.BackupChunkPrefix + "0123",
+		storage.BackupHistoryPrefix + "1630000000000000000/" + storage.DefaultBackupChunkIndex,
+		"trail-1630000000-abc",
+	} {
+		_, _, err = rs.Upload(ctx, src, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	svc, err := NewWorkspaceService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = svc.DeleteWorkspace(ctx, &api.DeleteWorkspaceRequest{OwnerId: "owner", WorkspaceId: "workspace"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	left, err := rs.ListObjects(ctx, rs.BackupObject(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) != 0 {
+		t.Errorf("expected all backup artifacts to be deleted, but found %v", left)
+	}
+}
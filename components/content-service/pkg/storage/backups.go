@@ -0,0 +1,129 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+const (
+	// backupTrailPrefix is the prefix of all trailing backups of a workspace
+	backupTrailPrefix = "trail-"
+)
+
+var (
+	historicBackupRegexp = regexp.MustCompile(`^history/(\d+)$`)
+	trailingBackupRegexp = regexp.MustCompile(`^trail-(\d+)-[a-zA-Z0-9_\-]+$`)
+)
+
+// BackupInfo describes a backup a workspace can be restored from
+type BackupInfo struct {
+	// ID identifies the backup among all backups of the workspace
+	ID string
+
+	// Created is the time the backup was made
+	Created time.Time
+}
+
+// ListBackups lists the backup history and backup trail of a workspace, most recent backup first.
+// The current backup of a workspace is not part of this list.
+func ListBackups(ctx context.Context, s PresignedAccess, ownerID, workspaceID string) ([]BackupInfo, error) {
+	var (
+		bucket = s.Bucket(ownerID)
+		prefix = s.BackupObject(workspaceID, "")
+	)
+	return listBackups(prefix, func(prefix string) ([]string, error) {
+		return s.ListObjects(ctx, bucket, prefix)
+	})
+}
+
+// ListDirectBackups lists the backup history and backup trail of the workspace the remote storage was initialized for,
+// most recent backup first. The current backup of a workspace is not part of this list.
+func ListDirectBackups(ctx context.Context, rs DirectAccess) ([]BackupInfo, error) {
+	return listBackups(rs.BackupObject(""), func(prefix string) ([]string, error) {
+		return rs.ListObjects(ctx, prefix)
+	})
+}
+
+func listBackups(prefix string, list func(prefix string) ([]string, error)) ([]BackupInfo, error) {
+	history, err := list(prefix + BackupHistoryPrefix)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list backup history: %w", err)
+	}
+	trail, err := list(prefix + backupTrailPrefix)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list backup trail: %w", err)
+	}
+
+	var (
+		res = make([]BackupInfo, 0, len(history)+len(trail))
+		idx = make(map[string]struct{})
+	)
+	for _, obj := range history {
+		segs := strings.SplitN(strings.TrimPrefix(obj, prefix+BackupHistoryPrefix), "/", 2)
+		ns, err := strconv.ParseInt(segs[0], 10, 64)
+		if len(segs) != 2 || err != nil {
+			log.WithField("obj", obj).Warn("found unexpected object in backup history")
+			continue
+		}
+		id := BackupHistoryPrefix + segs[0]
+		if _, exists := idx[id]; exists {
+			continue
+		}
+		idx[id] = struct{}{}
+		res = append(res, BackupInfo{ID: id, Created: time.Unix(0, ns)})
+	}
+	for _, obj := range trail {
+		id := strings.TrimPrefix(obj, prefix)
+		match := trailingBackupRegexp.FindStringSubmatch(id)
+		if match == nil {
+			log.WithField("obj", obj).Warn("found unexpected object in backup trail")
+			continue
+		}
+		sec, _ := strconv.ParseInt(match[1], 10, 64)
+		res = append(res, BackupInfo{ID: id, Created: time.Unix(sec, 0)})
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Created.After(res[j].Created) })
+	return res, nil
+}
+
+// BackupAt returns the most recent backup which was made at or before t.
+// Expects the backups to be sorted as returned by ListBackups.
+func BackupAt(backups []BackupInfo, t time.Time) (backup BackupInfo, found bool) {
+	for _, b := range backups {
+		if b.Created.After(t) {
+			continue
+		}
+		return b, true
+	}
+	return BackupInfo{}, false
+}
+
+// BackupObjects returns the names of the objects a backup is stored in. Those names are relative to the workspace,
+// i.e. can be passed to BackupObject or a direct downloader. If the backup cannot be chunked, chunkIndex is empty.
+// An empty backup ID denotes the current backup of a workspace.
+func BackupObjects(backupID string) (chunkIndex, full string, err error) {
+	switch {
+	case backupID == "":
+		return DefaultBackupChunkIndex, DefaultBackup, nil
+	case historicBackupRegexp.MatchString(backupID):
+		return fmt.Sprintf("%s/%s", backupID, DefaultBackupChunkIndex), fmt.Sprintf("%s/%s", backupID, DefaultBackup), nil
+	case trailingBackupRegexp.MatchString(backupID):
+		return "", backupID, nil
+	default:
+		return "", "", xerrors.Errorf("invalid backup ID: %s", backupID)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackupObjects(t *testing.T) {
+	tests := []struct {
+		Name       string
+		BackupID   string
+		ChunkIndex string
+		Full       string
+		Error      bool
+	}{
+		{Name: "current backup", ChunkIndex: DefaultBackupChunkIndex, Full: DefaultBackup},
+		{Name: "historic backup", BackupID: "history/1622548800000000000", ChunkIndex: "history/1622548800000000000/wschunks.json", Full: "history/1622548800000000000/full.tar"},
+		{Name: "trailing backup", BackupID: "trail-1622548800-trail", Full: "trail-1622548800-trail"},
+		{Name: "traversal", BackupID: "history/../../other-ws/full.tar", Error: true},
+		{Name: "arbitrary object", BackupID: "full.tar", Error: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			chunkIndex, full, err := BackupObjects(test.BackupID)
+			if (err != nil) != test.Error {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if chunkIndex != test.ChunkIndex || full != test.Full {
+				t.Errorf("unexpected backup objects: got (%q, %q), expected (%q, %q)", chunkIndex, full, test.ChunkIndex, test.Full)
+			}
+		})
+	}
+}
+
+func TestListBackups(t *testing.T) {
+	cfg := newTestFSConfig(t)
+	rs, err := newDirectFSAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	err = rs.Init(ctx, "owner", "ws", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(t.TempDir(), "src.tar")
+	for i, ctnt := range []string{"a", "b", "c"} {
+		err = os.WriteFile(src, []byte(ctnt), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = rs.Upload(ctx, src, DefaultBackup, WithBackupHistory(), WithBackupTrail("trail", 5))
+		if err != nil {
+			t.Fatalf("upload %d: %v", i, err)
+		}
+	}
+
+	ps, err := newPresignedFSAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backups, err := ListBackups(ctx, ps, "owner", "ws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	direct, err := ListDirectBackups(ctx, rs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != len(direct) {
+		t.Errorf("presigned and direct listing differ: %v vs %v", backups, direct)
+	}
+
+	var history, trail int
+	for i, b := range backups {
+		if i > 0 && b.Created.After(backups[i-1].Created) {
+			t.Errorf("backups are not sorted most recent first: %v", backups)
+		}
+		if _, _, err := BackupObjects(b.ID); err != nil {
+			t.Errorf("listed backup has invalid ID: %v", err)
+		}
+		switch b.ID[0] {
+		case 'h':
+			history++
+		case 't':
+			trail++
+		}
+	}
+	// trailing backups are named with second precision, hence uploads within the same second share a trail entry
+	if history != 3 || trail == 0 {
+		t.Errorf("expected three historic and some trailing backups, got %v", backups)
+	}
+
+	_, found := BackupAt(backups, time.Now().Add(-time.Hour))
+	if found {
+		t.Error("found a backup before any backup was made")
+	}
+	b, found := BackupAt(backups, time.Now())
+	if !found || b.ID != backups[0].ID {
+		t.Errorf("expected the most recent backup, got %v", b)
+	}
+
+	_, full, _ := BackupObjects(backups[len(backups)-1].ID)
+	rc, err := rs.DownloadRaw(ctx, full)
+	if err != nil {
+		t.Fatalf("cannot download oldest backup %s: %v", full, err)
+	}
+	rc.Close()
+}
+
+func TestBackupTrailCreationTime(t *testing.T) {
+	rs, err := newDirectFSAccess(newTestFSConfig(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	err = rs.Init(ctx, "owner", "ws", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(t.TempDir(), "src.tar")
+	err = os.WriteFile(src, []byte("a"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = rs.Upload(ctx, src, DefaultBackup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	op, err := rs.store.objectPath(rs.bucketName(), rs.objectName(DefaultBackup))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chtimes(op, created, created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = rs.Upload(ctx, src, DefaultBackup, WithBackupTrail("trail", 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := ListDirectBackups(ctx, rs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 1 || !backups[0].Created.Equal(created) {
+		t.Errorf("expected the trailing backup to carry its creation time %v, got %v", created, backups)
+	}
+}
@@ -0,0 +1,382 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+)
+
+const (
+	// DefaultBackupChunkIndex is the name of the chunk index of a chunked regular backup
+	DefaultBackupChunkIndex = "wschunks.json"
+
+	// BackupChunkPrefix is the prefix of all backup chunk names
+	BackupChunkPrefix = "chunks/"
+
+	chunkMinSize = 512 * 1024
+	chunkMaxSize = 8 * 1024 * 1024
+	// chunkMask produces an average chunk size of about 2MiB
+	chunkMask = (1 << 21) - 1
+)
+
+// BackupChunkName returns the name of a backup chunk with the given digest
+func BackupChunkName(dgst digest.Digest) string {
+	return BackupChunkPrefix + dgst.Encoded()
+}
+
+// gearTable is the table of random values used by the rolling gear hash. The values must
+// never change, otherwise chunk boundaries shift and deduplication against existing chunks breaks.
+var gearTable = func() (res [256]uint64) {
+	// splitmix64 with a fixed seed
+	x := uint64(0x6769747061642121)
+	for i := range res {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		res[i] = z ^ (z >> 31)
+	}
+	return
+}()
+
+// chunker splits a stream into content-defined chunks using a gear-based rolling hash.
+// Chunk boundaries depend on the content only, hence inserting or removing data in one place
+// changes the chunks around that place only.
+type chunker struct {
+	r io.Reader
+	// buf holds the chunk returned last, followed by the data read ahead
+	buf []byte
+	off int
+	n   int
+	eof bool
+}
+
+func newChunker(r io.Reader) *chunker {
+	return &chunker{
+		r:   r,
+		buf: make([]byte, chunkMaxSize),
+	}
+}
+
+// Next returns the next chunk. The returned slice is only valid until the next call to Next.
+// Returns io.EOF once the stream is exhausted.
+func (c *chunker) Next() ([]byte, error) {
+	// move the data read ahead to the front and fill up the buffer, which then holds at least one chunk
+	c.n = copy(c.buf, c.buf[c.off:c.n])
+	c.off = 0
+	if !c.eof {
+		n, err := io.ReadFull(c.r, c.buf[c.n:])
+		c.n += n
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			c.eof = true
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	if c.n == 0 {
+		return nil, io.EOF
+	}
+
+	// The hash shifts by one bit per byte, hence only the last 64 bytes contribute to it. Starting
+	// 64 bytes ahead of the minimum chunk size yields the same boundaries as hashing the whole chunk.
+	var (
+		h   uint64
+		end = c.n
+	)
+	for i := chunkMinSize - 64; i < c.n; i++ {
+		h = (h << 1) + gearTable[c.buf[i]]
+		if i+1 >= chunkMinSize && h&chunkMask == 0 {
+			end = i + 1
+			break
+		}
+	}
+	c.off = end
+	return c.buf[:end], nil
+}
+
+// ChunkedUploadStats describes the outcome of a chunked upload
+type ChunkedUploadStats struct {
+	TotalChunks    int
+	TotalSize      int64
+	UploadedChunks int
+	UploadedSize   int64
+}
+
+// UploadChunked splits the tarball at source into content-defined chunks and uploads all chunks which do not yet
+// exist in the remote storage. Once all chunks are uploaded, the chunk index is uploaded under indexName.
+// The upload options are applied to the chunk index only, except for encryption which applies to the chunks as well.
+// Chunk indexes cannot be trailed, because the backup trail holds tarballs only.
+func UploadChunked(ctx context.Context, rs DirectAccess, source, indexName, tmpDir string, opts ...UploadOption) (bucket, obj string, stats ChunkedUploadStats, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "UploadChunked")
+	span.SetTag("index", indexName)
+	defer tracing.FinishSpan(span, &err)
+
+	options, err := GetUploadOptions(opts)
+	if err != nil {
+		return "", "", stats, xerrors.Errorf("cannot get options: %w", err)
+	}
+	if options.BackupTrail.Enabled {
+		return "", "", stats, xerrors.Errorf("chunked backups cannot be trailed")
+	}
+	chunkOpts := []UploadOption{WithContentType("application/octet-stream")}
+	if options.Encrypt {
+		chunkOpts = append(chunkOpts, WithEncryption())
+	}
+
+	existingObjs, err := rs.ListObjects(ctx, rs.BackupObject(BackupChunkPrefix))
+	if err != nil {
+		return "", "", stats, xerrors.Errorf("cannot list existing chunks: %w", err)
+	}
+	existing := make(map[string]struct{}, len(existingObjs))
+	for _, o := range existingObjs {
+		existing[o] = struct{}{}
+	}
+
+	src, err := os.Open(source)
+	if err != nil {
+		return "", "", stats, xerrors.Errorf("cannot open file for uploading: %w", err)
+	}
+	defer src.Close()
+
+	var (
+		idx      csapi.WorkspaceChunkIndex
+		digester = digest.Canonical.Digester()
+		chnkr    = newChunker(io.TeeReader(src, digester.Hash()))
+	)
+	for {
+		chunk, err := chnkr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", "", stats, xerrors.Errorf("cannot read %s: %w", source, err)
+		}
+
+		dgst := digest.FromBytes(chunk)
+		idx.Chunks = append(idx.Chunks, csapi.WorkspaceChunk{Digest: dgst, Size: int64(len(chunk))})
+		idx.Size += int64(len(chunk))
+
+		name := BackupChunkName(dgst)
+		if _, exists := existing[rs.BackupObject(name)]; exists {
+			continue
+		}
+		_, _, err = uploadBytes(ctx, rs, tmpDir, name, chunk,
+			append(chunkOpts, WithAnnotations(map[string]string{ObjectAnnotationDigest: dgst.String()}))...,
+		)
+		if err != nil {
+			return "", "", stats, xerrors.Errorf("cannot upload chunk %s: %w", dgst, err)
+		}
+		existing[rs.BackupObject(name)] = struct{}{}
+		stats.UploadedChunks++
+		stats.UploadedSize += int64(len(chunk))
+	}
+	idx.Digest = digester.Digest()
+	stats.TotalChunks = len(idx.Chunks)
+	stats.TotalSize = idx.Size
+	span.LogKV("totalChunks", stats.TotalChunks, "uploadedChunks", stats.UploadedChunks, "uploadedSize", stats.UploadedSize)
+
+	ctnt, err := json.Marshal(idx)
+	if err != nil {
+		return "", "", stats, err
+	}
+	bucket, obj, err = uploadBytes(ctx, rs, tmpDir, indexName, ctnt, append(opts, WithContentType(csapi.ContentTypeChunkIndex))...)
+	if err != nil {
+		return "", "", stats, xerrors.Errorf("cannot upload chunk index: %w", err)
+	}
+
+	return bucket, obj, stats, nil
+}
+
+func uploadBytes(ctx context.Context, rs DirectAccess, tmpDir, name string, content []byte, opts ...UploadOption) (bucket, obj string, err error) {
+	tmpf, err := os.CreateTemp(tmpDir, "chunk-*")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.Remove(tmpf.Name())
+
+	_, err = tmpf.Write(content)
+	tmpf.Close()
+	if err != nil {
+		return "", "", err
+	}
+
+	return rs.Upload(ctx, tmpf.Name(), name, opts...)
+}
+
+// DownloadChunked restores a chunked backup by streaming all chunks listed in the chunk index named indexName
+// into destination. If the chunk index does not exist, found is false.
+func DownloadChunked(ctx context.Context, rs DirectDownloader, destination, indexName string, mappings []archive.IDMapping) (found bool, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DownloadChunked")
+	span.SetTag("index", indexName)
+	defer tracing.FinishSpan(span, &err)
+
+	idx, err := downloadChunkIndex(ctx, rs, indexName)
+	if err == ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return true, err
+	}
+	span.LogKV("chunks", len(idx.Chunks), "size", idx.Size)
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeChunks(ctx, rs, pw, idx))
+	}()
+
+	err = extractTarbal(ctx, destination, pr, nil, mappings)
+	pr.Close()
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+func downloadChunkIndex(ctx context.Context, rs DirectDownloader, name string) (*csapi.WorkspaceChunkIndex, error) {
+	rc, err := rs.DownloadRaw(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var idx csapi.WorkspaceChunkIndex
+	err = json.NewDecoder(rc).Decode(&idx)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal chunk index: %w", err)
+	}
+	return &idx, nil
+}
+
+// writeChunks writes all chunks of the index to out and verifies their digests along the way
+func writeChunks(ctx context.Context, rs DirectDownloader, out io.Writer, idx *csapi.WorkspaceChunkIndex) error {
+	total := digest.Canonical.Digester()
+	out = io.MultiWriter(out, total.Hash())
+	for _, chunk := range idx.Chunks {
+		err := func() error {
+			rc, err := rs.DownloadRaw(ctx, BackupChunkName(chunk.Digest))
+			if err == ErrNotFound {
+				return xerrors.Errorf("chunk %s is missing", chunk.Digest)
+			}
+			if err != nil {
+				return err
+			}
+			defer rc.Close()
+
+			verifier := chunk.Digest.Verifier()
+			n, err := io.Copy(out, io.TeeReader(rc, verifier))
+			if err != nil {
+				return err
+			}
+			if n != chunk.Size || !verifier.Verified() {
+				return xerrors.Errorf("chunk %s is corrupt", chunk.Digest)
+			}
+			return nil
+		}()
+		if err != nil {
+			return err
+		}
+	}
+	if act := total.Digest(); act != idx.Digest {
+		return xerrors.Errorf("digest mismatch: expected %s, got %s", idx.Digest, act)
+	}
+
+	log.WithField("chunks", len(idx.Chunks)).WithField("size", idx.Size).Debug("downloaded chunked backup")
+	return nil
+}
+
+// ChunkedBackupObjects returns the names of the chunk index and all chunks referenced from it.
+// The names are relative to the workspace, i.e. in the form the DirectDownloader accepts them.
+func ChunkedBackupObjects(ctx context.Context, rs DirectDownloader, indexName string) ([]string, error) {
+	idx, err := downloadChunkIndex(ctx, rs, indexName)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(idx.Chunks)+1)
+	res = append(res, indexName)
+	seen := make(map[digest.Digest]struct{}, len(idx.Chunks))
+	for _, c := range idx.Chunks {
+		if _, exists := seen[c.Digest]; exists {
+			continue
+		}
+		seen[c.Digest] = struct{}{}
+		res = append(res, BackupChunkName(c.Digest))
+	}
+	return res, nil
+}
+
+// DeleteUnreferencedChunks deletes the chunks of the workspace the remote storage was initialized for which neither its
+// current chunk index nor a chunk index in its backup history references. Chunks are not shared among workspaces, hence
+// the caller must make sure no backup of the workspace is uploaded concurrently.
+func DeleteUnreferencedChunks(ctx context.Context, rs DirectAccess, ps PresignedAccess, ownerID string) (deleted int, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DeleteUnreferencedChunks")
+	defer tracing.FinishSpan(span, &err)
+
+	chunks, err := rs.ListObjects(ctx, rs.BackupObject(BackupChunkPrefix))
+	if err != nil {
+		return 0, xerrors.Errorf("cannot list chunks: %w", err)
+	}
+	if len(chunks) == 0 {
+		return 0, nil
+	}
+
+	history, err := rs.ListObjects(ctx, rs.BackupObject(BackupHistoryPrefix))
+	if err != nil {
+		return 0, xerrors.Errorf("cannot list backup history: %w", err)
+	}
+	indexes := []string{DefaultBackupChunkIndex}
+	for _, obj := range history {
+		if strings.HasSuffix(obj, "/"+DefaultBackupChunkIndex) {
+			indexes = append(indexes, strings.TrimPrefix(obj, rs.BackupObject("")))
+		}
+	}
+
+	referenced := make(map[string]struct{})
+	for _, name := range indexes {
+		idx, err := downloadChunkIndex(ctx, rs, name)
+		if err == ErrNotFound {
+			// the current backup is not chunked, or the retention policy deleted the backup in the meantime
+			continue
+		}
+		if err != nil {
+			// we must not delete chunks we cannot tell are unreferenced
+			return 0, xerrors.Errorf("cannot read chunk index %s: %w", name, err)
+		}
+		for _, c := range idx.Chunks {
+			referenced[rs.BackupObject(BackupChunkName(c.Digest))] = struct{}{}
+		}
+	}
+
+	bucket := rs.Bucket(ownerID)
+	for _, obj := range chunks {
+		if _, ok := referenced[obj]; ok {
+			continue
+		}
+		err = ps.DeleteObject(ctx, bucket, &DeleteObjectQuery{Name: obj})
+		if err != nil && err != ErrNotFound {
+			return deleted, xerrors.Errorf("cannot delete chunk %s: %w", obj, err)
+		}
+		deleted++
+	}
+	span.LogKV("chunks", len(chunks), "indexes", len(indexes), "deleted", deleted)
+	return deleted, nil
+}
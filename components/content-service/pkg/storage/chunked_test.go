@@ -0,0 +1,179 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChunkerBoundaries(t *testing.T) {
+	data := make([]byte, 20*1024*1024)
+	rand.New(rand.NewSource(42)).Read(data)
+
+	chunks := func(data []byte) (res []string) {
+		c := newChunker(bytes.NewReader(data))
+		for {
+			chunk, err := c.Next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(chunk) > chunkMaxSize {
+				t.Fatalf("chunk exceeds maximum size: %d", len(chunk))
+			}
+			res = append(res, string(chunk))
+		}
+	}
+
+	orig := chunks(data)
+	if len(orig) < 2 {
+		t.Fatalf("expected more than one chunk, got %d", len(orig))
+	}
+	// the chunker must find the same boundaries as hashing every chunk from its first byte
+	var (
+		ref   []string
+		start int
+		h     uint64
+	)
+	for i, b := range data {
+		h = (h << 1) + gearTable[b]
+		if n := i + 1 - start; n == chunkMaxSize || (n >= chunkMinSize && h&chunkMask == 0) || i == len(data)-1 {
+			ref = append(ref, string(data[start:i+1]))
+			start, h = i+1, 0
+		}
+	}
+	if len(ref) != len(orig) {
+		t.Fatalf("expected %d chunks, got %d", len(ref), len(orig))
+	}
+	for i := range ref {
+		if ref[i] != orig[i] {
+			t.Errorf("chunk %d differs from the reference", i)
+		}
+	}
+	var total int
+	for i, c := range orig {
+		if i < len(orig)-1 && len(c) < chunkMinSize {
+			t.Errorf("chunk %d is below the minimum size: %d", i, len(c))
+		}
+		total += len(c)
+	}
+	if total != len(data) {
+		t.Errorf("chunks do not add up: %d != %d", total, len(data))
+	}
+
+	// inserting data at the beginning must not shift all chunk boundaries
+	modified := chunks(append([]byte("some prefix"), data...))
+	known := make(map[string]struct{}, len(orig))
+	for _, c := range orig {
+		known[c] = struct{}{}
+	}
+	var shared int
+	for _, c := range modified {
+		if _, ok := known[c]; ok {
+			shared++
+		}
+	}
+	if shared < len(orig)-2 {
+		t.Errorf("expected at most two chunks to change, but only %d of %d are shared", shared, len(orig))
+	}
+}
+
+func TestChunkedUploadDownload(t *testing.T) {
+	cfg := newTestFSConfig(t)
+	rs, err := newDirectFSAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	err = rs.Init(ctx, "owner", "ws", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := make([]byte, 12*1024*1024)
+	rand.New(rand.NewSource(42)).Read(content)
+	writeTar := func(content []byte) string {
+		fn := filepath.Join(t.TempDir(), "backup.tar")
+		f, err := os.Create(fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		tw := tar.NewWriter(f)
+		err = tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "data", Mode: 0644, Size: int64(len(content)), Uid: os.Getuid(), Gid: os.Getgid()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tw.Write(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = tw.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fn
+	}
+
+	_, _, stats, err := UploadChunked(ctx, rs, writeTar(content), DefaultBackupChunkIndex, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.UploadedChunks != stats.TotalChunks {
+		t.Errorf("expected all chunks to be uploaded initially, got %+v", stats)
+	}
+
+	copy(content[len(content)/2:], "some change in the middle")
+	_, _, stats, err = UploadChunked(ctx, rs, writeTar(content), DefaultBackupChunkIndex, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.UploadedChunks == 0 || stats.UploadedChunks > 2 {
+		t.Errorf("expected only the changed chunks to be uploaded, got %+v", stats)
+	}
+
+	ps, err := newPresignedFSAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleted, err := DeleteUnreferencedChunks(ctx, rs, ps, "owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != stats.UploadedChunks {
+		t.Errorf("expected the %d replaced chunks to be deleted, got %d", stats.UploadedChunks, deleted)
+	}
+
+	dst := t.TempDir()
+	found, err := DownloadChunked(ctx, rs, dst, DefaultBackupChunkIndex, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatal("chunked backup not found")
+	}
+	act, err := os.ReadFile(filepath.Join(dst, "data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(act, content) {
+		t.Error("restored content does not match the original")
+	}
+
+	found, err = DownloadChunked(ctx, rs, dst, "does-not-exist.json", nil)
+	if err != nil || found {
+		t.Errorf("expected missing chunk index to be not found, got found=%v, err=%v", found, err)
+	}
+}
@@ -0,0 +1,390 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+)
+
+const (
+	// encryptionMagic prefixes all encrypted objects
+	encryptionMagic = "GPENC\x01"
+
+	// encryptionSegmentSize is the size of the plaintext segments which are sealed individually
+	encryptionSegmentSize = 64 * 1024
+
+	// encryptionNoncePrefixSize is the size of the random nonce prefix. The remainder of the 12 byte
+	// nonce is made up of the segment counter and the last segment flag.
+	encryptionNoncePrefixSize = 7
+
+	maxEncryptionHeaderSize = 64 * 1024
+	dataKeySize             = 32
+)
+
+var (
+	// ErrEncryptionKeyMissing is returned when encrypted content is downloaded without a keyring
+	ErrEncryptionKeyMissing = xerrors.Errorf("content is encrypted but no key is available")
+)
+
+// Keyring provides the per-owner data keys which encrypt workspace content
+type Keyring interface {
+	// DataKey returns the data key of an owner together with its wrapped form
+	DataKey(owner string) (key, wrapped []byte, err error)
+
+	// UnwrapDataKey returns the data key of an owner from its wrapped form
+	UnwrapDataKey(owner string, wrapped []byte) ([]byte, error)
+}
+
+// NewKeyring produces a keyring from the encryption config. Returns nil if no key-encryption key is configured.
+func NewKeyring(cfg config.EncryptionConfig) (Keyring, error) {
+	if cfg.KeyEncryptionKeyFile == "" {
+		if cfg.Enabled {
+			return nil, xerrors.Errorf("encryption is enabled but no key-encryption key is configured")
+		}
+		return nil, nil
+	}
+
+	fc, err := os.ReadFile(cfg.KeyEncryptionKeyFile)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read key-encryption key: %w", err)
+	}
+	kek, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(fc)))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot decode key-encryption key: %w", err)
+	}
+	return NewKEKKeyring(kek)
+}
+
+// NewKEKKeyring produces a keyring whose data keys are wrapped by the given key-encryption key
+func NewKEKKeyring(kek []byte) (*KEKKeyring, error) {
+	if len(kek) != dataKeySize {
+		return nil, xerrors.Errorf("key-encryption key must be %d bytes long", dataKeySize)
+	}
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	return &KEKKeyring{kek: kek, aead: aead}, nil
+}
+
+// KEKKeyring derives a stable data key per owner and wraps it using a key-encryption key.
+// Because the data keys are derived, every party holding the key-encryption key can hand out
+// a single owner's data key (see DataKeys) without sharing the key-encryption key itself.
+type KEKKeyring struct {
+	kek  []byte
+	aead cipher.AEAD
+}
+
+// DataKey returns the data key of an owner together with its wrapped form
+func (k *KEKKeyring) DataKey(owner string) (key, wrapped []byte, err error) {
+	mac := hmac.New(sha256.New, k.kek)
+	_, _ = mac.Write([]byte("gitpod-data-key\x00" + owner))
+	key = mac.Sum(nil)
+
+	nonce := make([]byte, k.aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+	wrapped = k.aead.Seal(nonce, nonce, key, []byte(owner))
+	return key, wrapped, nil
+}
+
+// UnwrapDataKey returns the data key of an owner from its wrapped form
+func (k *KEKKeyring) UnwrapDataKey(owner string, wrapped []byte) ([]byte, error) {
+	ns := k.aead.NonceSize()
+	if len(wrapped) < ns {
+		return nil, xerrors.Errorf("wrapped data key is too short")
+	}
+	key, err := k.aead.Open(nil, wrapped[:ns], wrapped[ns:], []byte(owner))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unwrap data key of %s: %w", owner, err)
+	}
+	return key, nil
+}
+
+// DataKeys is a keyring of plain data keys indexed by owner. It can decrypt content only.
+type DataKeys map[string][]byte
+
+// DataKey returns an error because DataKeys cannot wrap keys
+func (d DataKeys) DataKey(owner string) (key, wrapped []byte, err error) {
+	return nil, nil, xerrors.Errorf("cannot encrypt using plain data keys")
+}
+
+// UnwrapDataKey returns the data key of the owner
+func (d DataKeys) UnwrapDataKey(owner string, wrapped []byte) ([]byte, error) {
+	key, ok := d[owner]
+	if !ok {
+		return nil, xerrors.Errorf("no data key for %s: %w", owner, ErrEncryptionKeyMissing)
+	}
+	return key, nil
+}
+
+// WithEncryption encrypts the object with the owner's data key before it is uploaded
+func WithEncryption() UploadOption {
+	return func(opts *UploadOptions) error {
+		opts.Encrypt = true
+		return nil
+	}
+}
+
+// encryptionHeader precedes the encrypted segments of an object
+type encryptionHeader struct {
+	Owner       string `json:"owner"`
+	WrappedKey  []byte `json:"wrappedKey"`
+	NoncePrefix []byte `json:"noncePrefix"`
+	SegmentSize int    `json:"segmentSize"`
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	blk, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(blk)
+}
+
+func segmentNonce(prefix []byte, idx uint32, last bool) []byte {
+	nonce := make([]byte, encryptionNoncePrefixSize+5)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[encryptionNoncePrefixSize:], idx)
+	if last {
+		nonce[encryptionNoncePrefixSize+4] = 1
+	}
+	return nonce
+}
+
+// encrypt writes the content of src encrypted with the owner's data key to dst
+func encrypt(dst io.Writer, src io.Reader, kr Keyring, owner string) error {
+	key, wrapped, err := kr.DataKey(owner)
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	hdr := encryptionHeader{
+		Owner:       owner,
+		WrappedKey:  wrapped,
+		NoncePrefix: make([]byte, encryptionNoncePrefixSize),
+		SegmentSize: encryptionSegmentSize,
+	}
+	_, err = rand.Read(hdr.NoncePrefix)
+	if err != nil {
+		return err
+	}
+	rawHdr, err := json.Marshal(hdr)
+	if err != nil {
+		return err
+	}
+	prelude := make([]byte, len(encryptionMagic)+4, len(encryptionMagic)+4+len(rawHdr))
+	copy(prelude, encryptionMagic)
+	binary.BigEndian.PutUint32(prelude[len(encryptionMagic):], uint32(len(rawHdr)))
+	_, err = dst.Write(append(prelude, rawHdr...))
+	if err != nil {
+		return err
+	}
+
+	var (
+		in  = bufio.NewReaderSize(src, encryptionSegmentSize)
+		seg = make([]byte, encryptionSegmentSize)
+		out = make([]byte, 0, encryptionSegmentSize+aead.Overhead())
+	)
+	for idx := uint32(0); ; idx++ {
+		n, err := io.ReadFull(in, seg)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		var last bool
+		if n < len(seg) {
+			last = true
+		} else if _, perr := in.Peek(1); perr == io.EOF {
+			last = true
+		}
+
+		out = aead.Seal(out[:0], segmentNonce(hdr.NoncePrefix, idx, last), seg[:n], nil)
+		_, err = dst.Write(out)
+		if err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+	}
+}
+
+// encryptForUpload encrypts source into a temporary file if the upload options ask for encryption.
+// The returned cleanup function removes that temporary file.
+func encryptForUpload(kr Keyring, owner, source string, options *UploadOptions) (fn string, cleanup func(), err error) {
+	cleanup = func() {}
+	if !options.Encrypt {
+		return source, cleanup, nil
+	}
+	if kr == nil {
+		return "", cleanup, xerrors.Errorf("encryption requested but no key-encryption key is configured")
+	}
+
+	src, err := os.Open(source)
+	if err != nil {
+		return "", cleanup, xerrors.Errorf("cannot open file for encryption: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.CreateTemp(filepath.Dir(source), filepath.Base(source)+".enc-*")
+	if err != nil {
+		return "", cleanup, xerrors.Errorf("cannot create encrypted file: %w", err)
+	}
+	cleanup = func() { os.Remove(dst.Name()) }
+
+	err = encrypt(dst, src, kr, owner)
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		cleanup()
+		return "", func() {}, xerrors.Errorf("cannot encrypt %s: %w", source, err)
+	}
+	return dst.Name(), cleanup, nil
+}
+
+// Decrypt returns a reader which transparently decrypts src. Content which is not encrypted is passed through as is.
+func Decrypt(src io.Reader, kr Keyring) (io.Reader, error) {
+	in := bufio.NewReaderSize(src, encryptionSegmentSize)
+	magic, err := in.Peek(len(encryptionMagic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if string(magic) != encryptionMagic {
+		return in, nil
+	}
+	if kr == nil {
+		return nil, ErrEncryptionKeyMissing
+	}
+
+	prelude := make([]byte, len(encryptionMagic)+4)
+	_, err = io.ReadFull(in, prelude)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read encryption header: %w", err)
+	}
+	hdrLen := binary.BigEndian.Uint32(prelude[len(encryptionMagic):])
+	if hdrLen > maxEncryptionHeaderSize {
+		return nil, xerrors.Errorf("encryption header is too large")
+	}
+	rawHdr := make([]byte, hdrLen)
+	_, err = io.ReadFull(in, rawHdr)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read encryption header: %w", err)
+	}
+	var hdr encryptionHeader
+	err = json.Unmarshal(rawHdr, &hdr)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal encryption header: %w", err)
+	}
+	if len(hdr.NoncePrefix) != encryptionNoncePrefixSize || hdr.SegmentSize <= 0 || hdr.SegmentSize > maxEncryptionSegmentSize {
+		return nil, xerrors.Errorf("invalid encryption header")
+	}
+
+	key, err := kr.UnwrapDataKey(hdr.Owner, hdr.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptingReader{
+		in:   in,
+		aead: aead,
+		hdr:  hdr,
+		seg:  make([]byte, hdr.SegmentSize+aead.Overhead()),
+	}, nil
+}
+
+const maxEncryptionSegmentSize = 16 * 1024 * 1024
+
+type decryptingReader struct {
+	in   *bufio.Reader
+	aead cipher.AEAD
+	hdr  encryptionHeader
+	seg  []byte
+
+	idx  uint32
+	buf  []byte
+	done bool
+}
+
+func (r *decryptingReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		err := r.next()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *decryptingReader) next() error {
+	n, err := io.ReadFull(r.in, r.seg)
+	if err == io.EOF {
+		return xerrors.Errorf("encrypted content is truncated")
+	}
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	var last bool
+	if n < len(r.seg) {
+		last = true
+	} else if _, perr := r.in.Peek(1); perr == io.EOF {
+		last = true
+	}
+
+	r.buf, err = r.aead.Open(r.seg[:0], segmentNonce(r.hdr.NoncePrefix, r.idx, last), r.seg[:n], nil)
+	if err != nil {
+		return xerrors.Errorf("cannot decrypt segment %d: %w", r.idx, err)
+	}
+	r.idx++
+	r.done = last
+	return nil
+}
+
+type decryptingReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// DecryptReadCloser wraps rc so that reading from it transparently decrypts its content
+func DecryptReadCloser(rc io.ReadCloser, kr Keyring) (io.ReadCloser, error) {
+	r, err := Decrypt(rc, kr)
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+	return &decryptingReadCloser{Reader: r, Closer: rc}, nil
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestKeyring(t *testing.T) *KEKKeyring {
+	kr, err := NewKEKKeyring(bytes.Repeat([]byte{42}, dataKeySize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kr
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	kr := newTestKeyring(t)
+	ownerKey, _, err := kr.DataKey("owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Name    string
+		Size    int
+		Keyring Keyring
+		Modify  func([]byte) []byte
+		Error   bool
+	}{
+		{Name: "empty", Size: 0, Keyring: kr},
+		{Name: "single segment", Size: 1000, Keyring: kr},
+		{Name: "exact segments", Size: 2 * encryptionSegmentSize, Keyring: kr},
+		{Name: "many segments", Size: 5*encryptionSegmentSize + 17, Keyring: kr},
+		{Name: "plain data key", Size: 1000, Keyring: DataKeys{"owner": ownerKey}},
+		{Name: "missing keyring", Size: 1000, Error: true},
+		{Name: "other owner's data key", Size: 1000, Keyring: DataKeys{"other": ownerKey}, Error: true},
+		{
+			Name: "tampered", Size: 2 * encryptionSegmentSize, Keyring: kr, Error: true,
+			Modify: func(b []byte) []byte { b[len(b)-100] ^= 1; return b },
+		},
+		{
+			Name: "truncated at segment boundary", Size: 2*encryptionSegmentSize + 10, Keyring: kr, Error: true,
+			Modify: func(b []byte) []byte { return b[:len(b)-(10+16)] },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			content := make([]byte, test.Size)
+			rand.New(rand.NewSource(42)).Read(content)
+
+			var enc bytes.Buffer
+			err := encrypt(&enc, bytes.NewReader(content), kr, "owner")
+			if err != nil {
+				t.Fatal(err)
+			}
+			ciphertext := enc.Bytes()
+			if test.Size > 0 && bytes.Contains(ciphertext, content) {
+				t.Fatal("ciphertext contains the plaintext")
+			}
+			if test.Modify != nil {
+				ciphertext = test.Modify(ciphertext)
+			}
+
+			r, err := Decrypt(bytes.NewReader(ciphertext), test.Keyring)
+			var act []byte
+			if err == nil {
+				act, err = io.ReadAll(r)
+			}
+			if (err != nil) != test.Error {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !test.Error && !bytes.Equal(act, content) {
+				t.Error("decrypted content does not match the original")
+			}
+		})
+	}
+}
+
+func TestDecryptPassesPlaintextThrough(t *testing.T) {
+	for _, content := range []string{"", "GP", "some plain content"} {
+		r, err := Decrypt(bytes.NewReader([]byte(content)), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		act, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(act) != content {
+			t.Errorf("unexpected content: %q != %q", act, content)
+		}
+	}
+}
+
+func TestEncryptedUploadDownload(t *testing.T) {
+	kr := newTestKeyring(t)
+	cfg := newTestFSConfig(t)
+	rs, err := newDirectFSAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs.Keyring = kr
+	ctx := context.Background()
+	err = rs.Init(ctx, "owner", "ws", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	content := []byte("hello world")
+	err = tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "data", Mode: 0644, Size: int64(len(content)), Uid: os.Getuid(), Gid: os.Getgid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = tw.Write(content)
+	tw.Close()
+	src := filepath.Join(t.TempDir(), "backup.tar")
+	err = os.WriteFile(src, buf.Bytes(), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = rs.Upload(ctx, src, DefaultBackup, WithEncryption())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := os.ReadFile(filepath.Join(cfg.BasePath, "gitpod-user-owner", "workspaces", "ws", DefaultBackup))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(stored, []byte(encryptionMagic)) {
+		t.Fatal("stored backup is not encrypted")
+	}
+
+	verify := func(t *testing.T, download func(dst string) (bool, error)) {
+		dst := t.TempDir()
+		found, err := download(dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !found {
+			t.Fatal("backup not found")
+		}
+		act, err := os.ReadFile(filepath.Join(dst, "data"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(act, content) {
+			t.Errorf("unexpected content: %q", act)
+		}
+	}
+	t.Run("Download", func(t *testing.T) {
+		verify(t, func(dst string) (bool, error) { return rs.Download(ctx, dst, DefaultBackup, nil) })
+	})
+	t.Run("DownloadSnapshot", func(t *testing.T) {
+		verify(t, func(dst string) (bool, error) { return rs.DownloadSnapshot(ctx, dst, rs.Qualify(DefaultBackup), nil) })
+	})
+	t.Run("NamedURLDownloader", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(stored) }))
+		defer srv.Close()
+
+		key, _, err := kr.DataKey("owner")
+		if err != nil {
+			t.Fatal(err)
+		}
+		dl := &NamedURLDownloader{URLs: map[string]string{DefaultBackup: srv.URL}, Keyring: DataKeys{"owner": key}}
+		verify(t, func(dst string) (bool, error) { return dl.Download(ctx, dst, DefaultBackup, nil) })
+	})
+
+	rs.Keyring = nil
+	_, err = rs.Download(ctx, t.TempDir(), DefaultBackup, nil)
+	if err == nil {
+		t.Error("expected download without keyring to fail")
+	}
+	_, _, err = rs.Upload(ctx, src, DefaultBackup, WithEncryption())
+	if err == nil {
+		t.Error("expected encrypted upload without keyring to fail")
+	}
+}
@@ -0,0 +1,995 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+)
+
+var _ DirectAccess = &DirectFSStorage{}
+var _ PresignedAccess = &PresignedFSStorage{}
+
+const (
+	// fsMetaDir is the directory below the base path which holds the object metadata
+	fsMetaDir = ".meta"
+	// fsUploadDir is the directory below the base path in which uploads are staged before they're moved in place
+	fsUploadDir = ".uploads"
+
+	fsQueryExpires   = "expires"
+	fsQuerySignature = "signature"
+
+	fsSignedURLTTL = 30 * time.Minute
+)
+
+var fsBucketNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_\-][a-zA-Z0-9._\-]*$`)
+
+// ValidateFileSystemConfig checks if the filesystem storage config is valid
+func ValidateFileSystemConfig(c *config.FileSystemConfig) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.BasePath, validation.Required),
+		validation.Field(&c.SigningKeyFile, validateExistsInFilesystem),
+	)
+}
+
+// fsStore implements the object layout on disk shared by the direct and presigned filesystem storage.
+// Objects live in <basePath>/<bucket>/<object>, their metadata in <basePath>/.meta/<bucket>/<object>.
+type fsStore struct {
+	BasePath string
+}
+
+// fsObjectMeta is the metadata we store alongside each object
+type fsObjectMeta struct {
+	ContentType string            `json:"contentType,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+func (s fsStore) bucketPath(bucket string) (string, error) {
+	if !fsBucketNameRegex.MatchString(bucket) {
+		return "", xerrors.Errorf("invalid bucket name: %s", bucket)
+	}
+	return filepath.Join(s.BasePath, bucket), nil
+}
+
+func (s fsStore) objectPath(bucket, obj string) (string, error) {
+	return s.pathInBucket(s.BasePath, bucket, obj)
+}
+
+func (s fsStore) metaPath(bucket, obj string) (string, error) {
+	return s.pathInBucket(filepath.Join(s.BasePath, fsMetaDir), bucket, obj)
+}
+
+func (s fsStore) pathInBucket(base, bucket, obj string) (string, error) {
+	if !fsBucketNameRegex.MatchString(bucket) {
+		return "", xerrors.Errorf("invalid bucket name: %s", bucket)
+	}
+	obj = strings.TrimPrefix(obj, "/")
+	if obj == "" {
+		return "", xerrors.Errorf("object name must not be empty")
+	}
+
+	bkt := filepath.Join(base, bucket)
+	res := filepath.Join(bkt, filepath.FromSlash(obj))
+	if !strings.HasPrefix(res, bkt+string(filepath.Separator)) {
+		return "", xerrors.Errorf("invalid object name: %s", obj)
+	}
+	return res, nil
+}
+
+// put atomically places the content of src at the object location
+func (s fsStore) put(bucket, obj string, src io.Reader, meta fsObjectMeta) (err error) {
+	dst, err := s.objectPath(bucket, obj)
+	if err != nil {
+		return err
+	}
+	mp, err := s.metaPath(bucket, obj)
+	if err != nil {
+		return err
+	}
+
+	uploadDir := filepath.Join(s.BasePath, fsUploadDir)
+	err = os.MkdirAll(uploadDir, 0755)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(uploadDir, "upload-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	_, err = io.Copy(tmp, src)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(dst), 0755)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp.Name(), dst)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(mp), 0755)
+	if err != nil {
+		return err
+	}
+	md, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(mp, md, 0644)
+}
+
+// copy copies an object within a bucket, including its metadata
+func (s fsStore) copy(bucket, src, dst string) error {
+	sp, err := s.objectPath(bucket, src)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(sp)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	meta, err := s.meta(bucket, src)
+	if err != nil {
+		return err
+	}
+	return s.put(bucket, dst, f, *meta)
+}
+
+func (s fsStore) meta(bucket, obj string) (*fsObjectMeta, error) {
+	mp, err := s.metaPath(bucket, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var res fsObjectMeta
+	md, err := os.ReadFile(mp)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(md, &res)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal metadata of %s: %w", obj, err)
+	}
+	return &res, nil
+}
+
+// list returns the names and sizes of all objects with the given prefix in lexicographical order
+func (s fsStore) list(bucket, prefix string) (objs []string, sizes []int64, err error) {
+	bkt, err := s.bucketPath(bucket)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = filepath.WalkDir(bkt, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == bkt {
+			return filepath.SkipDir
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(bkt, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+		nfo, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		objs = append(objs, name)
+		sizes = append(sizes, nfo.Size())
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return objs, sizes, nil
+}
+
+// delete removes an object and its metadata, and cleans up directories that became empty
+func (s fsStore) delete(bucket, obj string) error {
+	op, err := s.objectPath(bucket, obj)
+	if err != nil {
+		return err
+	}
+	mp, err := s.metaPath(bucket, obj)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(op)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	err = os.Remove(mp)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.WithField("bucket", bucket).WithField("object", obj).WithError(err).Warn("cannot delete object metadata")
+	}
+
+	bkt, _ := s.bucketPath(bucket)
+	removeEmptyParents(filepath.Dir(op), bkt)
+	removeEmptyParents(filepath.Dir(mp), filepath.Join(s.BasePath, fsMetaDir, bucket))
+	return nil
+}
+
+// removeEmptyParents removes dir and its parents up to (but excluding) stop for as long as they're empty
+func removeEmptyParents(dir, stop string) {
+	for dir != stop && strings.HasPrefix(dir, stop) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// newDirectFSAccess provides direct access to the filesystem storage
+func newDirectFSAccess(cfg config.FileSystemConfig) (*DirectFSStorage, error) {
+	if err := ValidateFileSystemConfig(&cfg); err != nil {
+		return nil, err
+	}
+	return &DirectFSStorage{FileSystemConfig: cfg}, nil
+}
+
+// DirectFSStorage stores data in a local or NFS-mounted directory, following the MinIO naming scheme
+type DirectFSStorage struct {
+	Username         string
+	WorkspaceName    string
+	InstanceID       string
+	FileSystemConfig config.FileSystemConfig
+
+	// Keyring decrypts downloaded content and encrypts uploads which ask for it. Can be nil.
+	Keyring Keyring
+
+	store fsStore
+}
+
+// Validate checks if the filesystem storage is configured properly
+func (rs *DirectFSStorage) Validate() error {
+	err := ValidateFileSystemConfig(&rs.FileSystemConfig)
+	if err != nil {
+		return err
+	}
+
+	return validation.ValidateStruct(rs,
+		validation.Field(&rs.Username, validation.Required),
+		validation.Field(&rs.WorkspaceName, validation.Required),
+	)
+}
+
+// Init initializes the remote storage - call this before calling anything else on the interface
+func (rs *DirectFSStorage) Init(ctx context.Context, owner, workspace, instance string) (err error) {
+	rs.Username = owner
+	rs.WorkspaceName = workspace
+	rs.InstanceID = instance
+	err = rs.Validate()
+	if err != nil {
+		return xerrors.Errorf("invalid filesystem remote storage config: %w", err)
+	}
+
+	rs.store = fsStore{BasePath: rs.FileSystemConfig.BasePath}
+	return nil
+}
+
+// EnsureExists makes sure that the remote storage location exists and can be up- or downloaded from
+func (rs *DirectFSStorage) EnsureExists(ctx context.Context) (err error) {
+	return fsEnsureExists(ctx, rs.store, rs.bucketName())
+}
+
+func fsEnsureExists(ctx context.Context, store fsStore, bucket string) (err error) {
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "fs.EnsureExists")
+	defer tracing.FinishSpan(span, &err)
+
+	if store.BasePath == "" {
+		return xerrors.Errorf("no base path available - did you call Init()?")
+	}
+
+	bkt, err := store.bucketPath(bucket)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(bkt, 0755)
+	if err != nil {
+		return xerrors.Errorf("cannot create bucket: %w", err)
+	}
+	return nil
+}
+
+func (rs *DirectFSStorage) download(ctx context.Context, destination string, bkt string, obj string, mappings []archive.IDMapping) (found bool, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "download")
+	span.SetTag("bucket", bkt)
+	span.SetTag("object", obj)
+	defer tracing.FinishSpan(span, &err)
+
+	fn, err := rs.store.objectPath(bkt, obj)
+	if err != nil {
+		return false, err
+	}
+	f, err := os.Open(fn)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	err = extractTarbal(ctx, destination, f, rs.Keyring, mappings)
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// Download takes the latest state from the remote storage and downloads it to a local path
+func (rs *DirectFSStorage) Download(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (bool, error) {
+	return rs.download(ctx, destination, rs.bucketName(), rs.objectName(name), mappings)
+}
+
+// DownloadSnapshot downloads a snapshot. The snapshot name is expected to be one produced by Qualify
+func (rs *DirectFSStorage) DownloadSnapshot(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (bool, error) {
+	bkt, obj, err := ParseSnapshotName(name)
+	if err != nil {
+		return false, err
+	}
+
+	return rs.download(ctx, destination, bkt, obj, mappings)
+}
+
+// DownloadRaw opens an object for reading without extracting it. Returns ErrNotFound if the object does not exist.
+func (rs *DirectFSStorage) DownloadRaw(ctx context.Context, name string) (io.ReadCloser, error) {
+	fn, err := rs.store.objectPath(rs.bucketName(), rs.objectName(name))
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(fn)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return DecryptReadCloser(f, rs.Keyring)
+}
+
+// ListObjects returns all objects found with the given prefix. Returns an empty list if the bucket does not exuist (yet).
+func (rs *DirectFSStorage) ListObjects(ctx context.Context, prefix string) (objects []string, err error) {
+	objects, _, err = rs.store.list(rs.bucketName(), prefix)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list objects: %w", err)
+	}
+	return objects, nil
+}
+
+// Qualify fully qualifies a snapshot name so that it can be downloaded using DownloadSnapshot
+func (rs *DirectFSStorage) Qualify(name string) string {
+	return fmt.Sprintf("%s@%s", rs.objectName(name), rs.bucketName())
+}
+
+// UploadInstance takes all files from a local location and uploads it to the per-instance remote storage
+func (rs *DirectFSStorage) UploadInstance(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, object string, err error) {
+	if rs.InstanceID == "" {
+		return "", "", xerrors.Errorf("instanceID is required to comput object name")
+	}
+	return rs.Upload(ctx, source, InstanceObjectName(rs.InstanceID, name), opts...)
+}
+
+// Upload takes all files from a local location and uploads it to the remote storage
+func (rs *DirectFSStorage) Upload(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, obj string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "fs.Upload")
+	defer tracing.FinishSpan(span, &err)
+
+	options, err := GetUploadOptions(opts)
+	if err != nil {
+		err = xerrors.Errorf("cannot get options: %w", err)
+		return
+	}
+
+	if rs.store.BasePath == "" {
+		err = xerrors.Errorf("no base path available - did you call Init()?")
+		return
+	}
+
+	source, cleanup, err := encryptForUpload(rs.Keyring, rs.Username, source, options)
+	if err != nil {
+		return
+	}
+	defer cleanup()
+
+	sfn, err := os.Open(source)
+	if err != nil {
+		err = xerrors.Errorf("cannot open file for uploading: %w", err)
+		return
+	}
+	defer sfn.Close()
+
+	bucket = rs.bucketName()
+	obj = rs.objectName(name)
+	span.LogKV("bucket", bucket)
+	span.LogKV("obj", obj)
+
+	// maintain backup trail if we're asked to - we do this prior to overwriting the regular backup file
+	// to make sure we're trailing the previous backup.
+	if options.BackupTrail.Enabled {
+		err := rs.trailBackup(bucket, obj, options.BackupTrail.ThisBackupID, options.BackupTrail.TrailLength)
+		if err != nil {
+			log.WithError(err).WithFields(log.OWI(rs.Username, rs.WorkspaceName, rs.InstanceID)).Error("cannot maintain backup trail")
+		}
+	}
+
+	err = rs.store.put(bucket, obj, sfn, fsObjectMeta{
+		ContentType: options.ContentType,
+		Annotations: options.Annotations,
+	})
+	if err != nil {
+		err = xerrors.Errorf("cannot upload %s: %w", obj, err)
+		return
+	}
+
+	if options.BackupHistory {
+		historicObj := rs.objectName(HistoricBackupName(time.Now(), name))
+		err := rs.store.copy(bucket, obj, historicObj)
+		if err != nil {
+			log.WithError(err).WithField("obj", historicObj).Error("cannot add backup to backup history")
+		}
+	}
+
+	return
+}
+
+func (rs *DirectFSStorage) trailBackup(bucket, obj string, backupID string, trailLength int) error {
+	op, err := rs.store.objectPath(bucket, obj)
+	if err != nil {
+		return err
+	}
+	stat, err := os.Stat(op)
+	if errors.Is(err, fs.ErrNotExist) {
+		// first backup - nothing to trail
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// the trailing object is named after the time the backup was made, not the time it was replaced
+	trailPrefix := fmt.Sprintf("%s/trail-", fsWorkspacePrefix(rs.WorkspaceName))
+	err = rs.store.copy(bucket, obj, fmt.Sprintf("%s%d-%s", trailPrefix, stat.ModTime().Unix(), backupID))
+	if err != nil {
+		return err
+	}
+
+	trail, _, err := rs.store.list(bucket, trailPrefix)
+	if err != nil {
+		return err
+	}
+	sort.Strings(trail)
+	for i, oldTrailObj := range trail {
+		if i >= len(trail)-trailLength {
+			break
+		}
+
+		err := rs.store.delete(bucket, oldTrailObj)
+		if err != nil {
+			log.WithError(err).WithField("obj", oldTrailObj).Warn("cannot delete old trailing backup")
+			continue
+		}
+		log.WithField("obj", oldTrailObj).WithField("originalTrailLength", len(trail)).Debug("old trailing object deleted")
+	}
+	return nil
+}
+
+func fsBucketName(ownerID string) string {
+	return fmt.Sprintf("gitpod-user-%s", ownerID)
+}
+
+func fsWorkspacePrefix(workspaceID string) string {
+	return fmt.Sprintf("workspaces/%s", workspaceID)
+}
+
+func fsWorkspaceBackupObjectName(workspaceID string, name string) string {
+	return fmt.Sprintf("%s/%s", fsWorkspacePrefix(workspaceID), name)
+}
+
+// Bucket provides the bucket name for a particular user
+func (rs *DirectFSStorage) Bucket(ownerID string) string {
+	return fsBucketName(ownerID)
+}
+
+// BackupObject returns a backup's object name that a direct downloader would download
+func (rs *DirectFSStorage) BackupObject(name string) string {
+	return rs.objectName(name)
+}
+
+func (rs *DirectFSStorage) bucketName() string {
+	return fsBucketName(rs.Username)
+}
+
+func (rs *DirectFSStorage) objectName(name string) string {
+	return fsWorkspaceBackupObjectName(rs.WorkspaceName, name)
+}
+
+func readFSSigningKey(cfg config.FileSystemConfig) ([]byte, error) {
+	if cfg.SigningKeyFile == "" {
+		return nil, xerrors.Errorf("signingKeyFile is required to sign URLs")
+	}
+	key, err := os.ReadFile(cfg.SigningKeyFile)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read signing key: %w", err)
+	}
+	key = []byte(strings.TrimSpace(string(key)))
+	if len(key) == 0 {
+		return nil, xerrors.Errorf("signing key must not be empty")
+	}
+	return key, nil
+}
+
+func newPresignedFSAccess(cfg config.FileSystemConfig) (*PresignedFSStorage, error) {
+	err := ValidateFileSystemConfig(&cfg)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid config: %w", err)
+	}
+	err = validation.ValidateStruct(&cfg, validation.Field(&cfg.URL, validation.Required))
+	if err != nil {
+		return nil, xerrors.Errorf("invalid config: %w", err)
+	}
+	baseURL, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid URL: %w", err)
+	}
+	key, err := readFSSigningKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PresignedFSStorage{
+		store:      fsStore{BasePath: cfg.BasePath},
+		baseURL:    baseURL,
+		signingKey: key,
+	}, nil
+}
+
+// PresignedFSStorage provides HMAC-signed URLs to access filesystem storage objects.
+// Those URLs are served by the handler produced by NewFileSystemHandler.
+type PresignedFSStorage struct {
+	store      fsStore
+	baseURL    *url.URL
+	signingKey []byte
+}
+
+// EnsureExists makes sure that the remote storage location exists and can be up- or downloaded from
+func (s *PresignedFSStorage) EnsureExists(ctx context.Context, bucket string) (err error) {
+	return fsEnsureExists(ctx, s.store, bucket)
+}
+
+// DiskUsage gives the total objects size of objects that have the given prefix
+func (s *PresignedFSStorage) DiskUsage(ctx context.Context, bucket string, prefix string) (size int64, err error) {
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "fs.DiskUsage")
+	defer tracing.FinishSpan(span, &err)
+
+	if !strings.HasSuffix(prefix, "/") {
+		prefix = prefix + "/"
+	}
+
+	_, sizes, err := s.store.list(bucket, prefix)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, s := range sizes {
+		total += s
+	}
+	return total, nil
+}
+
+// ListObjects returns the names of all objects in the bucket with the given prefix
+func (s *PresignedFSStorage) ListObjects(ctx context.Context, bucket string, prefix string) (objects []string, err error) {
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "fs.ListObjects")
+	defer tracing.FinishSpan(span, &err)
+
+	objects, _, err = s.store.list(bucket, prefix)
+	return objects, err
+}
+
+// SignDownload describes an object for download - if the object is not found, ErrNotFound is returned
+func (s *PresignedFSStorage) SignDownload(ctx context.Context, bucket, obj string, options *SignedURLOptions) (info *DownloadInfo, err error) {
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "fs.SignDownload")
+	defer func() {
+		if err == ErrNotFound {
+			span.LogKV("found", false)
+			tracing.FinishSpan(span, nil)
+			return
+		}
+
+		tracing.FinishSpan(span, &err)
+	}()
+
+	op, err := s.store.objectPath(bucket, obj)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(op)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	meta, err := s.store.meta(bucket, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DownloadInfo{
+		Meta: ObjectMeta{
+			ContentType:        meta.ContentType,
+			OCIMediaType:       meta.Annotations[ObjectAnnotationOCIContentType],
+			Digest:             meta.Annotations[ObjectAnnotationDigest],
+			UncompressedDigest: meta.Annotations[ObjectAnnotationUncompressedDigest],
+		},
+		Size: stat.Size(),
+		URL:  s.signURL(http.MethodGet, bucket, obj, options, time.Now().Add(fsSignedURLTTL)),
+	}, nil
+}
+
+// SignUpload describes an object for upload
+func (s *PresignedFSStorage) SignUpload(ctx context.Context, bucket, obj string, options *SignedURLOptions) (info *UploadInfo, err error) {
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "fs.SignUpload")
+	defer func() {
+		if err == ErrNotFound {
+			span.LogKV("found", false)
+			tracing.FinishSpan(span, nil)
+			return
+		}
+
+		tracing.FinishSpan(span, &err)
+	}()
+
+	bkt, err := s.store.bucketPath(bucket)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(bkt); errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if _, err := s.store.objectPath(bucket, obj); err != nil {
+		return nil, err
+	}
+
+	return &UploadInfo{
+		URL: s.signURL(http.MethodPut, bucket, obj, options, time.Now().Add(fsSignedURLTTL)),
+	}, nil
+}
+
+func (s *PresignedFSStorage) signURL(method, bucket, obj string, options *SignedURLOptions, expires time.Time) string {
+	var contentType string
+	if options != nil {
+		contentType = options.ContentType
+	}
+
+	p := fmt.Sprintf("%s/%s", bucket, strings.TrimPrefix(obj, "/"))
+	exp := strconv.FormatInt(expires.Unix(), 10)
+
+	u := *s.baseURL
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + p
+	u.RawQuery = url.Values{
+		fsQueryExpires:   []string{exp},
+		fsQuerySignature: []string{fsSignature(s.signingKey, method, p, contentType, exp)},
+	}.Encode()
+	return u.String()
+}
+
+// fsSignature computes the HMAC signature of a signed URL
+func fsSignature(key []byte, method, path, contentType, expires string) string {
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s", method, path, contentType, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// DeleteObject deletes objects in the given bucket specified by the given query
+func (s *PresignedFSStorage) DeleteObject(ctx context.Context, bucket string, query *DeleteObjectQuery) (err error) {
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "fs.DeleteObject")
+	defer tracing.FinishSpan(span, &err)
+
+	if query.Name != "" {
+		err = s.store.delete(bucket, query.Name)
+		if err != nil && err != ErrNotFound {
+			log.WithField("bucket", bucket).WithField("object", query.Name).WithError(err).Warn("cannot delete object")
+		}
+		return err
+	}
+
+	prefix := query.Prefix
+	if prefix == "/" {
+		prefix = ""
+	}
+	objs, _, err := s.store.list(bucket, prefix)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		err = s.store.delete(bucket, obj)
+		if err == ErrNotFound {
+			err = nil
+			continue
+		}
+		if err != nil {
+			log.WithField("bucket", bucket).WithField("object", obj).WithError(err).Warn("cannot delete object, continue deleting objects")
+		}
+	}
+	return err
+}
+
+// DeleteBucket deletes a bucket
+func (s *PresignedFSStorage) DeleteBucket(ctx context.Context, bucket string) (err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "fs.DeleteBucket")
+	defer tracing.FinishSpan(span, &err)
+
+	bkt, err := s.store.bucketPath(bucket)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(bkt); errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+
+	err = s.DeleteObject(ctx, bucket, &DeleteObjectQuery{})
+	if err != nil {
+		return err
+	}
+
+	err = os.RemoveAll(filepath.Join(s.store.BasePath, fsMetaDir, bucket))
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(bkt)
+}
+
+// ObjectHash gets a hash value of an object
+func (s *PresignedFSStorage) ObjectHash(ctx context.Context, bucket string, obj string) (hash string, err error) {
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "fs.ObjectHash")
+	defer tracing.FinishSpan(span, &err)
+
+	op, err := s.store.objectPath(bucket, obj)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(op)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := md5.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Bucket provides the bucket name for a particular user
+func (s *PresignedFSStorage) Bucket(ownerID string) string {
+	return fsBucketName(ownerID)
+}
+
+// BlobObject returns a blob's object name
+func (s *PresignedFSStorage) BlobObject(name string) (string, error) {
+	return blobObjectName(name)
+}
+
+// BackupObject returns a backup's object name that a direct downloader would download
+func (s *PresignedFSStorage) BackupObject(workspaceID string, name string) string {
+	return fsWorkspaceBackupObjectName(workspaceID, name)
+}
+
+// InstanceObject returns a instance's object name that a direct downloader would download
+func (s *PresignedFSStorage) InstanceObject(workspaceID string, instanceID string, name string) string {
+	return s.BackupObject(workspaceID, InstanceObjectName(instanceID, name))
+}
+
+// NewFileSystemHandler produces an HTTP handler which serves the URLs signed by the filesystem storage.
+// GET and HEAD requests download an object, PUT requests upload one.
+func NewFileSystemHandler(cfg config.FileSystemConfig) (http.Handler, error) {
+	err := ValidateFileSystemConfig(&cfg)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid config: %w", err)
+	}
+	key, err := readFSSigningKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var basePath string
+	if cfg.URL != "" {
+		u, err := url.Parse(cfg.URL)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid URL: %w", err)
+		}
+		basePath = strings.TrimSuffix(u.Path, "/")
+	}
+
+	return &fsHandler{
+		store:      fsStore{BasePath: cfg.BasePath},
+		basePath:   basePath,
+		signingKey: key,
+	}, nil
+}
+
+type fsHandler struct {
+	store      fsStore
+	basePath   string
+	signingKey []byte
+}
+
+func (h *fsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, h.basePath), "/")
+	segs := strings.SplitN(p, "/", 2)
+	if len(segs) != 2 {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	bucket, obj := segs[0], segs[1]
+
+	method := r.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	var contentType string
+	if method == http.MethodPut {
+		contentType = r.Header.Get("Content-Type")
+	}
+
+	q := r.URL.Query()
+	exp := q.Get(fsQueryExpires)
+	expires, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		http.Error(w, "signature expired", http.StatusForbidden)
+		return
+	}
+	sig, err := hex.DecodeString(q.Get(fsQuerySignature))
+	if err != nil {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+	expected, _ := hex.DecodeString(fsSignature(h.signingKey, method, p, contentType, exp))
+	if !hmac.Equal(sig, expected) {
+		// the URL might have been signed without a content type - in that case any content type goes
+		expected, _ = hex.DecodeString(fsSignature(h.signingKey, method, p, "", exp))
+		if !hmac.Equal(sig, expected) {
+			http.Error(w, "invalid signature", http.StatusForbidden)
+			return
+		}
+	}
+
+	switch method {
+	case http.MethodGet:
+		h.serveObject(w, r, bucket, obj)
+	case http.MethodPut:
+		h.receiveObject(w, r, bucket, obj)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *fsHandler) serveObject(w http.ResponseWriter, r *http.Request, bucket, obj string) {
+	op, err := h.store.objectPath(bucket, obj)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f, err := os.Open(op)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.WithField("bucket", bucket).WithField("object", obj).WithError(err).Error("cannot open object")
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		log.WithField("bucket", bucket).WithField("object", obj).WithError(err).Error("cannot stat object")
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	meta, err := h.store.meta(bucket, obj)
+	if err != nil {
+		log.WithField("bucket", bucket).WithField("object", obj).WithError(err).Warn("cannot read object metadata")
+	} else if meta.ContentType != "" {
+		w.Header().Set("Content-Type", meta.ContentType)
+	}
+	http.ServeContent(w, r, "", stat.ModTime(), f)
+}
+
+func (h *fsHandler) receiveObject(w http.ResponseWriter, r *http.Request, bucket, obj string) {
+	bkt, err := h.store.bucketPath(bucket)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := os.Stat(bkt); errors.Is(err, fs.ErrNotExist) {
+		http.Error(w, "bucket not found", http.StatusNotFound)
+		return
+	}
+
+	err = h.store.put(bucket, obj, r.Body, fsObjectMeta{ContentType: r.Header.Get("Content-Type")})
+	if err != nil {
+		log.WithField("bucket", bucket).WithField("object", obj).WithError(err).Error("cannot store object")
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
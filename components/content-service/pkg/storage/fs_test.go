@@ -0,0 +1,218 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/gitpod-io/gitpod/content-service/api/config"
+)
+
+func newTestFSConfig(t *testing.T) config.FileSystemConfig {
+	base := t.TempDir()
+	keyfile := filepath.Join(t.TempDir(), "key")
+	err := os.WriteFile(keyfile, []byte("not-so-secret\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return config.FileSystemConfig{
+		BasePath:       base,
+		URL:            "http://localhost/storage",
+		SigningKeyFile: keyfile,
+	}
+}
+
+func TestFSObjectPath(t *testing.T) {
+	store := fsStore{BasePath: "/base"}
+	tests := []struct {
+		Name        string
+		Bucket      string
+		Object      string
+		Expectation string
+		Error       bool
+	}{
+		{Name: "simple object", Bucket: "gitpod-user-foo", Object: "workspaces/ws/full.tar", Expectation: "/base/gitpod-user-foo/workspaces/ws/full.tar"},
+		{Name: "leading slash", Bucket: "gitpod-user-foo", Object: "/blobs/foo", Expectation: "/base/gitpod-user-foo/blobs/foo"},
+		{Name: "empty object", Bucket: "gitpod-user-foo", Object: "", Error: true},
+		{Name: "object traversal", Bucket: "gitpod-user-foo", Object: "../gitpod-user-bar/secret", Error: true},
+		{Name: "bucket traversal", Bucket: "..", Object: "foo", Error: true},
+		{Name: "hidden bucket", Bucket: ".meta", Object: "foo", Error: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := store.objectPath(test.Bucket, test.Object)
+			if (err != nil) != test.Error {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if act != test.Expectation {
+				t.Errorf("unexpected object path: is '%s' but expected '%s'", act, test.Expectation)
+			}
+		})
+	}
+}
+
+func TestFSUploadWithBackupTrail(t *testing.T) {
+	cfg := newTestFSConfig(t)
+	rs, err := newDirectFSAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	err = rs.Init(ctx, "owner", "ws", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rs.EnsureExists(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(t.TempDir(), "src.tar")
+	for i, id := range []string{"a", "b", "c", "d"} {
+		err = os.WriteFile(src, []byte(id), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = rs.Upload(ctx, src, DefaultBackup, WithBackupTrail(id, 2), WithAnnotations(map[string]string{"n": id}))
+		if err != nil {
+			t.Fatalf("upload %d: %v", i, err)
+		}
+	}
+
+	objs, err := rs.ListObjects(ctx, "workspaces/ws/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var trail []string
+	for _, o := range objs {
+		if strings.HasPrefix(o, "workspaces/ws/trail-") {
+			trail = append(trail, o)
+		}
+	}
+	if len(objs) != 3 || len(trail) != 2 {
+		t.Errorf("expected the backup and a trail of two, got %v", objs)
+	}
+
+	ctnt, err := os.ReadFile(filepath.Join(cfg.BasePath, "gitpod-user-owner", "workspaces", "ws", DefaultBackup))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(ctnt) != "d" {
+		t.Errorf("unexpected backup content: %s", ctnt)
+	}
+	meta, err := rs.store.meta(rs.bucketName(), rs.objectName(DefaultBackup))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]string{"n": "d"}, meta.Annotations); diff != "" {
+		t.Errorf("unexpected annotations (-want +got):\n%s", diff)
+	}
+}
+
+func TestFSSignedURLs(t *testing.T) {
+	cfg := newTestFSConfig(t)
+	handler, err := NewFileSystemHandler(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.StripPrefix("/storage", handler))
+	defer srv.Close()
+	cfg.URL = srv.URL + "/storage"
+
+	ps, err := newPresignedFSAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		ctx     = context.Background()
+		bucket  = ps.Bucket("owner")
+		obj     = "blobs/foo"
+		content = []byte("hello world")
+	)
+	_, err = ps.SignUpload(ctx, bucket, obj, &SignedURLOptions{})
+	if err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound for missing bucket, got %v", err)
+	}
+	err = ps.EnsureExists(ctx, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ul, err := ps.SignUpload(ctx, bucket, obj, &SignedURLOptions{ContentType: "text/plain"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	put := func(url, contentType string) int {
+		req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if code := put(ul.URL, "application/json"); code != http.StatusForbidden {
+		t.Errorf("expected upload with wrong content type to be forbidden, got %d", code)
+	}
+	if code := put(strings.Replace(ul.URL, "blobs/foo", "blobs/bar", 1), "text/plain"); code != http.StatusForbidden {
+		t.Errorf("expected upload to a different object to be forbidden, got %d", code)
+	}
+	if code := put(ul.URL, "text/plain"); code != http.StatusOK {
+		t.Fatalf("upload failed with status %d", code)
+	}
+
+	dl, err := ps.SignDownload(ctx, bucket, obj, &SignedURLOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dl.Size != int64(len(content)) || dl.Meta.ContentType != "text/plain" {
+		t.Errorf("unexpected download info: %+v", dl)
+	}
+	resp, err := http.Get(dl.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	act, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || !bytes.Equal(act, content) {
+		t.Errorf("unexpected download: status %d, content %q", resp.StatusCode, act)
+	}
+
+	usage, err := ps.DiskUsage(ctx, bucket, "blobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage != int64(len(content)) {
+		t.Errorf("unexpected disk usage: %d", usage)
+	}
+
+	err = ps.DeleteObject(ctx, bucket, &DeleteObjectQuery{Prefix: "blobs/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ps.SignDownload(ctx, bucket, obj, &SignedURLOptions{})
+	if err != ErrNotFound {
+		t.Errorf("expected ErrNotFound after deletion, got %v", err)
+	}
+}
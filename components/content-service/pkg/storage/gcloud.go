@@ -82,6 +82,9 @@ type DirectGCPStorage struct {
 	GCPConfig     config.GCPConfig
 	Stage         config.Stage
 
+	// Keyring decrypts downloaded content and encrypts uploads which ask for it. Can be nil.
+	Keyring Keyring
+
 	client *gcpstorage.Client
 
 	// ObjectAccess just exists so that we can swap out the stream access during testing
@@ -196,7 +199,7 @@ func (rs *DirectGCPStorage) download(ctx context.Context, destination string, bk
 	}
 	defer rc.Close()
 
-	err = extractTarbal(ctx, destination, rc, mappings)
+	err = extractTarbal(ctx, destination, rc, rs.Keyring, mappings)
 	if err != nil {
 		return true, err
 	}
@@ -264,6 +267,21 @@ func (rs *DirectGCPStorage) DownloadSnapshot(ctx context.Context, destination st
 	return rs.download(ctx, destination, bkt, obj, mappings)
 }
 
+// DownloadRaw opens an object for reading without extracting it. Returns ErrNotFound if the object does not exist.
+func (rs *DirectGCPStorage) DownloadRaw(ctx context.Context, name string) (io.ReadCloser, error) {
+	rc, _, err := rs.ObjectAccess(ctx, rs.bucketName(), rs.objectName(name))
+	if errors.Is(err, gcpstorage.ErrObjectNotExist) || errors.Is(err, gcpstorage.ErrBucketNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rc == nil {
+		return nil, ErrNotFound
+	}
+	return DecryptReadCloser(rc, rs.Keyring)
+}
+
 // ParseSnapshotName parses the name of a snapshot into bucket and object
 func ParseSnapshotName(name string) (bkt, obj string, err error) {
 	segments := strings.Split(name, "@")
@@ -332,6 +350,12 @@ func (rs *DirectGCPStorage) Upload(ctx context.Context, source string, name stri
 		return
 	}
 
+	source, cleanup, err := encryptForUpload(rs.Keyring, rs.Username, source, options)
+	if err != nil {
+		return
+	}
+	defer cleanup()
+
 	// check if we have not yet exceeded the max number of backups
 	if name != DefaultBackup {
 		if err = rs.ensureBackupSlotAvailable(); err != nil {
@@ -387,13 +411,14 @@ func (rs *DirectGCPStorage) Upload(ctx context.Context, source string, name stri
 	obj := bkt.Object(object)
 
 	var firstBackup bool
-	if _, e := obj.Attrs(ctx); e == gcpstorage.ErrObjectNotExist {
+	prevAttrs, e := obj.Attrs(ctx)
+	if e == gcpstorage.ErrObjectNotExist {
 		firstBackup = true
 	}
 	// maintain backup trail if we're asked to - we do this prior to overwriting the regular backup file
 	// to make sure we're trailign the previous backup.
-	if options.BackupTrail.Enabled && !firstBackup {
-		err := rs.trailBackup(ctx, bkt, obj, options.BackupTrail.ThisBackupID, options.BackupTrail.TrailLength)
+	if options.BackupTrail.Enabled && !firstBackup && e == nil {
+		err := rs.trailBackup(ctx, bkt, obj, prevAttrs.Created, options.BackupTrail.ThisBackupID, options.BackupTrail.TrailLength)
 		if err != nil {
 			log.WithError(err).Error("cannot maintain backup trail")
 		}
@@ -414,6 +439,14 @@ func (rs *DirectGCPStorage) Upload(ctx context.Context, source string, name stri
 		tracing.FinishSpan(uploadSpan, &err)
 		return
 	}
+
+	if options.BackupHistory {
+		historicObj := bkt.Object(rs.objectName(HistoricBackupName(time.Now(), name)))
+		_, err := historicObj.CopierFrom(obj).Run(ctx)
+		if err != nil {
+			log.WithError(err).WithField("obj", historicObj.ObjectName()).Error("cannot add backup to backup history")
+		}
+	}
 	log.WithField("chunkCount", fmt.Sprintf("%d", len(chunks))).Debug("Composited chunks")
 	uploadSpan.Finish()
 
@@ -607,13 +640,15 @@ func (rs *DirectGCPStorage) deleteChunks(ctx context.Context, chunks []string) (
 	return nil
 }
 
-func (rs *DirectGCPStorage) trailBackup(ctx context.Context, bkt *gcpstorage.BucketHandle, obj *gcpstorage.ObjectHandle, backupID string, trailLength int) (err error) {
+// trailBackup copies obj, which was created at the given time, to the backup trail. The trailing object is named after that
+// time so that the trail records when each of its backups was made.
+func (rs *DirectGCPStorage) trailBackup(ctx context.Context, bkt *gcpstorage.BucketHandle, obj *gcpstorage.ObjectHandle, created time.Time, backupID string, trailLength int) (err error) {
 	//nolint:ineffassign
 	span, ctx := opentracing.StartSpanFromContext(ctx, "uploadChunk")
 	defer tracing.FinishSpan(span, &err)
 
 	trailIter := bkt.Objects(ctx, &gcpstorage.Query{Prefix: rs.trailPrefix()})
-	trailingObj := bkt.Object(rs.trailingObjectName(backupID, time.Now()))
+	trailingObj := bkt.Object(rs.trailingObjectName(backupID, created))
 	_, err = trailingObj.CopierFrom(obj).Run(ctx)
 	if err != nil {
 		return
@@ -820,6 +855,45 @@ func (p *PresignedGCPStorage) DiskUsage(ctx context.Context, bucket string, pref
 	return total, nil
 }
 
+// ListObjects returns the names of all objects in the bucket with the given prefix
+func (p *PresignedGCPStorage) ListObjects(ctx context.Context, bucket string, prefix string) (objects []string, err error) {
+	client, err := newGCPClient(ctx, p.config)
+	if err != nil {
+		return
+	}
+	//nolint:staticcheck
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	bkt := client.Bucket(bucket)
+	_, err = bkt.Attrs(ctx)
+	if errors.Is(err, storage.ErrBucketNotExist) {
+		// bucket does not exist: nothing to list
+		return nil, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list objects: %w", err)
+	}
+
+	it := bkt.Objects(ctx, &storage.Query{
+		Prefix: prefix,
+	})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("cannot iterate list objects: %w", err)
+		}
+		objects = append(objects, attrs.Name)
+	}
+
+	return objects, nil
+}
+
 // SignDownload provides presigned URLs to access remote storage objects
 func (p *PresignedGCPStorage) SignDownload(ctx context.Context, bucket, object string, options *SignedURLOptions) (*DownloadInfo, error) {
 	client, err := newGCPClient(ctx, p.config)
@@ -74,6 +74,9 @@ type DirectMinIOStorage struct {
 	InstanceID    string
 	MinIOConfig   config.MinIOConfig
 
+	// Keyring decrypts downloaded content and encrypts uploads which ask for it. Can be nil.
+	Keyring Keyring
+
 	client *minio.Client
 
 	// ObjectAccess just exists so that we can swap out the stream access during testing
@@ -178,7 +181,7 @@ func (rs *DirectMinIOStorage) download(ctx context.Context, destination string,
 	}
 	defer rc.Close()
 
-	err = extractTarbal(ctx, destination, rc, mappings)
+	err = extractTarbal(ctx, destination, rc, rs.Keyring, mappings)
 	if err != nil {
 		return true, err
 	}
@@ -201,6 +204,18 @@ func (rs *DirectMinIOStorage) DownloadSnapshot(ctx context.Context, destination
 	return rs.download(ctx, destination, bkt, obj, mappings)
 }
 
+// DownloadRaw opens an object for reading without extracting it. Returns ErrNotFound if the object does not exist.
+func (rs *DirectMinIOStorage) DownloadRaw(ctx context.Context, name string) (io.ReadCloser, error) {
+	rc, err := rs.ObjectAccess(ctx, rs.bucketName(), rs.objectName(name))
+	if err != nil {
+		return nil, err
+	}
+	if rc == nil {
+		return nil, ErrNotFound
+	}
+	return DecryptReadCloser(rc, rs.Keyring)
+}
+
 // ListObjects returns all objects found with the given prefix. Returns an empty list if the bucket does not exuist (yet).
 func (rs *DirectMinIOStorage) ListObjects(ctx context.Context, prefix string) (objects []string, err error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -259,6 +274,12 @@ func (rs *DirectMinIOStorage) Upload(ctx context.Context, source string, name st
 		return
 	}
 
+	source, cleanup, err := encryptForUpload(rs.Keyring, rs.Username, source, options)
+	if err != nil {
+		return
+	}
+	defer cleanup()
+
 	// upload the thing
 	bucket = rs.bucketName()
 	obj = rs.objectName(name)
@@ -276,6 +297,17 @@ func (rs *DirectMinIOStorage) Upload(ctx context.Context, source string, name st
 		return
 	}
 
+	if options.BackupHistory {
+		historicObj := rs.objectName(HistoricBackupName(time.Now(), name))
+		_, err := rs.client.CopyObject(ctx,
+			minio.CopyDestOptions{Bucket: bucket, Object: historicObj},
+			minio.CopySrcOptions{Bucket: bucket, Object: obj},
+		)
+		if err != nil {
+			log.WithError(err).WithField("obj", historicObj).Error("cannot add backup to backup history")
+		}
+	}
+
 	return
 }
 
@@ -345,6 +377,37 @@ func (s *presignedMinIOStorage) DiskUsage(ctx context.Context, bucket string, pr
 	return total, nil
 }
 
+// ListObjects returns the names of all objects in the bucket with the given prefix
+func (s *presignedMinIOStorage) ListObjects(ctx context.Context, bucket string, prefix string) (objects []string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "minio.ListObjects")
+	defer tracing.FinishSpan(span, &err)
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	exists, err := s.client.BucketExists(ctx, bucket)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list objects: %w", err)
+	}
+	if !exists {
+		// bucket does not exist: nothing to list
+		return nil, nil
+	}
+
+	objectCh := s.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	})
+	for object := range objectCh {
+		if object.Err != nil {
+			return nil, xerrors.Errorf("cannot iterate list objects: %w", object.Err)
+		}
+		objects = append(objects, object.Key)
+	}
+	return objects, nil
+}
+
 func (s *presignedMinIOStorage) SignDownload(ctx context.Context, bucket, object string, options *SignedURLOptions) (info *DownloadInfo, err error) {
 	//nolint:ineffassign
 	span, ctx := opentracing.StartSpanFromContext(ctx, "minio.SignDownload")
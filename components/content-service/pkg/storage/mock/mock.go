@@ -10,6 +10,7 @@ package mock
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	archive "github.com/gitpod-io/gitpod/content-service/pkg/archive"
@@ -154,6 +155,21 @@ func (mr *MockPresignedAccessMockRecorder) InstanceObject(arg0, arg1, arg2 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceObject", reflect.TypeOf((*MockPresignedAccess)(nil).InstanceObject), arg0, arg1, arg2)
 }
 
+// ListObjects mocks base method.
+func (m *MockPresignedAccess) ListObjects(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjects", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjects indicates an expected call of ListObjects.
+func (mr *MockPresignedAccessMockRecorder) ListObjects(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjects", reflect.TypeOf((*MockPresignedAccess)(nil).ListObjects), arg0, arg1, arg2)
+}
+
 // ObjectHash mocks base method.
 func (m *MockPresignedAccess) ObjectHash(arg0 context.Context, arg1, arg2 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -265,6 +281,21 @@ func (mr *MockDirectAccessMockRecorder) Download(arg0, arg1, arg2, arg3 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockDirectAccess)(nil).Download), arg0, arg1, arg2, arg3)
 }
 
+// DownloadRaw mocks base method.
+func (m *MockDirectAccess) DownloadRaw(arg0 context.Context, arg1 string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadRaw", arg0, arg1)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadRaw indicates an expected call of DownloadRaw.
+func (mr *MockDirectAccessMockRecorder) DownloadRaw(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadRaw", reflect.TypeOf((*MockDirectAccess)(nil).DownloadRaw), arg0, arg1)
+}
+
 // DownloadSnapshot mocks base method.
 func (m *MockDirectAccess) DownloadSnapshot(arg0 context.Context, arg1, arg2 string, arg3 []archive.IDMapping) (bool, error) {
 	m.ctrl.T.Helper()
@@ -6,6 +6,7 @@ package storage
 
 import (
 	"context"
+	"io"
 	"net/http"
 
 	"golang.org/x/xerrors"
@@ -16,6 +17,9 @@ import (
 // NamedURLDownloader offers downloads from fixed URLs
 type NamedURLDownloader struct {
 	URLs map[string]string
+
+	// Keyring decrypts encrypted content. Can be nil if no encrypted content is expected.
+	Keyring Keyring
 }
 
 // Download takes the latest state from the remote storage and downloads it to a local path
@@ -42,7 +46,7 @@ func (d *NamedURLDownloader) Download(ctx context.Context, destination string, n
 	}
 	defer resp.Body.Close()
 
-	err = extractTarbal(ctx, destination, resp.Body, mappings)
+	err = extractTarbal(ctx, destination, resp.Body, d.Keyring, mappings)
 	if err != nil {
 		return true, err
 	}
@@ -54,3 +58,31 @@ func (d *NamedURLDownloader) Download(ctx context.Context, destination string, n
 func (d *NamedURLDownloader) DownloadSnapshot(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (found bool, err error) {
 	return d.Download(ctx, destination, name, mappings)
 }
+
+// DownloadRaw opens an object for reading without extracting it. Returns ErrNotFound if the object does not exist.
+func (d *NamedURLDownloader) DownloadRaw(ctx context.Context, name string) (io.ReadCloser, error) {
+	url, found := d.URLs[name]
+	if !found {
+		return nil, ErrNotFound
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, xerrors.Errorf("non-OK status code: %v", resp.StatusCode)
+	}
+
+	return DecryptReadCloser(resp.Body, d.Keyring)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+)
+
+// ExpiredBackups returns the timestamps of all backups which the retention policy does not keep.
+// Hourly and daily rules keep the most recent backup of the most recent hours/days which have a backup at all,
+// hence a workspace which hasn't run for a while does not lose its history.
+func ExpiredBackups(policy config.BackupRetentionConfig, backups []time.Time) []time.Time {
+	if policy.KeepLast <= 0 && policy.Hourly <= 0 && policy.Daily <= 0 {
+		return nil
+	}
+
+	sorted := make([]time.Time, len(backups))
+	copy(sorted, backups)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].After(sorted[j]) })
+
+	var (
+		keep                = make([]bool, len(sorted))
+		lastHour, lastDay   string
+		keptHours, keptDays int
+	)
+	for i, t := range sorted {
+		t = t.UTC()
+		if i < policy.KeepLast {
+			keep[i] = true
+		}
+		if hour := t.Format("2006-01-02T15"); hour != lastHour && keptHours < policy.Hourly {
+			lastHour = hour
+			keptHours++
+			keep[i] = true
+		}
+		if day := t.Format("2006-01-02"); day != lastDay && keptDays < policy.Daily {
+			lastDay = day
+			keptDays++
+			keep[i] = true
+		}
+	}
+
+	var res []time.Time
+	for i, t := range sorted {
+		if !keep[i] {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// EnforceBackupRetention deletes all backups from the workspace's backup history which the retention policy does not keep
+func EnforceBackupRetention(ctx context.Context, s PresignedAccess, ownerID, workspaceID string, policy config.BackupRetentionConfig) (deleted int, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EnforceBackupRetention")
+	span.SetTag("workspaceId", workspaceID)
+	defer tracing.FinishSpan(span, &err)
+
+	backups, err := ListBackups(ctx, s, ownerID, workspaceID)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		bucket = s.Bucket(ownerID)
+		ids    = make(map[time.Time]string)
+		times  []time.Time
+	)
+	for _, b := range backups {
+		if !strings.HasPrefix(b.ID, BackupHistoryPrefix) {
+			// trailing backups are subject to the backup trail length, not the retention policy
+			continue
+		}
+		ids[b.Created] = b.ID
+		times = append(times, b.Created)
+	}
+
+	expired := ExpiredBackups(policy, times)
+	span.LogKV("backups", len(times), "expired", len(expired))
+	for _, t := range expired {
+		err = s.DeleteObject(ctx, bucket, &DeleteObjectQuery{Prefix: s.BackupObject(workspaceID, ids[t]+"/")})
+		if err != nil && err != ErrNotFound {
+			return deleted, xerrors.Errorf("cannot delete backup from %s: %w", t.UTC().Format(time.RFC3339), err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
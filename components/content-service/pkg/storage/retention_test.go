@@ -0,0 +1,147 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/gitpod-io/gitpod/content-service/api/config"
+)
+
+func TestExpiredBackups(t *testing.T) {
+	base := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return base.Add(d) }
+	backups := []time.Time{
+		at(0),
+		at(-10 * time.Minute),
+		at(-20 * time.Minute),
+		at(-70 * time.Minute),
+		at(-130 * time.Minute),
+		at(-24 * time.Hour),
+		at(-24*time.Hour - 10*time.Minute),
+		at(-72 * time.Hour),
+	}
+
+	tests := []struct {
+		Name        string
+		Policy      config.BackupRetentionConfig
+		Expectation []time.Time
+	}{
+		{
+			Name:   "no rules",
+			Policy: config.BackupRetentionConfig{Enabled: true},
+		},
+		{
+			Name:   "keep last",
+			Policy: config.BackupRetentionConfig{KeepLast: 6},
+			Expectation: []time.Time{
+				at(-24*time.Hour - 10*time.Minute),
+				at(-72 * time.Hour),
+			},
+		},
+		{
+			Name:   "hourly",
+			Policy: config.BackupRetentionConfig{Hourly: 3},
+			Expectation: []time.Time{
+				at(-20 * time.Minute),
+				at(-130 * time.Minute),
+				at(-24 * time.Hour),
+				at(-24*time.Hour - 10*time.Minute),
+				at(-72 * time.Hour),
+			},
+		},
+		{
+			Name:   "daily",
+			Policy: config.BackupRetentionConfig{Daily: 2},
+			Expectation: []time.Time{
+				at(-10 * time.Minute),
+				at(-20 * time.Minute),
+				at(-70 * time.Minute),
+				at(-130 * time.Minute),
+				at(-24*time.Hour - 10*time.Minute),
+				at(-72 * time.Hour),
+			},
+		},
+		{
+			Name:   "combined",
+			Policy: config.BackupRetentionConfig{KeepLast: 2, Hourly: 2, Daily: 3},
+			Expectation: []time.Time{
+				at(-20 * time.Minute),
+				at(-70 * time.Minute),
+				at(-130 * time.Minute),
+				at(-24*time.Hour - 10*time.Minute),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := ExpiredBackups(test.Policy, backups)
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected expired backups (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnforceBackupRetention(t *testing.T) {
+	cfg := newTestFSConfig(t)
+	rs, err := newDirectFSAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	err = rs.Init(ctx, "owner", "ws", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(t.TempDir(), "src.tar")
+	err = os.WriteFile(src, []byte("content"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 4; i++ {
+		_, _, err = rs.Upload(ctx, src, DefaultBackup, WithBackupHistory())
+		if err != nil {
+			t.Fatalf("upload %d: %v", i, err)
+		}
+	}
+
+	ps, err := newPresignedFSAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	history := ps.BackupObject("ws", BackupHistoryPrefix)
+	objs, err := ps.ListObjects(ctx, ps.Bucket("owner"), history)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 4 {
+		t.Fatalf("expected four backups in the history, got %v", objs)
+	}
+
+	deleted, err := EnforceBackupRetention(ctx, ps, "owner", "ws", config.BackupRetentionConfig{Enabled: true, KeepLast: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 3 {
+		t.Errorf("expected three deleted backups, got %d", deleted)
+	}
+
+	remaining, err := ps.ListObjects(ctx, ps.Bucket("owner"), history)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(objs[len(objs)-1:], remaining); diff != "" {
+		t.Errorf("unexpected remaining backups (-want +got):\n%s", diff)
+	}
+}
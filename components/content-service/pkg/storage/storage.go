@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"time"
 
 	"golang.org/x/xerrors"
 
@@ -28,6 +29,9 @@ const (
 
 	// FmtFullWorkspaceBackup is the format for names of full workspace backups
 	FmtFullWorkspaceBackup = "wsfull-%d.tar"
+
+	// BackupHistoryPrefix is the prefix of all objects in a workspace's backup history
+	BackupHistoryPrefix = "history/"
 )
 
 var (
@@ -70,6 +74,9 @@ type PresignedAccess interface {
 	// DiskUsage gives the total objects size of objects that have the given prefix
 	DiskUsage(ctx context.Context, bucket string, prefix string) (size int64, err error)
 
+	// ListObjects returns the names of all objects in the bucket with the given prefix. Returns an empty list if the bucket does not exist.
+	ListObjects(ctx context.Context, bucket string, prefix string) ([]string, error)
+
 	// SignDownload describes an object for download - if the object is not found, ErrNotFound is returned
 	SignDownload(ctx context.Context, bucket, obj string, options *SignedURLOptions) (info *DownloadInfo, err error)
 
@@ -133,6 +140,9 @@ type DirectDownloader interface {
 
 	// Downloads a snapshot. The snapshot name is expected to be one produced by Qualify
 	DownloadSnapshot(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (found bool, err error)
+
+	// DownloadRaw opens an object for reading without extracting it. Returns ErrNotFound if the object does not exist.
+	DownloadRaw(ctx context.Context, name string) (io.ReadCloser, error)
 }
 
 // DirectAccess represents a remote location where we can store data
@@ -172,6 +182,12 @@ type UploadOptions struct {
 	Annotations map[string]string
 
 	ContentType string
+
+	// Encrypt encrypts the object with the owner's data key before it is uploaded
+	Encrypt bool
+
+	// BackupHistory adds a copy of the uploaded object to the workspace's backup history
+	BackupHistory bool
 }
 
 // UploadOption configures a particular aspect of remote storage upload
@@ -195,6 +211,20 @@ func WithBackupTrail(thisBackupID string, trailLength int) UploadOption {
 	}
 }
 
+// WithBackupHistory adds a timestamped copy of the uploaded object to the workspace's backup history.
+// Unlike the backup trail, the history is not pruned on upload but by EnforceBackupRetention.
+func WithBackupHistory() UploadOption {
+	return func(opts *UploadOptions) error {
+		opts.BackupHistory = true
+		return nil
+	}
+}
+
+// HistoricBackupName returns the name of a backup's copy in the backup history
+func HistoricBackupName(t time.Time, name string) string {
+	return fmt.Sprintf("%s%d/%s", BackupHistoryPrefix, t.UnixNano(), name)
+}
+
 // WithAnnotations adds arbitrary metadata to a storage object
 func WithAnnotations(md map[string]string) UploadOption {
 	return func(opts *UploadOptions) error {
@@ -241,11 +271,33 @@ func NewDirectAccess(c *config.StorageConfig) (DirectAccess, error) {
 		return nil, xerrors.Errorf("missing storage stage")
 	}
 
+	kr, err := NewKeyring(c.Encryption)
+	if err != nil {
+		return nil, err
+	}
+
 	switch c.Kind {
 	case config.GCloudStorage:
-		return newDirectGCPAccess(c.GCloudConfig, stage)
+		rs, err := newDirectGCPAccess(c.GCloudConfig, stage)
+		if err != nil {
+			return nil, err
+		}
+		rs.Keyring = kr
+		return rs, nil
 	case config.MinIOStorage:
-		return newDirectMinIOAccess(c.MinIOConfig)
+		rs, err := newDirectMinIOAccess(c.MinIOConfig)
+		if err != nil {
+			return nil, err
+		}
+		rs.Keyring = kr
+		return rs, nil
+	case config.FileSystemStorage:
+		rs, err := newDirectFSAccess(c.FileSystemConfig)
+		if err != nil {
+			return nil, err
+		}
+		rs.Keyring = kr
+		return rs, nil
 	default:
 		return &DirectNoopStorage{}, nil
 	}
@@ -263,14 +315,21 @@ func NewPresignedAccess(c *config.StorageConfig) (PresignedAccess, error) {
 		return newPresignedGCPAccess(c.GCloudConfig, stage)
 	case config.MinIOStorage:
 		return newPresignedMinIOAccess(c.MinIOConfig)
+	case config.FileSystemStorage:
+		return newPresignedFSAccess(c.FileSystemConfig)
 	default:
 		log.Warnf("falling back to noop presigned storage access. Is this intentional? (storage kind: %s)", c.Kind)
 		return &PresignedNoopStorage{}, nil
 	}
 }
 
-func extractTarbal(ctx context.Context, dest string, src io.Reader, mappings []archive.IDMapping) error {
-	err := archive.ExtractTarbal(ctx, src, dest, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
+func extractTarbal(ctx context.Context, dest string, src io.Reader, kr Keyring, mappings []archive.IDMapping) error {
+	src, err := Decrypt(src, kr)
+	if err != nil {
+		return xerrors.Errorf("tar %s: %w", dest, err)
+	}
+
+	err = archive.ExtractTarbal(ctx, src, dest, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
 	if err != nil {
 		return xerrors.Errorf("tar %s: %s", dest, err.Error())
 	}
@@ -0,0 +1,84 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+var portsAwaitOpts struct {
+	Exposed bool
+	Timeout time.Duration
+}
+
+var portsAwaitCmd = &cobra.Command{
+	Use:   "await <port>",
+	Short: "Waits for a process to serve a port",
+	Long: `
+Waits for a process to serve a port. With --exposed, waits until the port is exposed, too,
+and prints its URL.
+	`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		port, protocol, err := parsePort(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ctx := context.Background()
+		if portsAwaitOpts.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, portsAwaitOpts.Timeout)
+			defer cancel()
+		}
+
+		conn, err := dialSupervisor()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer conn.Close()
+
+		p, err := awaitPort(ctx, conn, port, protocol, func(p *supervisor.PortsStatus) bool {
+			return p.Served && (!portsAwaitOpts.Exposed || p.Exposed != nil)
+		})
+		if err != nil {
+			log.Fatalf("port %s did not become available: %v", args[0], err)
+		}
+		if portsAwaitOpts.Exposed {
+			fmt.Println(p.Exposed.Url)
+		}
+	},
+}
+
+// awaitPort observes the ports until the status of a port satisfies the condition
+func awaitPort(ctx context.Context, conn *grpc.ClientConn, port uint32, protocol supervisor.PortProtocol, cond func(*supervisor.PortsStatus) bool) (*supervisor.PortsStatus, error) {
+	stream, err := supervisor.NewStatusServiceClient(conn).PortsStatus(ctx, &supervisor.PortsStatusRequest{Observe: true})
+	if err != nil {
+		return nil, err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if p := findPort(resp.Ports, port, protocol); p != nil && cond(p) {
+			return p, nil
+		}
+	}
+}
+
+func init() {
+	portsCmd.AddCommand(portsAwaitCmd)
+	portsAwaitCmd.Flags().BoolVar(&portsAwaitOpts.Exposed, "exposed", false, "wait until the port is exposed, too, and print its URL")
+	portsAwaitCmd.Flags().DurationVar(&portsAwaitOpts.Timeout, "timeout", 0, "give up after this time, e.g. 5m (waits forever by default)")
+}
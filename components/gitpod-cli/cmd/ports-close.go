@@ -0,0 +1,56 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+var portsCloseCmd = &cobra.Command{
+	Use:   "close <port>",
+	Short: "Makes an exposed port unavailable on the internet",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		port, protocol, err := parsePort(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		if protocol != supervisor.PortProtocol_tcp {
+			log.Fatalf("%s ports cannot be exposed, use gp tunnel instead", protocol)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+		defer cancel()
+
+		conn, err := dialSupervisor()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer conn.Close()
+
+		client, workspaceID, err := connectToServerForPorts(ctx, conn)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer client.Close()
+
+		err = client.ClosePort(ctx, workspaceID, float32(port))
+		if err != nil {
+			log.Fatalf("cannot close port %d: %v", port, err)
+		}
+		fmt.Printf("port %d is closed\n", port)
+	},
+}
+
+func init() {
+	portsCmd.AddCommand(portsCloseCmd)
+}
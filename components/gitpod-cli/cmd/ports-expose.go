@@ -0,0 +1,65 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+var portsExposeCmd = &cobra.Command{
+	Use:   "expose <port> [target-port]",
+	Short: "Makes a port available on the internet",
+	Long: `
+Makes a port available on the internet, initially to the workspace owner only (see gp ports visibility).
+If the port is bound to localhost only, supervisor proxies it from the target port, which defaults to the port.
+	`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		port, protocol, err := parsePort(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		if protocol != supervisor.PortProtocol_tcp {
+			log.Fatalf("%s ports cannot be exposed, use gp tunnel instead", protocol)
+		}
+		var targetPort uint64
+		if len(args) > 1 {
+			targetPort, err = strconv.ParseUint(args[1], 10, 16)
+			if err != nil {
+				log.Fatalf("target-port cannot be parsed as int: %s", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+		defer cancel()
+
+		conn, err := dialSupervisor()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer conn.Close()
+
+		_, err = supervisor.NewControlServiceClient(conn).ExposePort(ctx, &supervisor.ExposePortRequest{
+			Port:       port,
+			TargetPort: uint32(targetPort),
+		})
+		if err != nil {
+			log.Fatalf("cannot expose port %d: %v", port, err)
+		}
+		fmt.Printf("port %d is exposed\n", port)
+	},
+}
+
+func init() {
+	portsCmd.AddCommand(portsExposeCmd)
+}
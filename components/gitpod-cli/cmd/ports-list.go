@@ -0,0 +1,140 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+var portsListOpts struct {
+	JSON bool
+}
+
+type portsListEntry struct {
+	Port        uint32             `json:"port"`
+	Protocol    string             `json:"protocol"`
+	Served      bool               `json:"served"`
+	Pid         uint32             `json:"pid,omitempty"`
+	ProcessName string             `json:"processName,omitempty"`
+	Exposed     *portsListExposed  `json:"exposed,omitempty"`
+	Tunneled    *portsListTunneled `json:"tunneled,omitempty"`
+}
+
+type portsListExposed struct {
+	GlobalPort uint32 `json:"globalPort"`
+	Visibility string `json:"visibility"`
+	URL        string `json:"url"`
+}
+
+type portsListTunneled struct {
+	TargetPort uint32            `json:"targetPort"`
+	Visibility string            `json:"visibility"`
+	Clients    map[string]uint32 `json:"clients,omitempty"`
+}
+
+var portsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the ports of this workspace and whether they are served, exposed and tunneled",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		conn, err := dialSupervisor()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer conn.Close()
+
+		ports, err := getPorts(ctx, conn)
+		if err != nil {
+			log.Fatal(err)
+		}
+		sort.Slice(ports, func(i, j int) bool {
+			if ports[i].LocalPort == ports[j].LocalPort {
+				return ports[i].Protocol < ports[j].Protocol
+			}
+			return ports[i].LocalPort < ports[j].LocalPort
+		})
+
+		if portsListOpts.JSON {
+			entries := make([]portsListEntry, 0, len(ports))
+			for _, p := range ports {
+				entries = append(entries, newPortsListEntry(p))
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			err = enc.Encode(entries)
+			if err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "PORT\tSTATUS\tVISIBILITY\tURL\tTUNNEL\tPROCESS")
+		for _, p := range ports {
+			status := "not served"
+			if p.Served {
+				status = "served"
+			}
+			visibility, url := "-", "-"
+			if p.Exposed != nil {
+				visibility, url = p.Exposed.Visibility.String(), p.Exposed.Url
+			}
+			tunnel := "-"
+			if p.Tunneled != nil {
+				tunnel = fmt.Sprintf("%d (%s, %d clients)", p.Tunneled.TargetPort, p.Tunneled.Visibility, len(p.Tunneled.Clients))
+			}
+			process := "-"
+			if p.ProcessName != "" {
+				process = fmt.Sprintf("%s (%d)", p.ProcessName, p.Pid)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", portName(p), status, visibility, url, tunnel, process)
+		}
+		w.Flush()
+	},
+}
+
+func newPortsListEntry(p *supervisor.PortsStatus) portsListEntry {
+	entry := portsListEntry{
+		Port:        p.LocalPort,
+		Protocol:    p.Protocol.String(),
+		Served:      p.Served,
+		Pid:         p.Pid,
+		ProcessName: p.ProcessName,
+	}
+	if p.Exposed != nil {
+		entry.Exposed = &portsListExposed{
+			GlobalPort: p.GlobalPort,
+			Visibility: p.Exposed.Visibility.String(),
+			URL:        p.Exposed.Url,
+		}
+	}
+	if p.Tunneled != nil {
+		entry.Tunneled = &portsListTunneled{
+			TargetPort: p.Tunneled.TargetPort,
+			Visibility: p.Tunneled.Visibility.String(),
+			Clients:    p.Tunneled.Clients,
+		}
+	}
+	return entry
+}
+
+func init() {
+	portsCmd.AddCommand(portsListCmd)
+	portsListCmd.Flags().BoolVar(&portsListOpts.JSON, "json", false, "print the ports as JSON")
+}
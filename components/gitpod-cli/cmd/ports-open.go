@@ -0,0 +1,53 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var portsOpenOpts struct {
+	External bool
+}
+
+var portsOpenCmd = &cobra.Command{
+	Use:   "open <port>",
+	Short: "Opens the URL of an exposed port in the IDE's preview",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		port, protocol, err := parsePort(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		conn, err := dialSupervisor()
+		if err != nil {
+			log.Fatal(err)
+		}
+		ports, err := getPorts(ctx, conn)
+		conn.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		p := findPort(ports, port, protocol)
+		if p == nil || p.Exposed == nil || p.Exposed.Url == "" {
+			log.Fatalf("port %s is not exposed, see gp ports expose", args[0])
+		}
+		previewURL(p.Exposed.Url, portsOpenOpts.External)
+	},
+}
+
+func init() {
+	portsCmd.AddCommand(portsOpenCmd)
+	portsOpenCmd.Flags().BoolVar(&portsOpenOpts.External, "external", false, "open the URL in a new browser tab")
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+var portsShareOpts struct {
+	TTL      time.Duration
+	ReadOnly bool
+}
+
+var portsShareCmd = &cobra.Command{
+	Use:   "share <port>",
+	Short: "Prints a link which grants access to a private port until it expires",
+	Long: `
+Prints a link which grants access to an exposed port to anyone who has it until it expires,
+without making the port public. Read-only links grant GET and HEAD requests only.
+	`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		port, err := strconv.ParseUint(args[0], 10, 16)
+		if err != nil {
+			log.Fatalf("port cannot be parsed as int: %s", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		conn, err := dialSupervisor()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer conn.Close()
+
+		resp, err := supervisor.NewPortServiceClient(conn).SharePort(ctx, &supervisor.SharePortRequest{
+			Port:       uint32(port),
+			TtlSeconds: int64(portsShareOpts.TTL.Seconds()),
+			ReadOnly:   portsShareOpts.ReadOnly,
+		})
+		if err != nil {
+			log.Fatalf("cannot share port %d: %v", port, err)
+		}
+		fmt.Println(resp.Url)
+		fmt.Printf("valid until %s\n", resp.ExpiresAt.AsTime().Local().Format(time.RFC1123))
+	},
+}
+
+func init() {
+	portsCmd.AddCommand(portsShareCmd)
+	portsShareCmd.Flags().DurationVar(&portsShareOpts.TTL, "ttl", time.Hour, "time the link grants access for")
+	portsShareCmd.Flags().BoolVar(&portsShareOpts.ReadOnly, "read-only", false, "grant GET and HEAD requests only")
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	serverapi "github.com/gitpod-io/gitpod/gitpod-protocol"
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+var portsVisibilityCmd = &cobra.Command{
+	Use:       "visibility <port> public|private",
+	Short:     "Makes a port accessible to everyone or to the workspace owner only",
+	Long:      "Makes a port accessible to everyone or to the workspace owner only. The port is exposed if it is not yet.",
+	Args:      cobra.ExactArgs(2),
+	ValidArgs: []string{"public", "private"},
+	Run: func(cmd *cobra.Command, args []string) {
+		port, protocol, err := parsePort(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		if protocol != supervisor.PortProtocol_tcp {
+			log.Fatalf("%s ports cannot be exposed, use gp tunnel instead", protocol)
+		}
+		visibility := args[1]
+		if visibility != "public" && visibility != "private" {
+			log.Fatalf("visibility must be public or private, not %s", visibility)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+		defer cancel()
+
+		conn, err := dialSupervisor()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer conn.Close()
+
+		ports, err := getPorts(ctx, conn)
+		if err != nil {
+			log.Fatal(err)
+		}
+		targetPort := port
+		if p := findPort(ports, port, protocol); p != nil && p.GlobalPort != 0 {
+			targetPort = p.GlobalPort
+		}
+
+		client, workspaceID, err := connectToServerForPorts(ctx, conn)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer client.Close()
+
+		_, err = client.OpenPort(ctx, workspaceID, &serverapi.WorkspaceInstancePort{
+			Port:       float64(port),
+			TargetPort: float64(targetPort),
+			Visibility: visibility,
+		})
+		if err != nil {
+			log.Fatalf("cannot make port %d %s: %v", port, visibility, err)
+		}
+		fmt.Printf("port %d is %s\n", port, visibility)
+	},
+}
+
+func init() {
+	portsCmd.AddCommand(portsVisibilityCmd)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+
+	serverapi "github.com/gitpod-io/gitpod/gitpod-protocol"
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+// portsCmd represents the ports command
+var portsCmd = &cobra.Command{
+	Use:   "ports",
+	Short: "Interact with the ports of this workspace",
+	Long: `
+Interact with the ports of this workspace.
+Ports are addressed by their number, UDP ports by their number followed by /udp, e.g. 5353/udp.
+	`,
+}
+
+func init() {
+	rootCmd.AddCommand(portsCmd)
+}
+
+// getPorts returns the current status of all ports
+func getPorts(ctx context.Context, conn *grpc.ClientConn) ([]*supervisor.PortsStatus, error) {
+	stream, err := supervisor.NewStatusServiceClient(conn).PortsStatus(ctx, &supervisor.PortsStatusRequest{})
+	if err != nil {
+		return nil, xerrors.Errorf("failed getting ports from supervisor: %w", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, xerrors.Errorf("failed getting ports from supervisor: %w", err)
+	}
+	return resp.Ports, nil
+}
+
+// parsePort parses a port as understood by findPort, i.e. <port>[/tcp|/udp]
+func parsePort(spec string) (uint32, supervisor.PortProtocol, error) {
+	protocol := supervisor.PortProtocol_tcp
+	if segs := strings.SplitN(spec, "/", 2); len(segs) == 2 {
+		p, known := supervisor.PortProtocol_value[segs[1]]
+		if !known {
+			return 0, protocol, xerrors.Errorf("unknown protocol %s, expected tcp or udp", segs[1])
+		}
+		protocol = supervisor.PortProtocol(p)
+		spec = segs[0]
+	}
+	port, err := strconv.ParseUint(spec, 10, 16)
+	if err != nil || port == 0 {
+		return 0, protocol, xerrors.Errorf("port %s cannot be parsed as port number", spec)
+	}
+	return uint32(port), protocol, nil
+}
+
+// findPort returns the status of a port, or nil if it is not known to supervisor
+func findPort(ports []*supervisor.PortsStatus, port uint32, protocol supervisor.PortProtocol) *supervisor.PortsStatus {
+	for _, p := range ports {
+		if p.LocalPort == port && p.Protocol == protocol {
+			return p
+		}
+	}
+	return nil
+}
+
+// portName returns the name of a port as understood by parsePort
+func portName(p *supervisor.PortsStatus) string {
+	if p.Protocol == supervisor.PortProtocol_tcp {
+		return strconv.Itoa(int(p.LocalPort))
+	}
+	return fmt.Sprintf("%d/%s", p.LocalPort, p.Protocol)
+}
+
+// connectToServerForPorts connects to the Gitpod server with permission to open and close ports of this workspace
+func connectToServerForPorts(ctx context.Context, conn *grpc.ClientConn) (client *serverapi.APIoverJSONRPC, workspaceID string, err error) {
+	wsinfo, err := supervisor.NewInfoServiceClient(conn).WorkspaceInfo(ctx, &supervisor.WorkspaceInfoRequest{})
+	if err != nil {
+		return nil, "", xerrors.Errorf("failed getting workspace info from supervisor: %w", err)
+	}
+	clientToken, err := supervisor.NewTokenServiceClient(conn).GetToken(ctx, &supervisor.GetTokenRequest{
+		Host: wsinfo.GitpodApi.Host,
+		Kind: "gitpod",
+		Scope: []string{
+			"function:openPort",
+			"function:closePort",
+		},
+	})
+	if err != nil {
+		return nil, "", xerrors.Errorf("failed getting token from supervisor: %w", err)
+	}
+	client, err = serverapi.ConnectToServer(wsinfo.GitpodApi.Endpoint, serverapi.ConnectToServerOpts{
+		Token:   clientToken.Token,
+		Context: ctx,
+		Log:     log.NewEntry(log.StandardLogger()),
+	})
+	if err != nil {
+		return nil, "", xerrors.Errorf("failed connecting to server: %w", err)
+	}
+	return client, wsinfo.WorkspaceId, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"testing"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+func TestParsePort(t *testing.T) {
+	tests := []struct {
+		Spec             string
+		ExpectedPort     uint32
+		ExpectedProtocol supervisor.PortProtocol
+		ExpectError      bool
+	}{
+		{Spec: "3000", ExpectedPort: 3000, ExpectedProtocol: supervisor.PortProtocol_tcp},
+		{Spec: "3000/tcp", ExpectedPort: 3000, ExpectedProtocol: supervisor.PortProtocol_tcp},
+		{Spec: "5353/udp", ExpectedPort: 5353, ExpectedProtocol: supervisor.PortProtocol_udp},
+		{Spec: "5353/sctp", ExpectError: true},
+		{Spec: "0", ExpectError: true},
+		{Spec: "70000", ExpectError: true},
+		{Spec: "http", ExpectError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Spec, func(t *testing.T) {
+			port, protocol, err := parsePort(test.Spec)
+			if (err != nil) != test.ExpectError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			if port != test.ExpectedPort || protocol != test.ExpectedProtocol {
+				t.Errorf("unexpected port: got %d/%s, expected %d/%s", port, protocol, test.ExpectedPort, test.ExpectedProtocol)
+			}
+		})
+	}
+}
+
+func TestFindPort(t *testing.T) {
+	ports := []*supervisor.PortsStatus{
+		{LocalPort: 3000, Protocol: supervisor.PortProtocol_tcp},
+		{LocalPort: 5353, Protocol: supervisor.PortProtocol_udp},
+	}
+	if p := findPort(ports, 3000, supervisor.PortProtocol_tcp); p == nil || portName(p) != "3000" {
+		t.Errorf("unexpected port: %v", p)
+	}
+	if p := findPort(ports, 5353, supervisor.PortProtocol_udp); p == nil || portName(p) != "5353/udp" {
+		t.Errorf("unexpected port: %v", p)
+	}
+	if p := findPort(ports, 5353, supervisor.PortProtocol_tcp); p != nil {
+		t.Errorf("unexpected port: %v", p)
+	}
+}
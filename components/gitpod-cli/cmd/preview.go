@@ -32,24 +32,29 @@ var previewCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		url := replaceLocalhostInURL(args[0])
-		if previewCmdOpts.External {
-			if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
-				url = "https://" + url
-			}
-			openPreview("GP_EXTERNAL_BROWSER", url)
-			return
+		previewURL(url, previewCmdOpts.External)
+	},
+}
+
+// previewURL opens a URL in the IDE's preview, or in a new browser tab if external is true
+func previewURL(url string, external bool) {
+	if external {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			url = "https://" + url
 		}
-		if isTheiaIDE() {
-			if service, err := theialib.NewServiceFromEnv(); err == nil {
-				_, err = service.OpenPreview(theialib.OpenPreviewRequest{URL: url})
-				if err == nil {
-					// we've opened the preview. All is well.
-					return
-				}
+		openPreview("GP_EXTERNAL_BROWSER", url)
+		return
+	}
+	if isTheiaIDE() {
+		if service, err := theialib.NewServiceFromEnv(); err == nil {
+			_, err = service.OpenPreview(theialib.OpenPreviewRequest{URL: url})
+			if err == nil {
+				// we've opened the preview. All is well.
+				return
 			}
 		}
-		openPreview("GP_PREVIEW_BROWSER", url)
-	},
+	}
+	openPreview("GP_PREVIEW_BROWSER", url)
 }
 
 func openPreview(gpBrowserEnvVar string, url string) {
@@ -0,0 +1,174 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+// detachKey detaches gp tasks attach from a task (Ctrl+])
+const detachKey = 0x1d
+
+var tasksAttachOpts struct {
+	ReadOnly    bool
+	ForceResize bool
+}
+
+var tasksAttachCmd = &cobra.Command{
+	Use:   "attach <name>",
+	Short: "Attaches to the terminal of a task",
+	Long: `
+Attaches to the terminal of a running task. Press Ctrl+] to detach.
+Without --read-only, the input is forwarded to the task.
+	`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		conn, err := dialSupervisor()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer conn.Close()
+
+		task, err := getRunningTask(ctx, conn, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var (
+			client  = supervisor.NewTerminalServiceClient(conn)
+			alias   = task.Terminal
+			stdin   = int(os.Stdin.Fd())
+			errchan = make(chan error, 2)
+			detach  = make(chan struct{})
+		)
+		listen, err := client.Listen(ctx, &supervisor.ListenTerminalRequest{Alias: alias})
+		if err != nil {
+			log.Fatalf("cannot attach to task %s: %v", args[0], err)
+		}
+
+		var exitCode int
+		go func() {
+			for {
+				resp, err := listen.Recv()
+				if err != nil {
+					errchan <- err
+					return
+				}
+				_, _ = os.Stdout.Write(resp.GetData())
+				if c := resp.GetExitCode(); c > 0 {
+					exitCode = int(c)
+				}
+			}
+		}()
+
+		var (
+			interactive = !tasksAttachOpts.ReadOnly && term.IsTerminal(stdin)
+			restore     = func() {}
+		)
+		if interactive {
+			oldState, err := term.MakeRaw(stdin)
+			if err != nil {
+				log.Fatalf("cannot put terminal into raw mode: %v", err)
+			}
+			restore = func() { _ = term.Restore(stdin, oldState) }
+			defer restore()
+			fmt.Fprint(os.Stderr, "attached to task "+args[0]+", press Ctrl+] to detach\r\n")
+
+			resize := make(chan os.Signal, 1)
+			signal.Notify(resize, syscall.SIGWINCH)
+			go func() {
+				for range resize {
+					cols, rows, err := term.GetSize(stdin)
+					if err != nil {
+						continue
+					}
+					req := &supervisor.SetTerminalSizeRequest{
+						Alias: alias,
+						Size: &supervisor.TerminalSize{
+							Cols: uint32(cols),
+							Rows: uint32(rows),
+						},
+					}
+					if tasksAttachOpts.ForceResize {
+						req.Priority = &supervisor.SetTerminalSizeRequest_Force{Force: true}
+					}
+					// without force, resizing fails if another client controls the size - that's fine
+					_, _ = client.SetSize(ctx, req)
+				}
+			}()
+			resize <- syscall.SIGWINCH
+
+			go func() {
+				buf := make([]byte, 32*1024)
+				for {
+					n, err := os.Stdin.Read(buf)
+					if n > 0 {
+						data := buf[:n]
+						i := bytes.IndexByte(data, detachKey)
+						if i >= 0 {
+							data = data[:i]
+						}
+						if len(data) > 0 {
+							_, werr := client.Write(ctx, &supervisor.WriteTerminalRequest{Alias: alias, Stdin: data})
+							if werr != nil {
+								errchan <- werr
+								return
+							}
+						}
+						if i >= 0 {
+							close(detach)
+							return
+						}
+					}
+					if err != nil {
+						// stdin closed - we stay attached read-only
+						return
+					}
+				}
+			}()
+		}
+
+		stopch := make(chan os.Signal, 1)
+		signal.Notify(stopch, syscall.SIGTERM, syscall.SIGINT)
+		select {
+		case err := <-errchan:
+			if err != io.EOF {
+				log.Printf("lost connection to task %s: %v", args[0], err)
+				return
+			}
+			if interactive {
+				fmt.Fprint(os.Stderr, "\r\n")
+			}
+			if exitCode != 0 {
+				restore()
+				os.Exit(exitCode)
+			}
+		case <-detach:
+			fmt.Fprint(os.Stderr, "\r\ndetached from task "+args[0]+"\r\n")
+		case <-stopch:
+		}
+	},
+}
+
+func init() {
+	tasksCmd.AddCommand(tasksAttachCmd)
+	tasksAttachCmd.Flags().BoolVarP(&tasksAttachOpts.ReadOnly, "read-only", "r", false, "do not forward input to the task")
+	tasksAttachCmd.Flags().BoolVar(&tasksAttachOpts.ForceResize, "force-resize", false, "force the task's terminal size regardless of other clients")
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var tasksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the tasks of this workspace and their state",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		conn, err := dialSupervisor()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer conn.Close()
+
+		tasks, err := getTasks(ctx, conn)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tSTATE\tRESTARTS\tSTARTED")
+		for _, t := range tasks {
+			started := "-"
+			if t.StartedAt != nil {
+				started = t.StartedAt.AsTime().Local().Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", t.Id, taskName(t), taskStateString(t), t.RestartCount, started)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	tasksCmd.AddCommand(tasksListCmd)
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+// tasksLogsIdleTimeout is the time without new output after which gp tasks logs considers the
+// recorded output of a task to be printed completely, unless it follows the output.
+const tasksLogsIdleTimeout = 500 * time.Millisecond
+
+var tasksLogsOpts struct {
+	Follow bool
+}
+
+var tasksLogsCmd = &cobra.Command{
+	Use:   "logs <name>",
+	Short: "Prints the output of a task",
+	Long: `
+Prints the recent output of a running task, or the recorded output of a closed task.
+With --follow, the output of a running task is streamed until the task closes or the command is interrupted.
+	`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		conn, err := dialSupervisor()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer conn.Close()
+
+		task, err := getTask(ctx, conn, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		if task.Terminal == "" {
+			log.Fatalf("task %s has not started yet (%s)", args[0], taskStateString(task))
+		}
+		if task.State == supervisor.TaskState_closed {
+			err = printTaskRecording(ctx, conn, task)
+			if err != nil {
+				log.Fatalf("cannot print recorded output of task %s: %v", args[0], err)
+			}
+			return
+		}
+
+		listen, err := supervisor.NewTerminalServiceClient(conn).Listen(ctx, &supervisor.ListenTerminalRequest{Alias: task.Terminal})
+		if err != nil {
+			log.Fatalf("cannot listen to task %s: %v", args[0], err)
+		}
+
+		output := make(chan []byte)
+		errchan := make(chan error, 1)
+		go func() {
+			for {
+				resp, err := listen.Recv()
+				if err != nil {
+					errchan <- err
+					return
+				}
+				if data := resp.GetData(); len(data) > 0 {
+					output <- data
+				}
+			}
+		}()
+
+		stopch := make(chan os.Signal, 1)
+		signal.Notify(stopch, syscall.SIGTERM, syscall.SIGINT)
+
+		// idle stays nil when following, i.e. never fires
+		var idle *time.Timer
+		if !tasksLogsOpts.Follow {
+			idle = time.NewTimer(tasksLogsIdleTimeout)
+			defer idle.Stop()
+		}
+		idleC := func() <-chan time.Time {
+			if idle == nil {
+				return nil
+			}
+			return idle.C
+		}
+
+		for {
+			select {
+			case data := <-output:
+				_, _ = os.Stdout.Write(data)
+				if idle != nil {
+					if !idle.Stop() {
+						select {
+						case <-idle.C:
+						default:
+						}
+					}
+					idle.Reset(tasksLogsIdleTimeout)
+				}
+			case <-idleC():
+				return
+			case err := <-errchan:
+				if err != io.EOF {
+					log.Fatalf("cannot read output of task %s: %v", args[0], err)
+				}
+				return
+			case <-stopch:
+				return
+			}
+		}
+	},
+}
+
+// printTaskRecording prints the recorded output of a task whose terminal is closed
+func printTaskRecording(ctx context.Context, conn *grpc.ClientConn, task *supervisor.TaskStatus) error {
+	replay, err := supervisor.NewTerminalServiceClient(conn).Replay(ctx, &supervisor.ReplayTerminalRequest{Alias: task.Terminal})
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := replay.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		_, _ = os.Stdout.Write(resp.Data)
+	}
+}
+
+func init() {
+	tasksCmd.AddCommand(tasksLogsCmd)
+	tasksLogsCmd.Flags().BoolVarP(&tasksLogsOpts.Follow, "follow", "f", false, "stream the output of the task")
+}
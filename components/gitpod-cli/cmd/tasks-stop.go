@@ -0,0 +1,63 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+var tasksStopOpts struct {
+	GracePeriod time.Duration
+}
+
+var tasksStopCmd = &cobra.Command{
+	Use:   "stop <name>",
+	Short: "Stops a task",
+	Long: `
+Stops a running task, or cancels its pending restart.
+The task's processes receive SIGTERM first, and SIGKILL once the grace period is over.
+	`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), tasksStopOpts.GracePeriod+10*time.Second)
+		defer cancel()
+
+		conn, err := dialSupervisor()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer conn.Close()
+
+		tasks, err := getTasks(ctx, conn)
+		if err != nil {
+			log.Fatal(err)
+		}
+		task, err := findTask(tasks, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		_, err = supervisor.NewTaskServiceClient(conn).Stop(ctx, &supervisor.StopTaskRequest{
+			Id:                 task.Id,
+			GracePeriodSeconds: int64(tasksStopOpts.GracePeriod.Seconds()),
+		})
+		if err != nil {
+			log.Fatalf("cannot stop task %s: %v", args[0], err)
+		}
+		fmt.Printf("task %s stopped\n", args[0])
+	},
+}
+
+func init() {
+	tasksCmd.AddCommand(tasksStopCmd)
+	tasksStopCmd.Flags().DurationVar(&tasksStopOpts.GracePeriod, "grace-period", 10*time.Second, "time the task's processes are given to stop before they are killed")
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+// tasksCmd represents the tasks command
+var tasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "Interact with the tasks configured in .gitpod.yml",
+	Long: `
+Interact with the tasks configured in .gitpod.yml.
+Tasks are addressed by their name, or by their ID if they have no name (see gp tasks list).
+	`,
+}
+
+func init() {
+	rootCmd.AddCommand(tasksCmd)
+}
+
+// dialSupervisor connects to the supervisor of this workspace
+func dialSupervisor() (*grpc.ClientConn, error) {
+	supervisorAddr := os.Getenv("SUPERVISOR_ADDR")
+	if supervisorAddr == "" {
+		supervisorAddr = "localhost:22999"
+	}
+	conn, err := grpc.Dial(supervisorAddr, grpc.WithInsecure())
+	if err != nil {
+		return nil, xerrors.Errorf("failed connecting to supervisor: %w", err)
+	}
+	return conn, nil
+}
+
+// getTasks returns the current status of all tasks
+func getTasks(ctx context.Context, conn *grpc.ClientConn) ([]*supervisor.TaskStatus, error) {
+	stream, err := supervisor.NewStatusServiceClient(conn).TasksStatus(ctx, &supervisor.TasksStatusRequest{})
+	if err != nil {
+		return nil, xerrors.Errorf("failed getting tasks from supervisor: %w", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, xerrors.Errorf("failed getting tasks from supervisor: %w", err)
+	}
+	return resp.Tasks, nil
+}
+
+// findTask returns the task with the given name. Tasks without a name can be found by their ID.
+func findTask(tasks []*supervisor.TaskStatus, name string) (*supervisor.TaskStatus, error) {
+	var (
+		byID    *supervisor.TaskStatus
+		byName  []*supervisor.TaskStatus
+		choices []string
+	)
+	for _, t := range tasks {
+		if t.Presentation != nil && t.Presentation.Name == name {
+			byName = append(byName, t)
+		}
+		if t.Id == name {
+			byID = t
+		}
+	}
+	switch {
+	case len(byName) == 1:
+		return byName[0], nil
+	case len(byName) > 1:
+		for _, t := range byName {
+			choices = append(choices, t.Id)
+		}
+		return nil, xerrors.Errorf("more than one task is named %s, please use one of their IDs instead: %s", name, strings.Join(choices, ", "))
+	case byID != nil:
+		return byID, nil
+	}
+
+	for _, t := range tasks {
+		choices = append(choices, taskName(t))
+	}
+	return nil, xerrors.Errorf("task %s not found, available tasks: %s", name, strings.Join(choices, ", "))
+}
+
+// taskName returns the name of a task as understood by findTask
+func taskName(t *supervisor.TaskStatus) string {
+	// supervisor presents tasks without a name using the repository root, which is the default workdir of tasks
+	if t.Presentation == nil || t.Presentation.Name == "" || t.Presentation.Name == os.Getenv("GITPOD_REPO_ROOT") {
+		return t.Id
+	}
+	return t.Presentation.Name
+}
+
+// getTask returns the task with the given name, regardless of its state
+func getTask(ctx context.Context, conn *grpc.ClientConn, name string) (*supervisor.TaskStatus, error) {
+	tasks, err := getTasks(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	return findTask(tasks, name)
+}
+
+// getRunningTask returns the task with the given name and makes sure it has a terminal
+func getRunningTask(ctx context.Context, conn *grpc.ClientConn, name string) (*supervisor.TaskStatus, error) {
+	task, err := getTask(ctx, conn, name)
+	if err != nil {
+		return nil, err
+	}
+	if task.State != supervisor.TaskState_running || task.Terminal == "" {
+		return nil, xerrors.Errorf("task %s is not running (%s)", name, task.State)
+	}
+	return task, nil
+}
+
+func taskStateString(t *supervisor.TaskStatus) string {
+	if t.State != supervisor.TaskState_closed {
+		return t.State.String()
+	}
+	return fmt.Sprintf("%s (%d)", t.State, t.ExitCode)
+}
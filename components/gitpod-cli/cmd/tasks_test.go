@@ -0,0 +1,71 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"testing"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+func TestFindTask(t *testing.T) {
+	task := func(id, name string) *supervisor.TaskStatus {
+		return &supervisor.TaskStatus{Id: id, Presentation: &supervisor.TaskPresentation{Name: name}}
+	}
+	tasks := []*supervisor.TaskStatus{
+		task("0", "frontend"),
+		task("1", "backend"),
+		task("2", "db"),
+		task("3", "db"),
+		task("4", ""),
+	}
+
+	tests := []struct {
+		Desc        string
+		Name        string
+		ExpectedID  string
+		ExpectError bool
+	}{
+		{Desc: "by name", Name: "backend", ExpectedID: "1"},
+		{Desc: "by ID", Name: "4", ExpectedID: "4"},
+		{Desc: "ambiguous name", Name: "db", ExpectError: true},
+		{Desc: "unknown name", Name: "worker", ExpectError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act, err := findTask(tasks, test.Name)
+			if (err != nil) != test.ExpectError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			if act.Id != test.ExpectedID {
+				t.Errorf("unexpected task: got %s, expected %s", act.Id, test.ExpectedID)
+			}
+		})
+	}
+}
+
+func TestTaskName(t *testing.T) {
+	t.Setenv("GITPOD_REPO_ROOT", "/workspace/gitpod")
+
+	tests := []struct {
+		Desc     string
+		Task     *supervisor.TaskStatus
+		Expected string
+	}{
+		{Desc: "named", Task: &supervisor.TaskStatus{Id: "0", Presentation: &supervisor.TaskPresentation{Name: "frontend"}}, Expected: "frontend"},
+		{Desc: "no presentation", Task: &supervisor.TaskStatus{Id: "1"}, Expected: "1"},
+		{Desc: "presented by repo root", Task: &supervisor.TaskStatus{Id: "2", Presentation: &supervisor.TaskPresentation{Name: "/workspace/gitpod"}}, Expected: "2"},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			if act := taskName(test.Task); act != test.Expected {
+				t.Errorf("unexpected name: got %s, expected %s", act, test.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+var tunnelOpts struct {
+	Reverse bool
+	Network bool
+}
+
+var tunnelCmd = &cobra.Command{
+	Use:   "tunnel <port> [target-port]",
+	Short: "Tunnels a workspace port to the local machine, or a local port into the workspace",
+	Long: `
+Tunnels a workspace port to the local machine running the Gitpod local companion app.
+The target port defaults to the workspace port.
+
+With --reverse, connections to the workspace port are forwarded to the target port on
+the local machine instead, e.g. to reach a database on your laptop or a service which
+is only available on your corporate network. The local companion app must offer the
+target port (--offer-port). The reverse tunnel is open until the command is stopped.
+	`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		port, err := strconv.ParseUint(args[0], 10, 16)
+		if err != nil {
+			log.Fatalf("port cannot be parsed as int: %s", err)
+		}
+		targetPort := port
+		if len(args) > 1 {
+			targetPort, err = strconv.ParseUint(args[1], 10, 16)
+			if err != nil {
+				log.Fatalf("target-port cannot be parsed as int: %s", err)
+			}
+		}
+
+		conn, err := dialSupervisor()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer conn.Close()
+		client := supervisor.NewPortServiceClient(conn)
+
+		if !tunnelOpts.Reverse {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			visibility := supervisor.TunnelVisiblity_host
+			if tunnelOpts.Network {
+				visibility = supervisor.TunnelVisiblity_network
+			}
+			_, err = client.Tunnel(ctx, &supervisor.TunnelPortRequest{
+				Port:       uint32(port),
+				TargetPort: uint32(targetPort),
+				Visibility: visibility,
+			})
+			if err != nil {
+				log.Fatalf("cannot tunnel port %d: %v", port, err)
+			}
+			fmt.Printf("Tunneling port %d to port %d of connected clients\n", port, targetPort)
+			return
+		}
+
+		stream, err := client.ReverseTunnel(context.Background(), &supervisor.ReverseTunnelRequest{
+			Port:       uint32(port),
+			TargetPort: uint32(targetPort),
+		})
+		if err != nil {
+			log.Fatalf("cannot reverse tunnel port %d: %v", port, err)
+		}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				log.Fatalf("cannot reverse tunnel port %d: %v", port, err)
+			}
+			if resp.ClientId == "" {
+				fmt.Printf("Waiting for a client offering port %d...\n", targetPort)
+				continue
+			}
+			fmt.Printf("Reverse tunneling: localhost:%d -> port %d of client %s\n", port, targetPort, resp.ClientId)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tunnelCmd)
+	tunnelCmd.Flags().BoolVarP(&tunnelOpts.Reverse, "reverse", "r", false, "forward connections to the workspace port to a port offered by the local machine")
+	tunnelCmd.Flags().BoolVar(&tunnelOpts.Network, "network", false, "make the tunneled port available to the local network instead of the local machine only")
+}
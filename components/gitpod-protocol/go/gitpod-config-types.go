@@ -18,6 +18,25 @@ import (
 type Env struct {
 }
 
+// GitClone Configures how the repository is cloned when a workspace is created.
+type GitClone struct {
+
+	// Truncates the history of the clone to this many commits. By default the full history is cloned.
+	Depth int `yaml:"depth,omitempty"`
+
+	// Makes the clone a partial clone which fetches the filtered objects on demand.
+	Filter string `yaml:"filter,omitempty"`
+
+	// Which Git LFS objects are fetched once the repository is checked out. 'skip' (default) leaves all Git LFS pointers unresolved, 'all' fetches all objects and 'include' the objects matching `lfsInclude`.
+	Lfs string `yaml:"lfs,omitempty"`
+
+	// Patterns selecting the Git LFS objects fetched in 'include' mode. See `git lfs fetch --include`.
+	LfsInclude []string `yaml:"lfsInclude,omitempty"`
+
+	// Checks out only these directories, relative to the repository root. By default the full working copy is checked out.
+	SparseCheckout []string `yaml:"sparseCheckout,omitempty"`
+}
+
 // Github Configures Gitpod's GitHub app
 type Github struct {
 
@@ -31,6 +50,9 @@ type GitpodConfig struct {
 	// Path to where the repository should be checked out.
 	CheckoutLocation string `yaml:"checkoutLocation,omitempty"`
 
+	// Configures how the repository is cloned when a workspace is created.
+	GitClone *GitClone `yaml:"gitClone,omitempty"`
+
 	// Git config values should be provided in pairs. E.g. `core.autocrlf: input`. See https://git-scm.com/docs/git-config#_values.
 	GitConfig map[string]string `yaml:"gitConfig,omitempty"`
 
@@ -116,6 +138,9 @@ type TasksItems struct {
 	// The main shell command to run after `before` and `init`. This command is executed last on every start and doesn't have to terminate.
 	Command string `yaml:"command,omitempty" json:"command,omitempty"`
 
+	// Names of the tasks which need to be running before this task starts. During prebuilds these tasks need to have completed successfully instead.
+	DependsOn []string `yaml:"dependsOn,omitempty" json:"dependsOn,omitempty"`
+
 	// Environment variables to set.
 	Env *Env `yaml:"env,omitempty" json:"env,omitempty"`
 
@@ -133,6 +158,12 @@ type TasksItems struct {
 
 	// A shell command to run after `before`. This command is executed only on during workspace prebuilds. This command is expected to terminate. If it fails, the workspace build fails.
 	Prebuild string `yaml:"prebuild,omitempty" json:"prebuild,omitempty"`
+
+	// Whether to start the task again once its `command` ended. 'on-failure' restarts the task if the command failed, 'always' whenever the command ended. Restarts back off exponentially and run `before` and `command` only. Ignored during prebuilds. Default is 'never'.
+	Restart string `yaml:"restart,omitempty" json:"restart,omitempty"`
+
+	// Conditions which need to be met before this task starts. All of them need to be met. Ignored during prebuilds.
+	WaitFor *WaitFor `yaml:"waitFor,omitempty" json:"waitFor,omitempty"`
 }
 
 // Vscode Configure VS Code integration
@@ -142,6 +173,119 @@ type Vscode struct {
 	Extensions []string `yaml:"extensions,omitempty"`
 }
 
+// WaitFor Conditions which need to be met before this task starts. All of them need to be met. Ignored during prebuilds.
+type WaitFor struct {
+
+	// Wait until this shell command exits successfully. The command is run every second.
+	Command string `yaml:"command,omitempty" json:"command,omitempty"`
+
+	// Wait until this file exists. Relative paths are resolved against the workspace location.
+	File string `yaml:"file,omitempty" json:"file,omitempty"`
+
+	// Wait until a service listens on this port.
+	Port int `yaml:"port,omitempty" json:"port,omitempty"`
+}
+
+func (strct *GitClone) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0))
+	buf.WriteString("{")
+	comma := false
+	// Marshal the "depth" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"depth\": ")
+	if tmp, err := json.Marshal(strct.Depth); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "filter" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"filter\": ")
+	if tmp, err := json.Marshal(strct.Filter); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "lfs" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"lfs\": ")
+	if tmp, err := json.Marshal(strct.Lfs); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "lfsInclude" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"lfsInclude\": ")
+	if tmp, err := json.Marshal(strct.LfsInclude); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "sparseCheckout" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"sparseCheckout\": ")
+	if tmp, err := json.Marshal(strct.SparseCheckout); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+
+	buf.WriteString("}")
+	rv := buf.Bytes()
+	return rv, nil
+}
+
+func (strct *GitClone) UnmarshalJSON(b []byte) error {
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(b, &jsonMap); err != nil {
+		return err
+	}
+	// parse all the defined properties
+	for k, v := range jsonMap {
+		switch k {
+		case "depth":
+			if err := json.Unmarshal([]byte(v), &strct.Depth); err != nil {
+				return err
+			}
+		case "filter":
+			if err := json.Unmarshal([]byte(v), &strct.Filter); err != nil {
+				return err
+			}
+		case "lfs":
+			if err := json.Unmarshal([]byte(v), &strct.Lfs); err != nil {
+				return err
+			}
+		case "lfsInclude":
+			if err := json.Unmarshal([]byte(v), &strct.LfsInclude); err != nil {
+				return err
+			}
+		case "sparseCheckout":
+			if err := json.Unmarshal([]byte(v), &strct.SparseCheckout); err != nil {
+				return err
+			}
+		default:
+			return xerrors.Errorf("additional property not allowed: \"" + k + "\"")
+		}
+	}
+	return nil
+}
+
 func (strct *Github) MarshalJSON() ([]byte, error) {
 	buf := bytes.NewBuffer(make([]byte, 0))
 	buf.WriteString("{")
@@ -197,6 +341,17 @@ func (strct *GitpodConfig) MarshalJSON() ([]byte, error) {
 		buf.Write(tmp)
 	}
 	comma = true
+	// Marshal the "gitClone" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"gitClone\": ")
+	if tmp, err := json.Marshal(strct.GitClone); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
 	// Marshal the "gitConfig" field
 	if comma {
 		buf.WriteString(",")
@@ -303,6 +458,10 @@ func (strct *GitpodConfig) UnmarshalJSON(b []byte) error {
 			if err := json.Unmarshal([]byte(v), &strct.CheckoutLocation); err != nil {
 				return err
 			}
+		case "gitClone":
+			if err := json.Unmarshal([]byte(v), &strct.GitClone); err != nil {
+				return err
+			}
 		case "gitConfig":
 			if err := json.Unmarshal([]byte(v), &strct.GitConfig); err != nil {
 				return err
@@ -539,6 +698,17 @@ func (strct *TasksItems) MarshalJSON() ([]byte, error) {
 		buf.Write(tmp)
 	}
 	comma = true
+	// Marshal the "dependsOn" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"dependsOn\": ")
+	if tmp, err := json.Marshal(strct.DependsOn); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
 	// Marshal the "env" field
 	if comma {
 		buf.WriteString(",")
@@ -605,6 +775,28 @@ func (strct *TasksItems) MarshalJSON() ([]byte, error) {
 		buf.Write(tmp)
 	}
 	comma = true
+	// Marshal the "restart" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"restart\": ")
+	if tmp, err := json.Marshal(strct.Restart); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "waitFor" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"waitFor\": ")
+	if tmp, err := json.Marshal(strct.WaitFor); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
 
 	buf.WriteString("}")
 	rv := buf.Bytes()
@@ -627,6 +819,10 @@ func (strct *TasksItems) UnmarshalJSON(b []byte) error {
 			if err := json.Unmarshal([]byte(v), &strct.Command); err != nil {
 				return err
 			}
+		case "dependsOn":
+			if err := json.Unmarshal([]byte(v), &strct.DependsOn); err != nil {
+				return err
+			}
 		case "env":
 			if err := json.Unmarshal([]byte(v), &strct.Env); err != nil {
 				return err
@@ -651,6 +847,14 @@ func (strct *TasksItems) UnmarshalJSON(b []byte) error {
 			if err := json.Unmarshal([]byte(v), &strct.Prebuild); err != nil {
 				return err
 			}
+		case "restart":
+			if err := json.Unmarshal([]byte(v), &strct.Restart); err != nil {
+				return err
+			}
+		case "waitFor":
+			if err := json.Unmarshal([]byte(v), &strct.WaitFor); err != nil {
+				return err
+			}
 		default:
 			return xerrors.Errorf("additional property not allowed: \"" + k + "\"")
 		}
@@ -697,3 +901,73 @@ func (strct *Vscode) UnmarshalJSON(b []byte) error {
 	}
 	return nil
 }
+
+func (strct *WaitFor) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0))
+	buf.WriteString("{")
+	comma := false
+	// Marshal the "command" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"command\": ")
+	if tmp, err := json.Marshal(strct.Command); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "file" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"file\": ")
+	if tmp, err := json.Marshal(strct.File); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "port" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"port\": ")
+	if tmp, err := json.Marshal(strct.Port); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+
+	buf.WriteString("}")
+	rv := buf.Bytes()
+	return rv, nil
+}
+
+func (strct *WaitFor) UnmarshalJSON(b []byte) error {
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(b, &jsonMap); err != nil {
+		return err
+	}
+	// parse all the defined properties
+	for k, v := range jsonMap {
+		switch k {
+		case "command":
+			if err := json.Unmarshal([]byte(v), &strct.Command); err != nil {
+				return err
+			}
+		case "file":
+			if err := json.Unmarshal([]byte(v), &strct.File); err != nil {
+				return err
+			}
+		case "port":
+			if err := json.Unmarshal([]byte(v), &strct.Port); err != nil {
+				return err
+			}
+		default:
+			return xerrors.Errorf("additional property not allowed: \"" + k + "\"")
+		}
+	}
+	return nil
+}
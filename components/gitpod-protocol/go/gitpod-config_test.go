@@ -38,6 +38,14 @@ tasks:
   - name: Go
     init: leeway exec --filter-type go -v -- go get -v ./...
     openMode: split-right
+  - name: Server
+    command: go run ./server
+    dependsOn:
+      - Go
+    waitFor:
+      port: 5432
+      file: .env
+    restart: on-failure
 vscode:
   extensions:
     - hangxingliu.vscode-nginx-conf-hint@0.1.0:UATTe2sTFfCYWQ3jw4IRsw==
@@ -65,6 +73,16 @@ vscode:
 						Init:     "leeway exec --filter-type go -v -- go get -v ./...",
 						OpenMode: "split-right",
 					},
+					{
+						Name:      "Server",
+						Command:   "go run ./server",
+						DependsOn: []string{"Go"},
+						WaitFor: &WaitFor{
+							Port: 5432,
+							File: ".env",
+						},
+						Restart: "on-failure",
+					},
 				},
 				Vscode: &Vscode{
 					Extensions: []string{
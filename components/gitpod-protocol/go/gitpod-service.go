@@ -65,6 +65,7 @@ type APIInterface interface {
 	GetEnvVars(ctx context.Context) (res []*UserEnvVarValue, err error)
 	SetEnvVar(ctx context.Context, variable *UserEnvVarValue) (err error)
 	DeleteEnvVar(ctx context.Context, variable *UserEnvVarValue) (err error)
+	GetSSHPublicKeys(ctx context.Context) (res []*UserSSHPublicKey, err error)
 	GetContentBlobUploadURL(ctx context.Context, name string) (url string, err error)
 	GetContentBlobDownloadURL(ctx context.Context, name string) (url string, err error)
 	GetGitpodTokens(ctx context.Context) (res []*APIToken, err error)
@@ -171,6 +172,8 @@ const (
 	FunctionSetEnvVar FunctionName = "setEnvVar"
 	// FunctionDeleteEnvVar is the name of the deleteEnvVar function
 	FunctionDeleteEnvVar FunctionName = "deleteEnvVar"
+	// FunctionGetSSHPublicKeys is the name of the getSSHPublicKeys function
+	FunctionGetSSHPublicKeys FunctionName = "getSSHPublicKeys"
 	// FunctionGetContentBlobUploadURL is the name fo the getContentBlobUploadUrl function
 	FunctionGetContentBlobUploadURL FunctionName = "getContentBlobUploadUrl"
 	// FunctionGetContentBlobDownloadURL is the name fo the getContentBlobDownloadUrl function
@@ -231,7 +234,7 @@ func ConnectToServer(endpoint string, opts ConnectToServerOpts) (*APIoverJSONRPC
 	}
 
 	var protocol string
-	if epURL.Scheme == "wss:" {
+	if epURL.Scheme == "wss" {
 		protocol = "https"
 	} else {
 		protocol = "http"
@@ -1123,6 +1126,24 @@ func (gp *APIoverJSONRPC) DeleteEnvVar(ctx context.Context, variable *UserEnvVar
 	return
 }
 
+// GetSSHPublicKeys calls getSSHPublicKeys on the server
+func (gp *APIoverJSONRPC) GetSSHPublicKeys(ctx context.Context) (res []*UserSSHPublicKey, err error) {
+	if gp == nil {
+		err = errNotConnected
+		return
+	}
+	var _params []interface{}
+
+	var result []*UserSSHPublicKey
+	err = gp.C.Call(ctx, "getSSHPublicKeys", _params, &result)
+	if err != nil {
+		return
+	}
+	res = result
+
+	return
+}
+
 // GetContentBlobUploadURL calls getContentBlobUploadUrl on the server
 func (gp *APIoverJSONRPC) GetContentBlobUploadURL(ctx context.Context, name string) (url string, err error) {
 	if gp == nil {
@@ -1913,6 +1934,13 @@ type UserEnvVarValue struct {
 	Value             string `json:"value,omitempty"`
 }
 
+// UserSSHPublicKey is the UserSSHPublicKey message type
+type UserSSHPublicKey struct {
+	Name         string `json:"name,omitempty"`
+	Key          string `json:"key,omitempty"`
+	CreationTime string `json:"creationTime,omitempty"`
+}
+
 // GenerateNewGitpodTokenOptions is the GenerateNewGitpodTokenOptions message type
 type GenerateNewGitpodTokenOptions struct {
 	Name string `json:"name,omitempty"`
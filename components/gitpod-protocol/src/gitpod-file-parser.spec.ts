@@ -138,6 +138,30 @@ gitConfig:
         });
     }
 
+    @test public testGitClone() {
+        const content =
+`
+gitClone:
+    depth: 1
+    sparseCheckout:
+      - assets
+    lfs: include
+    lfsInclude:
+      - "*.png"
+`;
+
+        const result = this.parser.parse(content, {}, DEFAULT_CONFIG);
+        expect(result.config).to.deep.equal({
+            gitClone: {
+                depth: 1,
+                sparseCheckout: ["assets"],
+                lfs: "include",
+                lfsInclude: ["*.png"]
+            },
+            image: DEFAULT_IMAGE
+        });
+    }
+
     @test public testBrokenConfig() {
         const content =
             `image: 42\n`;
@@ -9,7 +9,7 @@ import {
     WhitelistedRepository, WorkspaceImageBuild, AuthProviderInfo, Branding, CreateWorkspaceMode,
     Token, UserEnvVarValue, ResolvePluginsParams, PreparePluginUploadParams, Terms,
     ResolvedPlugins, Configuration, InstallPluginsParams, UninstallPluginParams, UserInfo, GitpodTokenType,
-    GitpodToken, AuthProviderEntry, GuessGitTokenScopesParams, GuessedGitTokenScopes
+    GitpodToken, AuthProviderEntry, GuessGitTokenScopesParams, GuessedGitTokenScopes, UserSSHPublicKey
 } from './protocol';
 import {
     Team, TeamMemberInfo,
@@ -114,6 +114,11 @@ export interface GitpodServer extends JsonRpcServer<GitpodClient>, AdminServer,
     setEnvVar(variable: UserEnvVarValue): Promise<void>;
     deleteEnvVar(variable: UserEnvVarValue): Promise<void>;
 
+    // User SSH keys
+    getSSHPublicKeys(): Promise<UserSSHPublicKey[]>;
+    addSSHPublicKey(name: string, key: string): Promise<UserSSHPublicKey>;
+    deleteSSHPublicKey(key: string): Promise<void>;
+
     // Teams
     getTeams(): Promise<Team[]>;
     getTeamMembers(teamId: string): Promise<TeamMemberInfo[]>;
@@ -7,6 +7,9 @@
 
 
 export namespace ErrorCodes {
+    // 400 Bad Request
+    export const BAD_REQUEST = 400;
+
     // 401 Unauthorized
     export const NOT_AUTHENTICATED = 401;
 
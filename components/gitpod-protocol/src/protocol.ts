@@ -104,6 +104,15 @@ export interface AdditionalUserData {
     oauthClientsApproved?: { [key: string]: string }
     // to remember GH Orgs the user installed/updated the GH App for
     knownGitHubOrgs?: string[];
+    // public keys which may log into the user's workspaces via SSH
+    sshPublicKeys?: UserSSHPublicKey[];
+}
+
+export interface UserSSHPublicKey {
+    name: string;
+    // in authorized_keys format, i.e. "<type> <base64 key> [comment]"
+    key: string;
+    creationTime: string;
 }
 
 export interface EmailNotificationSettings {
@@ -556,6 +565,7 @@ export interface WorkspaceConfig {
     checkoutLocation?: string;
     workspaceLocation?: string;
     gitConfig?: { [config: string]: string };
+    gitClone?: GitCloneConfig;
     github?: GithubAppConfig;
     vscode?: VSCodeConfig;
 
@@ -578,6 +588,14 @@ export interface WorkspaceConfig {
     _featureFlags?: NamedWorkspaceFeatureFlag[];
 }
 
+export interface GitCloneConfig {
+    depth?: number;
+    filter?: 'blob:none' | 'tree:0';
+    sparseCheckout?: string[];
+    lfs?: 'skip' | 'all' | 'include';
+    lfsInclude?: string[];
+}
+
 export interface GithubAppConfig {
     prebuilds?: GithubAppPrebuildConfig
 }
@@ -708,6 +726,13 @@ export interface TaskConfig {
     env?: { [env: string]: any };
     openIn?: 'bottom' | 'main' | 'left' | 'right';
     openMode?: 'split-top' | 'split-left' | 'split-right' | 'split-bottom' | 'tab-before' | 'tab-after';
+    dependsOn?: string[];
+    waitFor?: {
+        port?: number;
+        file?: string;
+        command?: string;
+    };
+    restart?: 'never' | 'on-failure' | 'always';
 }
 
 export namespace TaskConfig {
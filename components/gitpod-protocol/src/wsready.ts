@@ -4,7 +4,7 @@
  * See License-AGPL.txt in the project root for license information.
  */
 
-// generated using github.com/32leaves/bel on 2021-08-03 10:42:32.582603295 +0000 UTC m=+0.005734479
+// generated using github.com/32leaves/bel on 2026-10-18 10:30:13.790770671 +0000 UTC m=+0.011673589
 // DO NOT MODIFY
 
 export enum WorkspaceInitSource {
@@ -12,6 +12,18 @@ export enum WorkspaceInitSource {
     WorkspaceInitFromPrebuild = "from-prebuild",
     WorkspaceInitFromOther = "from-other",
 }
+export interface InitializerReport {
+    kind: string
+    location?: string
+    source?: WorkspaceInitSource
+    durationMillis: number
+    bestEffort?: boolean
+    error?: string
+    lfsBytes?: number
+}
+
 export interface WorkspaceReadyMessage {
     source: WorkspaceInitSource
+    initializer?: InitializerReport[]
+    lfsBytes?: number
 }
@@ -27,6 +27,7 @@ import (
 	"github.com/sirupsen/logrus"
 	cli "github.com/urfave/cli/v2"
 	keyring "github.com/zalando/go-keyring"
+	"golang.org/x/xerrors"
 	"google.golang.org/grpc"
 )
 
@@ -86,6 +87,13 @@ func main() {
 				},
 				Value: true,
 			},
+			&cli.IntSliceFlag{
+				Name:  "offer-port",
+				Usage: "Local port which workspaces can reverse tunnel to (see gp tunnel --reverse), can be repeated",
+				EnvVars: []string{
+					"GITPOD_LCA_OFFERED_PORTS",
+				},
+			},
 			&cli.StringFlag{
 				Name: "auth-redirect-url",
 				EnvVars: []string{
@@ -117,7 +125,7 @@ func main() {
 						keyring.MockInit()
 					}
 					return run(c.String("gitpod-host"), c.String("ssh_config"), c.Int("api-port"), c.Bool("allow-cors-from-port"),
-						c.Bool("auto-tunnel"), c.String("auth-redirect-url"), c.Bool("verbose"), c.Duration("auth-timeout"))
+						c.Bool("auto-tunnel"), c.IntSlice("offer-port"), c.String("auth-redirect-url"), c.Bool("verbose"), c.Duration("auth-timeout"))
 				},
 				Flags: []cli.Flag{
 					&cli.PathFlag{
@@ -141,7 +149,7 @@ func DefaultCommand(name string) cli.ActionFunc {
 	}
 }
 
-func run(origin, sshConfig string, apiPort int, allowCORSFromPort bool, autoTunnel bool, authRedirectUrl string, verbose bool, authTimeout time.Duration) error {
+func run(origin, sshConfig string, apiPort int, allowCORSFromPort bool, autoTunnel bool, offeredPorts []int, authRedirectUrl string, verbose bool, authTimeout time.Duration) error {
 	if verbose {
 		logrus.SetLevel(logrus.DebugLevel)
 	}
@@ -187,6 +195,12 @@ func run(origin, sshConfig string, apiPort int, allowCORSFromPort bool, autoTunn
 
 	b = bastion.New(client, cb)
 	b.EnableAutoTunnel = autoTunnel
+	for _, port := range offeredPorts {
+		if port <= 0 || port > 0xFFFF {
+			return xerrors.Errorf("invalid offered port: %d", port)
+		}
+		b.OfferedPorts = append(b.OfferedPorts, uint32(port))
+	}
 	grpcServer := grpc.NewServer()
 	appapi.RegisterLocalAppServer(grpcServer, bastion.NewLocalAppService(b, s))
 	allowOrigin := func(origin string) bool {
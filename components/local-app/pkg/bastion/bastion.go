@@ -64,10 +64,17 @@ type TunnelListener struct {
 	LocalAddr  string
 	LocalPort  uint32
 	Visibility supervisor.TunnelVisiblity
+	Protocol   supervisor.PortProtocol
 	Ctx        context.Context
 	Cancel     func()
 }
 
+// tunnelKey identifies a tunneled port: a TCP and a UDP port with the same number are tunneled independently
+type tunnelKey struct {
+	Port     uint32
+	Protocol supervisor.PortProtocol
+}
+
 type Workspace struct {
 	InstanceID  string
 	WorkspaceID string
@@ -79,7 +86,7 @@ type Workspace struct {
 	supervisorClient   *grpc.ClientConn
 
 	tunnelMu        sync.RWMutex
-	tunnelListeners map[uint32]*TunnelListener
+	tunnelListeners map[tunnelKey]*TunnelListener
 	tunnelEnabled   bool
 	cancelTunnel    context.CancelFunc
 
@@ -103,6 +110,7 @@ func (ws *Workspace) Status() []*app.TunnelStatus {
 			RemotePort: listener.RemotePort,
 			LocalPort:  listener.LocalPort,
 			Visibility: listener.Visibility,
+			Protocol:   listener.Protocol,
 		})
 	}
 	return res
@@ -198,6 +206,8 @@ type Bastion struct {
 	subscriptions   map[*StatusSubscription]struct{}
 
 	EnableAutoTunnel bool
+	// OfferedPorts are the local ports workspaces can reverse tunnel to
+	OfferedPorts []uint32
 }
 
 func (b *Bastion) Run() error {
@@ -296,7 +306,7 @@ func (b *Bastion) handleUpdate(ur *WorkspaceUpdateRequest) {
 			cancel: cancel,
 
 			tunnelClient:    make(chan chan *TunnelClient, 1),
-			tunnelListeners: make(map[uint32]*TunnelListener),
+			tunnelListeners: make(map[tunnelKey]*TunnelListener),
 			tunnelEnabled:   true,
 		}
 	}
@@ -460,7 +470,7 @@ func (b *Bastion) connectTunnelClient(ctx context.Context, ws *Workspace) error
 				logrus.WithField("workspace", ws.WorkspaceID).WithField("id", client.ID).Warn("tunnel: ssh client is permanently closed")
 			}
 		}()
-		client, closed, err := newTunnelClient(ctx, ws, webSocket)
+		client, closed, err := newTunnelClient(ctx, ws, webSocket, b.OfferedPorts)
 		for {
 			if err != nil {
 				return
@@ -471,14 +481,14 @@ func (b *Bastion) connectTunnelClient(ctx context.Context, ws *Workspace) error
 			case clientCh := <-ws.tunnelClient:
 				clientCh <- client
 			case <-closed:
-				client, closed, err = newTunnelClient(ctx, ws, webSocket)
+				client, closed, err = newTunnelClient(ctx, ws, webSocket, b.OfferedPorts)
 			}
 		}
 	}()
 	return nil
 }
 
-func newTunnelClient(ctx context.Context, ws *Workspace, reconnecting *gitpod.ReconnectingWebsocket) (client *TunnelClient, closed chan struct{}, err error) {
+func newTunnelClient(ctx context.Context, ws *Workspace, reconnecting *gitpod.ReconnectingWebsocket, offeredPorts []uint32) (client *TunnelClient, closed chan struct{}, err error) {
 	logrus.WithField("workspace", ws.WorkspaceID).Info("tunnel: trying to connect ssh client...")
 	err = reconnecting.EnsureConnection(func(conn *gitpod.WebsocketConnection) (bool, error) {
 		id, err := uuid.NewRandom()
@@ -501,10 +511,19 @@ func newTunnelClient(ctx context.Context, ws *Workspace, reconnecting *gitpod.Re
 		go ssh.DiscardRequests(reqs)
 		go func() {
 			for newCh := range chans {
-				// TODO(ak) reverse tunneling
-				newCh.Reject(ssh.UnknownChannelType, "tunnel: reverse is not supported yet")
+				if newCh.ChannelType() != "reverse-tunnel" {
+					newCh.Reject(ssh.UnknownChannelType, "tunnel: unknown channel type")
+					continue
+				}
+				go handleReverseTunnel(ws, offeredPorts, newCh)
 			}
 		}()
+		if len(offeredPorts) > 0 {
+			err = offerPorts(sshConn, id.String(), offeredPorts)
+			if err != nil {
+				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("id", id).Warn("tunnel: failed to offer ports for reverse tunnels")
+			}
+		}
 		closed = make(chan struct{}, 1)
 		go func() {
 			err := sshConn.Wait()
@@ -734,8 +753,8 @@ func (b *Bastion) doTunnelPorts(ctx context.Context, ws *Workspace) error {
 	defer func() {
 		ws.tunnelMu.Lock()
 		defer ws.tunnelMu.Unlock()
-		for port, t := range ws.tunnelListeners {
-			delete(ws.tunnelListeners, port)
+		for key, t := range ws.tunnelListeners {
+			delete(ws.tunnelListeners, key)
 			t.Cancel()
 		}
 	}()
@@ -745,22 +764,23 @@ func (b *Bastion) doTunnelPorts(ctx context.Context, ws *Workspace) error {
 			return err
 		}
 		ws.tunnelMu.Lock()
-		currentTunneled := make(map[uint32]struct{})
+		currentTunneled := make(map[tunnelKey]struct{})
 		for _, port := range resp.Ports {
 			visibility := supervisor.TunnelVisiblity_none
 			if port.Tunneled != nil {
 				visibility = port.Tunneled.Visibility
 			}
-			listener, alreadyTunneled := ws.tunnelListeners[port.LocalPort]
+			key := tunnelKey{Port: port.LocalPort, Protocol: port.Protocol}
+			listener, alreadyTunneled := ws.tunnelListeners[key]
 			if alreadyTunneled && listener.Visibility != visibility {
 				listener.Cancel()
-				delete(ws.tunnelListeners, port.LocalPort)
+				delete(ws.tunnelListeners, key)
 			}
 			if visibility == supervisor.TunnelVisiblity_none {
 				continue
 			}
-			currentTunneled[port.LocalPort] = struct{}{}
-			_, alreadyTunneled = ws.tunnelListeners[port.LocalPort]
+			currentTunneled[key] = struct{}{}
+			_, alreadyTunneled = ws.tunnelListeners[key]
 			if alreadyTunneled {
 				continue
 			}
@@ -769,18 +789,24 @@ func (b *Bastion) doTunnelPorts(ctx context.Context, ws *Workspace) error {
 				continue
 			}
 
-			logprefix := "tunnel[" + supervisor.TunnelVisiblity_name[int32(port.Tunneled.Visibility)] + ":" + strconv.Itoa(int(port.LocalPort)) + "]"
-			listener, err := b.establishTunnel(ws.ctx, ws, logprefix, int(port.LocalPort), int(port.Tunneled.TargetPort), port.Tunneled.Visibility)
+			var err error
+			if port.Protocol == supervisor.PortProtocol_udp {
+				logprefix := "tunnel[" + supervisor.TunnelVisiblity_name[int32(port.Tunneled.Visibility)] + ":" + strconv.Itoa(int(port.LocalPort)) + "/udp]"
+				listener, err = b.establishUDPTunnel(ws.ctx, ws, logprefix, int(port.LocalPort), int(port.Tunneled.TargetPort), port.Tunneled.Visibility)
+			} else {
+				logprefix := "tunnel[" + supervisor.TunnelVisiblity_name[int32(port.Tunneled.Visibility)] + ":" + strconv.Itoa(int(port.LocalPort)) + "]"
+				listener, err = b.establishTunnel(ws.ctx, ws, logprefix, int(port.LocalPort), int(port.Tunneled.TargetPort), port.Tunneled.Visibility)
+			}
 			if err != nil {
-				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("port", port.LocalPort).Error("cannot establish port tunnel")
+				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("port", port.LocalPort).WithField("protocol", port.Protocol.String()).Error("cannot establish port tunnel")
 			} else {
-				ws.tunnelListeners[port.LocalPort] = listener
+				ws.tunnelListeners[key] = listener
 			}
 		}
-		for port, listener := range ws.tunnelListeners {
-			_, exists := currentTunneled[port]
+		for key, listener := range ws.tunnelListeners {
+			_, exists := currentTunneled[key]
 			if !exists {
-				delete(ws.tunnelListeners, port)
+				delete(ws.tunnelListeners, key)
 				listener.Cancel()
 			}
 		}
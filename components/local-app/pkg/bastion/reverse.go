@@ -0,0 +1,92 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package bastion
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/proto"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+// offerPorts makes local ports available for reverse tunnels of the workspace, see gp tunnel --reverse
+func offerPorts(conn ssh.Conn, clientID string, ports []uint32) error {
+	payload, err := proto.Marshal(&supervisor.ReverseTunnelOffer{
+		ClientId: clientID,
+		Ports:    ports,
+	})
+	if err != nil {
+		return err
+	}
+	ok, _, err := conn.SendRequest("offer-ports", true, payload)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return xerrors.Errorf("supervisor does not support reverse tunnels")
+	}
+	return nil
+}
+
+// handleReverseTunnel forwards a connection to a workspace port to the offered local port
+func handleReverseTunnel(ws *Workspace, offeredPorts []uint32, newCh ssh.NewChannel) {
+	req := &supervisor.TunnelPortRequest{}
+	err := proto.Unmarshal(newCh.ExtraData(), req)
+	if err != nil {
+		logrus.WithError(err).WithField("workspace", ws.WorkspaceID).Error("reverse tunnel: invalid ssh chan request")
+		newCh.Reject(ssh.Prohibited, err.Error())
+		return
+	}
+	var offered bool
+	for _, port := range offeredPorts {
+		if port == req.TargetPort {
+			offered = true
+			break
+		}
+	}
+	// the workspace must not reach any other local port than the offered ones
+	if !offered {
+		logrus.WithField("workspace", ws.WorkspaceID).WithField("port", req.TargetPort).Warn("reverse tunnel: port is not offered")
+		newCh.Reject(ssh.Prohibited, "port is not offered")
+		return
+	}
+
+	logprefix := "reverse tunnel[" + strconv.Itoa(int(req.Port)) + ":" + strconv.Itoa(int(req.TargetPort)) + "]"
+	conn, err := net.Dial("tcp", "127.0.0.1:"+strconv.Itoa(int(req.TargetPort)))
+	if err != nil {
+		logrus.WithError(err).WithField("workspace", ws.WorkspaceID).Warn(logprefix + ": failed to connect")
+		newCh.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	defer conn.Close()
+
+	sshChan, reqs, err := newCh.Accept()
+	if err != nil {
+		logrus.WithError(err).WithField("workspace", ws.WorkspaceID).Warn(logprefix + ": accepting ssh channel failed")
+		return
+	}
+	defer sshChan.Close()
+	go ssh.DiscardRequests(reqs)
+	logrus.WithField("workspace", ws.WorkspaceID).Debug(logprefix + ": accepted new connection")
+	defer logrus.WithField("workspace", ws.WorkspaceID).Debug(logprefix + ": connection closed")
+
+	ctx, cancel := context.WithCancel(ws.ctx)
+	go func() {
+		_, _ = io.Copy(sshChan, conn)
+		cancel()
+	}()
+	go func() {
+		_, _ = io.Copy(conn, sshChan)
+		cancel()
+	}()
+	<-ctx.Done()
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package bastion
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/proto"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+const (
+	// maxDatagramSize is the maximum size of a datagram the length prefix of a frame can describe
+	maxDatagramSize = 0xFFFF
+	// udpSessionTimeout is how long a peer's tunnel stays open without any datagrams in either direction
+	udpSessionTimeout = 2 * time.Minute
+	// udpSessionQueue is the number of datagrams buffered per peer while its tunnel is busy
+	udpSessionQueue = 64
+)
+
+// establishUDPTunnel listens for datagrams on a local UDP port. Each peer sending to it gets its own tunnel,
+// such that supervisor can send the replies of the workspace service back to the right peer.
+// Datagrams are framed on the tunnel by their length as big-endian uint16.
+func (b *Bastion) establishUDPTunnel(ctx context.Context, ws *Workspace, logprefix string, remotePort int, targetPort int, visibility supervisor.TunnelVisiblity) (*TunnelListener, error) {
+	if !ws.tunnelClientConnected {
+		return nil, xerrors.Errorf("tunnel client is not connected")
+	}
+	if visibility == supervisor.TunnelVisiblity_none {
+		return nil, xerrors.Errorf("tunnel visibility is none")
+	}
+
+	targetHost := "127.0.0.1"
+	if visibility == supervisor.TunnelVisiblity_network {
+		targetHost = "0.0.0.0"
+	}
+
+	conn, err := net.ListenPacket("udp", targetHost+":"+strconv.Itoa(targetPort))
+	if err != nil {
+		conn, err = net.ListenPacket("udp", targetHost+":0")
+		if err != nil {
+			return nil, err
+		}
+	}
+	localPort := conn.LocalAddr().(*net.UDPAddr).Port
+	logrus.WithField("workspace", ws.WorkspaceID).Info(logprefix + ": listening on " + conn.LocalAddr().String() + "...")
+	listenerCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		<-listenerCtx.Done()
+		conn.Close()
+		logrus.WithField("workspace", ws.WorkspaceID).Info(logprefix + ": closed")
+	}()
+	go func() {
+		var (
+			mu       sync.Mutex
+			sessions = make(map[string]chan []byte)
+			buf      = make([]byte, maxDatagramSize)
+		)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if listenerCtx.Err() != nil {
+				return
+			}
+			if err != nil {
+				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).Warn(logprefix + ": failed to read datagram")
+				continue
+			}
+			datagram := make([]byte, n)
+			copy(datagram, buf[:n])
+
+			mu.Lock()
+			datagrams, exists := sessions[addr.String()]
+			if !exists {
+				datagrams = make(chan []byte, udpSessionQueue)
+				sessions[addr.String()] = datagrams
+				logrus.WithField("workspace", ws.WorkspaceID).WithField("peer", addr.String()).Debug(logprefix + ": new peer")
+				go func() {
+					defer logrus.WithField("workspace", ws.WorkspaceID).WithField("peer", addr.String()).Debug(logprefix + ": peer closed")
+					b.tunnelDatagrams(listenerCtx, ws, logprefix, conn, addr, datagrams, &supervisor.TunnelPortRequest{
+						Port:       uint32(remotePort),
+						TargetPort: uint32(localPort),
+						Protocol:   supervisor.PortProtocol_udp,
+					})
+
+					mu.Lock()
+					delete(sessions, addr.String())
+					mu.Unlock()
+				}()
+			}
+			mu.Unlock()
+
+			select {
+			case datagrams <- datagram:
+			default:
+				// like any other UDP hop we drop datagrams we cannot keep up with
+			}
+		}
+	}()
+	return &TunnelListener{
+		RemotePort: uint32(remotePort),
+		LocalAddr:  conn.LocalAddr().String(),
+		LocalPort:  uint32(localPort),
+		Visibility: visibility,
+		Protocol:   supervisor.PortProtocol_udp,
+		Ctx:        listenerCtx,
+		Cancel:     cancel,
+	}, nil
+}
+
+// tunnelDatagrams forwards the datagrams of a peer over a new tunnel and sends the replies back to the peer
+// until neither side sent anything for udpSessionTimeout.
+func (b *Bastion) tunnelDatagrams(ctx context.Context, ws *Workspace, logprefix string, conn net.PacketConn, peer net.Addr, datagrams <-chan []byte, req *supervisor.TunnelPortRequest) {
+	clientCh := make(chan *TunnelClient, 1)
+	select {
+	case <-ctx.Done():
+		return
+	case ws.tunnelClient <- clientCh:
+	}
+	client := <-clientCh
+
+	req.ClientId = client.ID
+	payload, err := proto.Marshal(req)
+	if err != nil {
+		logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("id", client.ID).Error(logprefix + ": failed to marshal tunnel payload")
+		return
+	}
+	sshChan, reqs, err := client.Conn.OpenChannel("tunnel", payload)
+	if err != nil {
+		logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("id", client.ID).Warn(logprefix + ": failed to establish tunnel")
+		return
+	}
+	defer sshChan.Close()
+	go ssh.DiscardRequests(reqs)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	active := make(chan struct{}, 1)
+	go func() {
+		defer cancel()
+		header := make([]byte, 2)
+		buf := make([]byte, maxDatagramSize)
+		for {
+			_, err := io.ReadFull(sshChan, header)
+			if err != nil {
+				return
+			}
+			datagram := buf[:binary.BigEndian.Uint16(header)]
+			_, err = io.ReadFull(sshChan, datagram)
+			if err != nil {
+				return
+			}
+			_, err = conn.WriteTo(datagram, peer)
+			if err != nil {
+				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("peer", peer.String()).Debug(logprefix + ": failed to send datagram")
+			}
+			select {
+			case active <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	idle := time.NewTimer(udpSessionTimeout)
+	defer idle.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-idle.C:
+			return
+		case <-active:
+		case datagram := <-datagrams:
+			frame := make([]byte, 2+len(datagram))
+			binary.BigEndian.PutUint16(frame, uint16(len(datagram)))
+			copy(frame[2:], datagram)
+			_, err := sshChan.Write(frame)
+			if err != nil {
+				return
+			}
+		}
+		if !idle.Stop() {
+			<-idle.C
+		}
+		idle.Reset(udpSessionTimeout)
+	}
+}
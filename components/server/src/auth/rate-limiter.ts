@@ -80,6 +80,9 @@ function getConfig(config: RateLimiterConfig): RateLimiterConfig {
         "getAllEnvVars": { group: "default", points: 1 },
         "setEnvVar": { group: "default", points: 1 },
         "deleteEnvVar": { group: "default", points: 1 },
+        "getSSHPublicKeys": { group: "default", points: 1 },
+        "addSSHPublicKey": { group: "default", points: 1 },
+        "deleteSSHPublicKey": { group: "default", points: 1 },
         "getTeams": { group: "default", points: 1 },
         "getTeamMembers": { group: "default", points: 1 },
         "createTeam": { group: "default", points: 1 },
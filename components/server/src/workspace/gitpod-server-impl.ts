@@ -7,7 +7,7 @@
 import { BlobServiceClient } from "@gitpod/content-service/lib/blobs_grpc_pb";
 import { DownloadUrlRequest, DownloadUrlResponse, UploadUrlRequest, UploadUrlResponse } from '@gitpod/content-service/lib/blobs_pb';
 import { AppInstallationDB, UserDB, UserMessageViewsDB, WorkspaceDB, DBWithTracing, TracedWorkspaceDB, DBGitpodToken, DBUser, UserStorageResourcesDB, TeamDB } from '@gitpod/gitpod-db/lib';
-import { AuthProviderEntry, AuthProviderInfo, Branding, CommitContext, Configuration, CreateWorkspaceMode, DisposableCollection, GetWorkspaceTimeoutResult, GitpodClient, GitpodServer, GitpodToken, GitpodTokenType, InstallPluginsParams, PermissionName, PortVisibility, PrebuiltWorkspace, PrebuiltWorkspaceContext, PreparePluginUploadParams, ResolvedPlugins, ResolvePluginsParams, SetWorkspaceTimeoutResult, StartPrebuildContext, StartWorkspaceResult, Terms, Token, UninstallPluginParams, User, UserEnvVar, UserEnvVarValue, UserInfo, WhitelistedRepository, Workspace, WorkspaceContext, WorkspaceCreationResult, WorkspaceImageBuild, WorkspaceInfo, WorkspaceInstance, WorkspaceInstancePort, WorkspaceInstanceUser, WorkspaceTimeoutDuration, GuessGitTokenScopesParams, GuessedGitTokenScopes, Team, TeamMemberInfo, TeamMembershipInvite, CreateProjectParams, Project, ProviderRepository, TeamMemberRole, WithDefaultConfig, FindPrebuildsParams, PrebuildWithStatus, StartPrebuildResult, ClientHeaderFields } from '@gitpod/gitpod-protocol';
+import { AuthProviderEntry, AuthProviderInfo, Branding, CommitContext, Configuration, CreateWorkspaceMode, DisposableCollection, GetWorkspaceTimeoutResult, GitpodClient, GitpodServer, GitpodToken, GitpodTokenType, InstallPluginsParams, PermissionName, PortVisibility, PrebuiltWorkspace, PrebuiltWorkspaceContext, PreparePluginUploadParams, ResolvedPlugins, ResolvePluginsParams, SetWorkspaceTimeoutResult, StartPrebuildContext, StartWorkspaceResult, Terms, Token, UninstallPluginParams, User, UserEnvVar, UserEnvVarValue, UserInfo, UserSSHPublicKey, WhitelistedRepository, Workspace, WorkspaceContext, WorkspaceCreationResult, WorkspaceImageBuild, WorkspaceInfo, WorkspaceInstance, WorkspaceInstancePort, WorkspaceInstanceUser, WorkspaceTimeoutDuration, GuessGitTokenScopesParams, GuessedGitTokenScopes, Team, TeamMemberInfo, TeamMembershipInvite, CreateProjectParams, Project, ProviderRepository, TeamMemberRole, WithDefaultConfig, FindPrebuildsParams, PrebuildWithStatus, StartPrebuildResult, ClientHeaderFields } from '@gitpod/gitpod-protocol';
 import { AccountStatement } from "@gitpod/gitpod-protocol/lib/accounting-protocol";
 import { AdminBlockUserRequest, AdminGetListRequest, AdminGetListResult, AdminGetWorkspacesRequest, AdminModifyPermanentWorkspaceFeatureFlagRequest, AdminModifyRoleOrPermissionRequest, WorkspaceAndInstance } from '@gitpod/gitpod-protocol/lib/admin-protocol';
 import { GetLicenseInfoResult, LicenseFeature, LicenseValidationResult } from '@gitpod/gitpod-protocol/lib/license-protocol';
@@ -53,6 +53,9 @@ import { HeadlessLogService } from "./headless-log-service";
 import { InvalidGitpodYMLError } from "./config-provider";
 import { ProjectsService } from "../projects/projects-service";
 
+// don't DOS our database using SSH keys
+const MAX_SSH_PUBLIC_KEYS_PER_USER = 32;
+
 @injectable()
 export class GitpodServerImpl<Client extends GitpodClient, Server extends GitpodServer> implements GitpodServer, Disposable {
 
@@ -1389,6 +1392,53 @@ export class GitpodServerImpl<Client extends GitpodClient, Server extends Gitpod
         await this.userDB.deleteEnvVar(envvar);
     }
 
+    async getSSHPublicKeys(): Promise<UserSSHPublicKey[]> {
+        const user = this.checkUser("getSSHPublicKeys");
+        return user.additionalData?.sshPublicKeys || [];
+    }
+
+    async addSSHPublicKey(name: string, key: string): Promise<UserSSHPublicKey> {
+        const user = this.checkAndBlockUser("addSSHPublicKey");
+
+        key = key.trim();
+        if (!/^(ssh-[a-z0-9-]+|ecdsa-sha2-[a-z0-9-]+|sk-[a-z0-9-@.]+) [A-Za-z0-9+/]+={0,3}( .*)?$/.test(key)) {
+            throw new ResponseError(ErrorCodes.BAD_REQUEST, "key must be a public key in authorized_keys format");
+        }
+        const existingKeys = user.additionalData?.sshPublicKeys || [];
+        if (existingKeys.some(k => this.sshPublicKeyBlob(k.key) === this.sshPublicKeyBlob(key))) {
+            throw new ResponseError(ErrorCodes.CONFLICT, "this key has already been added");
+        }
+        if (existingKeys.length >= MAX_SSH_PUBLIC_KEYS_PER_USER) {
+            throw new ResponseError(ErrorCodes.PERMISSION_DENIED, `cannot have more than ${MAX_SSH_PUBLIC_KEYS_PER_USER} SSH keys`);
+        }
+
+        const sshKey: UserSSHPublicKey = { name, key, creationTime: new Date().toISOString() };
+        user.additionalData = { ...user.additionalData, sshPublicKeys: [...existingKeys, sshKey] };
+        await this.userDB.updateUserPartial(user);
+        this.analytics.track({ event: "ssh-key-added", userId: user.id });
+        return sshKey;
+    }
+
+    async deleteSSHPublicKey(key: string): Promise<void> {
+        const user = this.checkAndBlockUser("deleteSSHPublicKey");
+
+        const existingKeys = user.additionalData?.sshPublicKeys || [];
+        const remainingKeys = existingKeys.filter(k => this.sshPublicKeyBlob(k.key) !== this.sshPublicKeyBlob(key));
+        if (remainingKeys.length === existingKeys.length) {
+            throw new ResponseError(ErrorCodes.NOT_FOUND, "SSH key not found");
+        }
+        user.additionalData = { ...user.additionalData, sshPublicKeys: remainingKeys };
+        await this.userDB.updateUserPartial(user);
+        this.analytics.track({ event: "ssh-key-deleted", userId: user.id });
+    }
+
+    /**
+     * Returns the base64 encoded key of a public key in authorized_keys format, which identifies the key regardless of its comment.
+     */
+    protected sshPublicKeyBlob(key: string): string {
+        return key.trim().split(/\s+/)[1] || "";
+    }
+
     protected async guardTeamOperation(teamId: string | undefined, op: ResourceAccessOp): Promise<void> {
         const team = await this.teamDB.findTeamById(teamId || "");
         if (!team) {
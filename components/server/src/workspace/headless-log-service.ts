@@ -104,16 +104,16 @@ export class HeadlessLogService {
         for (const task of tasks) {
             const taskId = task.getId();
             const terminalId = task.getTerminal();
-            if (task.getState() === TaskState.OPENING) {
-                // this might be the case when there is no terminal for this task, yet.
-                // if we find any such case, we deem the workspace not ready yet, and try to reconnect later,
-                // to be sure to get hold of all terminals created.
-                throw new Error(`instance's ${wsi.id} task ${task.getId()} has no terminal yet`);
-            }
             if (task.getState() === TaskState.CLOSED) {
                 // if a task has already been closed we can no longer access it's terminal, and have to skip it.
                 continue;
             }
+            if (task.getState() === TaskState.OPENING || task.getState() === TaskState.WAITING) {
+                // there is no terminal for this task yet, because it's still opening or waiting for its dependencies,
+                // readiness gates or restart backoff. If we find any such case, we deem the workspace not ready yet,
+                // and try to reconnect later, to be sure to get hold of all terminals created.
+                throw new Error(`instance's ${wsi.id} task ${task.getId()} has no terminal yet`);
+            }
             streams[taskId] = this.config.hostUrl.with({
                 pathname: `/headless-logs/${wsi.id}/${terminalId}`,
             }).toString();
@@ -4,7 +4,7 @@
  * See License-AGPL.txt in the project root for license information.
  */
 
-import { CloneTargetMode, FileDownloadInitializer, GitAuthMethod, GitConfig, GitInitializer, PrebuildInitializer, SnapshotInitializer, WorkspaceInitializer } from "@gitpod/content-service/lib";
+import { CloneFilter, CloneTargetMode, FileDownloadInitializer, GitAuthMethod, GitConfig, GitInitializer, GitLFSMode, PrebuildInitializer, SnapshotInitializer, WorkspaceInitializer } from "@gitpod/content-service/lib";
 import { CompositeInitializer, FromBackupInitializer } from "@gitpod/content-service/lib/initializer_pb";
 import { DBUser, DBWithTracing, TracedUserDB, TracedWorkspaceDB, UserDB, WorkspaceDB } from '@gitpod/gitpod-db/lib';
 import { CommitContext, Disposable, GitpodToken, GitpodTokenType, IssueContext, NamedWorkspaceFeatureFlag, PullRequestContext, RefType, SnapshotContext, StartWorkspaceResult, User, UserEnvVar, UserEnvVarValue, WithEnvvarsContext, WithPrebuild, Workspace, WorkspaceContext, WorkspaceImageSource, WorkspaceImageSourceDocker, WorkspaceImageSourceReference, WorkspaceInstance, WorkspaceInstanceConfiguration, WorkspaceInstanceStatus, WorkspaceProbeContext, Permission, HeadlessWorkspaceEvent, HeadlessWorkspaceEventType, DisposableCollection, AdditionalContentContext, ImageConfigFile } from "@gitpod/gitpod-protocol";
@@ -739,6 +739,7 @@ export class WorkspaceStarter {
             "function:getEnvVars",
             "function:setEnvVar",
             "function:deleteEnvVar",
+            "function:getSSHPublicKeys",
             "function:trackEvent",
 
             "resource:"+ScopedResourceGuard.marshalResourceScope({kind: "workspace", subjectID: workspace.id, operations: ["get", "update"]}),
@@ -917,6 +918,19 @@ export class WorkspaceStarter {
                 .forEach(k => gitConfig.getCustomConfigMap().set(k, userGitConfig[k]));
         }
 
+        const cloneConfig = workspace.config.gitClone || {};
+        switch (cloneConfig.lfs) {
+            case 'all':
+                gitConfig.setLfs(GitLFSMode.LFS_FETCH_ALL);
+                break;
+            case 'include':
+                gitConfig.setLfs(GitLFSMode.LFS_FETCH_INCLUDE);
+                gitConfig.setLfsIncludePatternsList(cloneConfig.lfsInclude || []);
+                break;
+            default:
+                gitConfig.setLfs(GitLFSMode.LFS_SKIP);
+        }
+
         const result = new GitInitializer();
         result.setConfig(gitConfig);
         result.setCheckoutLocation(this.getCheckoutLocation(workspace));
@@ -928,6 +942,20 @@ export class WorkspaceStarter {
         if (!!upstreamRemoteURI) {
             result.setUpstreamRemoteUri(upstreamRemoteURI);
         }
+        if (!!cloneConfig.depth) {
+            result.setCloneDepth(cloneConfig.depth);
+        }
+        switch (cloneConfig.filter) {
+            case 'blob:none':
+                result.setCloneFilter(CloneFilter.BLOB_NONE);
+                break;
+            case 'tree:0':
+                result.setCloneFilter(CloneFilter.TREE_ZERO);
+                break;
+        }
+        if (!!cloneConfig.sparseCheckout) {
+            result.setSparseCheckoutPatternsList(cloneConfig.sparseCheckout);
+        }
 
         return {
             git: result,
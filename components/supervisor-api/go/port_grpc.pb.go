@@ -29,6 +29,14 @@ type PortServiceClient interface {
 	CloseTunnel(ctx context.Context, in *CloseTunnelRequest, opts ...grpc.CallOption) (*CloseTunnelResponse, error)
 	// EstablishTunnel actually establishes the tunnel for an incoming connection on a remote machine.
 	EstablishTunnel(ctx context.Context, opts ...grpc.CallOption) (PortService_EstablishTunnelClient, error)
+	// ReverseTunnel listens on a workspace port and forwards incoming connections
+	// to a port offered by a connected client, e.g. a service on the user's machine.
+	// Clients offer their ports with a ReverseTunnelOffer on the tunnel connection.
+	// The reverse tunnel exists as long as the call is active.
+	ReverseTunnel(ctx context.Context, in *ReverseTunnelRequest, opts ...grpc.CallOption) (PortService_ReverseTunnelClient, error)
+	// SharePort mints a link to an exposed port which grants access to anyone
+	// who has it until it expires, without making the port public.
+	SharePort(ctx context.Context, in *SharePortRequest, opts ...grpc.CallOption) (*SharePortResponse, error)
 	// AutoTunnel controls enablement of auto tunneling
 	AutoTunnel(ctx context.Context, in *AutoTunnelRequest, opts ...grpc.CallOption) (*AutoTunnelResponse, error)
 	// RetryAutoExpose retries auto exposing the give port
@@ -92,6 +100,47 @@ func (x *portServiceEstablishTunnelClient) Recv() (*EstablishTunnelResponse, err
 	return m, nil
 }
 
+func (c *portServiceClient) ReverseTunnel(ctx context.Context, in *ReverseTunnelRequest, opts ...grpc.CallOption) (PortService_ReverseTunnelClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PortService_ServiceDesc.Streams[1], "/supervisor.PortService/ReverseTunnel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &portServiceReverseTunnelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PortService_ReverseTunnelClient interface {
+	Recv() (*ReverseTunnelResponse, error)
+	grpc.ClientStream
+}
+
+type portServiceReverseTunnelClient struct {
+	grpc.ClientStream
+}
+
+func (x *portServiceReverseTunnelClient) Recv() (*ReverseTunnelResponse, error) {
+	m := new(ReverseTunnelResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *portServiceClient) SharePort(ctx context.Context, in *SharePortRequest, opts ...grpc.CallOption) (*SharePortResponse, error) {
+	out := new(SharePortResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.PortService/SharePort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *portServiceClient) AutoTunnel(ctx context.Context, in *AutoTunnelRequest, opts ...grpc.CallOption) (*AutoTunnelResponse, error) {
 	out := new(AutoTunnelResponse)
 	err := c.cc.Invoke(ctx, "/supervisor.PortService/AutoTunnel", in, out, opts...)
@@ -121,6 +170,14 @@ type PortServiceServer interface {
 	CloseTunnel(context.Context, *CloseTunnelRequest) (*CloseTunnelResponse, error)
 	// EstablishTunnel actually establishes the tunnel for an incoming connection on a remote machine.
 	EstablishTunnel(PortService_EstablishTunnelServer) error
+	// ReverseTunnel listens on a workspace port and forwards incoming connections
+	// to a port offered by a connected client, e.g. a service on the user's machine.
+	// Clients offer their ports with a ReverseTunnelOffer on the tunnel connection.
+	// The reverse tunnel exists as long as the call is active.
+	ReverseTunnel(*ReverseTunnelRequest, PortService_ReverseTunnelServer) error
+	// SharePort mints a link to an exposed port which grants access to anyone
+	// who has it until it expires, without making the port public.
+	SharePort(context.Context, *SharePortRequest) (*SharePortResponse, error)
 	// AutoTunnel controls enablement of auto tunneling
 	AutoTunnel(context.Context, *AutoTunnelRequest) (*AutoTunnelResponse, error)
 	// RetryAutoExpose retries auto exposing the give port
@@ -141,6 +198,12 @@ func (UnimplementedPortServiceServer) CloseTunnel(context.Context, *CloseTunnelR
 func (UnimplementedPortServiceServer) EstablishTunnel(PortService_EstablishTunnelServer) error {
 	return status.Errorf(codes.Unimplemented, "method EstablishTunnel not implemented")
 }
+func (UnimplementedPortServiceServer) ReverseTunnel(*ReverseTunnelRequest, PortService_ReverseTunnelServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReverseTunnel not implemented")
+}
+func (UnimplementedPortServiceServer) SharePort(context.Context, *SharePortRequest) (*SharePortResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SharePort not implemented")
+}
 func (UnimplementedPortServiceServer) AutoTunnel(context.Context, *AutoTunnelRequest) (*AutoTunnelResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AutoTunnel not implemented")
 }
@@ -222,6 +285,45 @@ func (x *portServiceEstablishTunnelServer) Recv() (*EstablishTunnelRequest, erro
 	return m, nil
 }
 
+func _PortService_ReverseTunnel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReverseTunnelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PortServiceServer).ReverseTunnel(m, &portServiceReverseTunnelServer{stream})
+}
+
+type PortService_ReverseTunnelServer interface {
+	Send(*ReverseTunnelResponse) error
+	grpc.ServerStream
+}
+
+type portServiceReverseTunnelServer struct {
+	grpc.ServerStream
+}
+
+func (x *portServiceReverseTunnelServer) Send(m *ReverseTunnelResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PortService_SharePort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharePortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortServiceServer).SharePort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.PortService/SharePort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortServiceServer).SharePort(ctx, req.(*SharePortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PortService_AutoTunnel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AutoTunnelRequest)
 	if err := dec(in); err != nil {
@@ -273,6 +375,10 @@ var PortService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CloseTunnel",
 			Handler:    _PortService_CloseTunnel_Handler,
 		},
+		{
+			MethodName: "SharePort",
+			Handler:    _PortService_SharePort_Handler,
+		},
 		{
 			MethodName: "AutoTunnel",
 			Handler:    _PortService_AutoTunnel_Handler,
@@ -289,6 +395,11 @@ var PortService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ReverseTunnel",
+			Handler:       _PortService_ReverseTunnel_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "port.proto",
 }
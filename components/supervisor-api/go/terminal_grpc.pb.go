@@ -41,6 +41,13 @@ type TerminalServiceClient interface {
 	SetTitle(ctx context.Context, in *SetTerminalTitleRequest, opts ...grpc.CallOption) (*SetTerminalTitleResponse, error)
 	// UpdateAnnotations updates the terminal's annotations
 	UpdateAnnotations(ctx context.Context, in *UpdateTerminalAnnotationsRequest, opts ...grpc.CallOption) (*UpdateTerminalAnnotationsResponse, error)
+	// GetRecording streams the asciicast v2 recording of a terminal's output.
+	// Recordings remain available after the terminal was closed.
+	GetRecording(ctx context.Context, in *GetTerminalRecordingRequest, opts ...grpc.CallOption) (TerminalService_GetRecordingClient, error)
+	// Replay streams the recorded output of a terminal with its original timing.
+	Replay(ctx context.Context, in *ReplayTerminalRequest, opts ...grpc.CallOption) (TerminalService_ReplayClient, error)
+	// TransferWriteLock hands the write lock of a terminal over to another attached client.
+	TransferWriteLock(ctx context.Context, in *TransferTerminalWriteLockRequest, opts ...grpc.CallOption) (*TransferTerminalWriteLockResponse, error)
 }
 
 type terminalServiceClient struct {
@@ -155,6 +162,79 @@ func (c *terminalServiceClient) UpdateAnnotations(ctx context.Context, in *Updat
 	return out, nil
 }
 
+func (c *terminalServiceClient) GetRecording(ctx context.Context, in *GetTerminalRecordingRequest, opts ...grpc.CallOption) (TerminalService_GetRecordingClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TerminalService_ServiceDesc.Streams[1], "/supervisor.TerminalService/GetRecording", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &terminalServiceGetRecordingClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TerminalService_GetRecordingClient interface {
+	Recv() (*GetTerminalRecordingResponse, error)
+	grpc.ClientStream
+}
+
+type terminalServiceGetRecordingClient struct {
+	grpc.ClientStream
+}
+
+func (x *terminalServiceGetRecordingClient) Recv() (*GetTerminalRecordingResponse, error) {
+	m := new(GetTerminalRecordingResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *terminalServiceClient) Replay(ctx context.Context, in *ReplayTerminalRequest, opts ...grpc.CallOption) (TerminalService_ReplayClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TerminalService_ServiceDesc.Streams[2], "/supervisor.TerminalService/Replay", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &terminalServiceReplayClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TerminalService_ReplayClient interface {
+	Recv() (*ReplayTerminalResponse, error)
+	grpc.ClientStream
+}
+
+type terminalServiceReplayClient struct {
+	grpc.ClientStream
+}
+
+func (x *terminalServiceReplayClient) Recv() (*ReplayTerminalResponse, error) {
+	m := new(ReplayTerminalResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *terminalServiceClient) TransferWriteLock(ctx context.Context, in *TransferTerminalWriteLockRequest, opts ...grpc.CallOption) (*TransferTerminalWriteLockResponse, error) {
+	out := new(TransferTerminalWriteLockResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TerminalService/TransferWriteLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TerminalServiceServer is the server API for TerminalService service.
 // All implementations must embed UnimplementedTerminalServiceServer
 // for forward compatibility
@@ -178,6 +258,13 @@ type TerminalServiceServer interface {
 	SetTitle(context.Context, *SetTerminalTitleRequest) (*SetTerminalTitleResponse, error)
 	// UpdateAnnotations updates the terminal's annotations
 	UpdateAnnotations(context.Context, *UpdateTerminalAnnotationsRequest) (*UpdateTerminalAnnotationsResponse, error)
+	// GetRecording streams the asciicast v2 recording of a terminal's output.
+	// Recordings remain available after the terminal was closed.
+	GetRecording(*GetTerminalRecordingRequest, TerminalService_GetRecordingServer) error
+	// Replay streams the recorded output of a terminal with its original timing.
+	Replay(*ReplayTerminalRequest, TerminalService_ReplayServer) error
+	// TransferWriteLock hands the write lock of a terminal over to another attached client.
+	TransferWriteLock(context.Context, *TransferTerminalWriteLockRequest) (*TransferTerminalWriteLockResponse, error)
 	mustEmbedUnimplementedTerminalServiceServer()
 }
 
@@ -212,6 +299,15 @@ func (UnimplementedTerminalServiceServer) SetTitle(context.Context, *SetTerminal
 func (UnimplementedTerminalServiceServer) UpdateAnnotations(context.Context, *UpdateTerminalAnnotationsRequest) (*UpdateTerminalAnnotationsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateAnnotations not implemented")
 }
+func (UnimplementedTerminalServiceServer) GetRecording(*GetTerminalRecordingRequest, TerminalService_GetRecordingServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetRecording not implemented")
+}
+func (UnimplementedTerminalServiceServer) Replay(*ReplayTerminalRequest, TerminalService_ReplayServer) error {
+	return status.Errorf(codes.Unimplemented, "method Replay not implemented")
+}
+func (UnimplementedTerminalServiceServer) TransferWriteLock(context.Context, *TransferTerminalWriteLockRequest) (*TransferTerminalWriteLockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferWriteLock not implemented")
+}
 func (UnimplementedTerminalServiceServer) mustEmbedUnimplementedTerminalServiceServer() {}
 
 // UnsafeTerminalServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -390,6 +486,66 @@ func _TerminalService_UpdateAnnotations_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TerminalService_GetRecording_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTerminalRecordingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TerminalServiceServer).GetRecording(m, &terminalServiceGetRecordingServer{stream})
+}
+
+type TerminalService_GetRecordingServer interface {
+	Send(*GetTerminalRecordingResponse) error
+	grpc.ServerStream
+}
+
+type terminalServiceGetRecordingServer struct {
+	grpc.ServerStream
+}
+
+func (x *terminalServiceGetRecordingServer) Send(m *GetTerminalRecordingResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TerminalService_Replay_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReplayTerminalRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TerminalServiceServer).Replay(m, &terminalServiceReplayServer{stream})
+}
+
+type TerminalService_ReplayServer interface {
+	Send(*ReplayTerminalResponse) error
+	grpc.ServerStream
+}
+
+type terminalServiceReplayServer struct {
+	grpc.ServerStream
+}
+
+func (x *terminalServiceReplayServer) Send(m *ReplayTerminalResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TerminalService_TransferWriteLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferTerminalWriteLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerminalServiceServer).TransferWriteLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TerminalService/TransferWriteLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerminalServiceServer).TransferWriteLock(ctx, req.(*TransferTerminalWriteLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TerminalService_ServiceDesc is the grpc.ServiceDesc for TerminalService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -429,6 +585,10 @@ var TerminalService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateAnnotations",
 			Handler:    _TerminalService_UpdateAnnotations_Handler,
 		},
+		{
+			MethodName: "TransferWriteLock",
+			Handler:    _TerminalService_TransferWriteLock_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -436,6 +596,16 @@ var TerminalService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TerminalService_Listen_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetRecording",
+			Handler:       _TerminalService_GetRecording_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Replay",
+			Handler:       _TerminalService_Replay_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "terminal.proto",
 }
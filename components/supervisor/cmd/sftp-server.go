@@ -0,0 +1,42 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/sftp"
+	"github.com/spf13/cobra"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+var sftpServerCmd = &cobra.Command{
+	Use:    "sftp-server",
+	Short:  "serves the SFTP protocol on stdin/stdout",
+	Hidden: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		// The SSH server runs this command as the workspace user for the sftp subsystem.
+		// We don't log.Fatal here because that would write the workspace's termination log.
+		srv, err := sftp.NewServer(struct {
+			io.Reader
+			io.WriteCloser
+		}{os.Stdin, os.Stdout})
+		if err != nil {
+			log.WithError(err).Error("cannot start SFTP server")
+			os.Exit(1)
+		}
+		err = srv.Serve()
+		if err != nil && err != io.EOF {
+			log.WithError(err).Error("SFTP server failed")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sftpServerCmd)
+}
@@ -9,11 +9,25 @@ import (
 	"strconv"
 	"time"
 
+	"golang.org/x/xerrors"
+
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/supervisor/api"
 	"github.com/spf13/cobra"
 )
 
+var tunnelOpts struct {
+	Protocol string
+}
+
+func tunnelProtocol() (api.PortProtocol, error) {
+	protocol, ok := api.PortProtocol_value[tunnelOpts.Protocol]
+	if !ok {
+		return 0, xerrors.Errorf("unknown protocol: %s", tunnelOpts.Protocol)
+	}
+	return api.PortProtocol(protocol), nil
+}
+
 var tunnelCmd = &cobra.Command{
 	Use:   "tunnel <localPort> [targetPort] [visibility]",
 	Short: "opens a new tunnel",
@@ -35,6 +49,10 @@ var tunnelCmd = &cobra.Command{
 		if len(args) > 2 {
 			visiblity = api.TunnelVisiblity(api.TunnelVisiblity_value[args[2]])
 		}
+		protocol, err := tunnelProtocol()
+		if err != nil {
+			log.WithError(err).Fatal("invalid protocol")
+		}
 
 		client := api.NewPortServiceClient(dialSupervisor())
 
@@ -44,6 +62,7 @@ var tunnelCmd = &cobra.Command{
 			Port:       uint32(localPort),
 			TargetPort: uint32(targetPort),
 			Visibility: visiblity,
+			Protocol:   protocol,
 		})
 		if err != nil {
 			log.WithError(err).Fatal("cannot tunnel")
@@ -61,13 +80,18 @@ var closeTunnelCmd = &cobra.Command{
 			log.WithError(err).Fatal("invalid local port")
 			return
 		}
+		protocol, err := tunnelProtocol()
+		if err != nil {
+			log.WithError(err).Fatal("invalid protocol")
+		}
 
 		client := api.NewPortServiceClient(dialSupervisor())
 
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
 		_, err = client.CloseTunnel(ctx, &api.CloseTunnelRequest{
-			Port: uint32(localPort),
+			Port:     uint32(localPort),
+			Protocol: protocol,
 		})
 		if err != nil {
 			log.WithError(err).Fatal("cannot close the tunnel")
@@ -101,6 +125,7 @@ var autoTunnelCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(tunnelCmd)
+	tunnelCmd.PersistentFlags().StringVar(&tunnelOpts.Protocol, "protocol", api.PortProtocol_tcp.String(), "protocol of the port: tcp or udp")
 	tunnelCmd.AddCommand(closeTunnelCmd)
 	tunnelCmd.AddCommand(autoTunnelCmd)
 }
@@ -0,0 +1,72 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Package exitstatus keeps the exit status of the child processes supervisor's reaper waits for.
+//
+// The reaper waits for any child process, including those started using os/exec. When the reaper
+// collects such a process first, waiting for it fails with ECHILD and its exit status would be lost.
+// Whoever started the process can look the exit status up here instead.
+package exitstatus
+
+import (
+	"context"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// retention is the time for which an exit status is kept
+const retention = 1 * time.Minute
+
+type record struct {
+	Status   syscall.WaitStatus
+	ReapedAt time.Time
+}
+
+var (
+	mu      sync.Mutex
+	records = make(map[int]record)
+	// recorded is closed and replaced whenever an exit status was recorded
+	recorded = make(chan struct{})
+)
+
+// Record stores the exit status of a process the reaper waited for
+func Record(pid int, status syscall.WaitStatus) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := time.Now()
+	for p, r := range records {
+		if now.Sub(r.ReapedAt) > retention {
+			delete(records, p)
+		}
+	}
+	records[pid] = record{Status: status, ReapedAt: now}
+
+	close(recorded)
+	recorded = make(chan struct{})
+}
+
+// Await returns the exit status of a process which was started at or after since and waited for by the reaper.
+// Waiting for a process by its PID only fails once the reaper collected it, but the reaper might not have
+// recorded its exit status yet. Hence Await waits for the exit status until ctx is done.
+func Await(ctx context.Context, pid int, since time.Time) (status syscall.WaitStatus, ok bool) {
+	for {
+		mu.Lock()
+		r, found := records[pid]
+		if found && !r.ReapedAt.Before(since) {
+			delete(records, pid)
+			mu.Unlock()
+			return r.Status, true
+		}
+		wait := recorded
+		mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return 0, false
+		case <-wait:
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package exitstatus
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestAwait(t *testing.T) {
+	const pid = 4242
+	Record(pid, syscall.WaitStatus(1<<8))
+	since := time.Now().Add(time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, ok := Await(ctx, pid, since); ok {
+		t.Errorf("exit status of a process which ended before the awaited one was started was returned")
+	}
+
+	go Record(pid, syscall.WaitStatus(3<<8))
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	status, ok := Await(ctx, pid, since)
+	if !ok {
+		t.Fatal("exit status was not awaited")
+	}
+	if status.ExitStatus() != 3 {
+		t.Errorf("unexpected exit status: %d", status.ExitStatus())
+	}
+}
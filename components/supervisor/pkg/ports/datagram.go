@@ -0,0 +1,68 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"encoding/binary"
+	"net"
+)
+
+const (
+	// datagramHeaderSize is the size of the length prefix of a framed datagram
+	datagramHeaderSize = 2
+	// maxDatagramSize is the maximum size of a datagram the length prefix can describe
+	maxDatagramSize = 0xFFFF
+)
+
+// datagramConn carries the datagrams of a UDP connection over a stream, e.g. a tunnel.
+// Reading returns the received datagrams, each prefixed by its length as big-endian uint16.
+// Writing expects the same framing and sends a datagram once its frame is complete.
+type datagramConn struct {
+	net.Conn
+
+	rframe []byte
+	rbuf   []byte
+	wbuf   []byte
+}
+
+func newDatagramConn(conn net.Conn) *datagramConn {
+	return &datagramConn{
+		Conn:   conn,
+		rframe: make([]byte, datagramHeaderSize+maxDatagramSize),
+	}
+}
+
+func (c *datagramConn) Read(b []byte) (n int, err error) {
+	if len(c.rbuf) == 0 {
+		n, err = c.Conn.Read(c.rframe[datagramHeaderSize:])
+		if err != nil {
+			return 0, err
+		}
+		binary.BigEndian.PutUint16(c.rframe, uint16(n))
+		c.rbuf = c.rframe[:datagramHeaderSize+n]
+	}
+	n = copy(b, c.rbuf)
+	c.rbuf = c.rbuf[n:]
+	return n, nil
+}
+
+func (c *datagramConn) Write(b []byte) (n int, err error) {
+	c.wbuf = append(c.wbuf, b...)
+	var offset int
+	for len(c.wbuf)-offset >= datagramHeaderSize {
+		size := int(binary.BigEndian.Uint16(c.wbuf[offset:]))
+		end := offset + datagramHeaderSize + size
+		if len(c.wbuf) < end {
+			break
+		}
+		_, err = c.Conn.Write(c.wbuf[offset+datagramHeaderSize : end])
+		if err != nil {
+			return 0, err
+		}
+		offset = end
+	}
+	c.wbuf = append(c.wbuf[:0], c.wbuf[offset:]...)
+	return len(b), nil
+}
@@ -39,6 +39,8 @@ func NewManager(exposed ExposedPortsInterface, served ServedPortsObserver, confi
 		internal[p] = struct{}{}
 	}
 
+	ephemeralPortRangeLo, ephemeralPortRangeHi := readEphemeralPortRange()
+
 	return &Manager{
 		E: exposed,
 		S: served,
@@ -50,12 +52,16 @@ func NewManager(exposed ExposedPortsInterface, served ServedPortsObserver, confi
 		internal:     internal,
 		proxies:      make(map[uint32]*localhostProxy),
 		autoExposed:  make(map[uint32]*autoExposure),
-		autoTunneled: make(map[uint32]struct{}),
+		autoTunneled: make(map[tunnelKey]struct{}),
 
 		state:         state,
+		udpState:      make(map[uint32]*managedPort),
 		subscriptions: make(map[*Subscription]struct{}),
 		proxyStarter:  startLocalhostProxy,
 
+		ephemeralPortRangeLo: ephemeralPortRangeLo,
+		ephemeralPortRangeHi: ephemeralPortRangeHi,
+
 		autoTunnelEnabled: true,
 	}
 }
@@ -87,16 +93,23 @@ type Manager struct {
 	proxyStarter func(LocalhostPort uint32, GlobalPort uint32) (proxy io.Closer, err error)
 	autoExposed  map[uint32]*autoExposure
 
-	autoTunneled      map[uint32]struct{}
+	autoTunneled      map[tunnelKey]struct{}
 	autoTunnelEnabled bool
 
-	configs  *Configs
-	exposed  []ExposedPort
-	served   []ServedPort
-	tunneled []PortTunnelState
+	// UDP sockets bound to a port in the ephemeral port range are most likely clients,
+	// e.g. DNS lookups. We ignore those unless the port is configured.
+	ephemeralPortRangeLo uint32
+	ephemeralPortRangeHi uint32
 
-	state map[uint32]*managedPort
-	mu    sync.RWMutex
+	configs   *Configs
+	exposed   []ExposedPort
+	served    []ServedPort
+	servedUDP []ServedPort
+	tunneled  []PortTunnelState
+
+	state    map[uint32]*managedPort
+	udpState map[uint32]*managedPort
+	mu       sync.RWMutex
 
 	subscriptions map[*Subscription]struct{}
 	closed        bool
@@ -113,6 +126,9 @@ type managedPort struct {
 	LocalhostPort uint32
 	GlobalPort    uint32
 
+	PID         uint32
+	ProcessName string
+
 	Tunneled           bool
 	TunneledTargetPort uint32
 	TunneledVisibility api.TunnelVisiblity
@@ -244,36 +260,42 @@ func (pm *Manager) updateState(ctx context.Context, exposed []ExposedPort, serve
 		pm.tunneled = tunneled
 	}
 
+	// the configs decide which UDP ports we tunnel
+	tunnelsChanged := configured != nil
+	if configured != nil {
+		pm.configs = configured
+	}
+
 	if served != nil {
-		var servedKeys []uint32 // to preserve insertion order
-		servedMap := make(map[uint32]ServedPort)
+		var servedTCP, servedUDP []ServedPort
 		for _, port := range served {
-			current, exists := servedMap[port.Port]
-			if !exists {
-				servedKeys = append(servedKeys, port.Port)
-			}
-			if !exists || (!port.BoundToLocalhost && current.BoundToLocalhost) {
-				servedMap[port.Port] = port
+			if port.Protocol == api.PortProtocol_udp {
+				servedUDP = append(servedUDP, port)
+			} else {
+				servedTCP = append(servedTCP, port)
 			}
 		}
-		var newServed []ServedPort
-		for _, key := range servedKeys {
-			newServed = append(newServed, servedMap[key])
-		}
+		newServed := dedupServedPorts(servedTCP)
 		if !reflect.DeepEqual(pm.served, newServed) {
 			pm.served = newServed
 			pm.updateProxies()
-			pm.autoTunnel(ctx)
+			tunnelsChanged = true
+		}
+		newServedUDP := dedupServedPorts(servedUDP)
+		if !reflect.DeepEqual(pm.servedUDP, newServedUDP) {
+			pm.servedUDP = newServedUDP
+			tunnelsChanged = true
 		}
 	}
-
-	if configured != nil {
-		pm.configs = configured
+	if tunnelsChanged {
+		pm.autoTunnel(ctx)
 	}
 
 	newState := pm.nextState(ctx)
-	stateChanged := !reflect.DeepEqual(newState, pm.state)
+	newUDPState := pm.nextUDPState()
+	stateChanged := !reflect.DeepEqual(newState, pm.state) || !reflect.DeepEqual(newUDPState, pm.udpState)
 	pm.state = newState
+	pm.udpState = newUDPState
 
 	if !stateChanged {
 		return
@@ -291,6 +313,26 @@ func (pm *Manager) updateState(ctx context.Context, exposed []ExposedPort, serve
 	}
 }
 
+// dedupServedPorts returns one entry per port, preferring globally bound ones
+func dedupServedPorts(served []ServedPort) []ServedPort {
+	var servedKeys []uint32 // to preserve insertion order
+	servedMap := make(map[uint32]ServedPort)
+	for _, port := range served {
+		current, exists := servedMap[port.Port]
+		if !exists {
+			servedKeys = append(servedKeys, port.Port)
+		}
+		if !exists || (!port.BoundToLocalhost && current.BoundToLocalhost) {
+			servedMap[port.Port] = port
+		}
+	}
+	var res []ServedPort
+	for _, key := range servedKeys {
+		res = append(res, servedMap[key])
+	}
+	return res
+}
+
 func (pm *Manager) nextState(ctx context.Context) map[uint32]*managedPort {
 	state := make(map[uint32]*managedPort)
 
@@ -318,7 +360,7 @@ func (pm *Manager) nextState(ctx context.Context) map[uint32]*managedPort {
 
 	for _, tunneled := range pm.tunneled {
 		port := tunneled.Desc.LocalPort
-		if pm.boundInternally(port) {
+		if tunneled.Desc.Protocol != api.PortProtocol_tcp || pm.boundInternally(port) {
 			continue
 		}
 		mp, exists := state[port]
@@ -386,6 +428,8 @@ func (pm *Manager) nextState(ctx context.Context) map[uint32]*managedPort {
 
 		mp.LocalhostPort = port
 		mp.Served = true
+		mp.PID = served.PID
+		mp.ProcessName = served.ProcessName
 
 		var exposedGlobalPort uint32
 		autoExposure, autoExposed := pm.autoExposed[port]
@@ -426,6 +470,38 @@ func (pm *Manager) nextState(ctx context.Context) map[uint32]*managedPort {
 	return state
 }
 
+// nextUDPState captures served and tunneled UDP ports. Those can be tunneled only, hence they are neither proxied nor exposed.
+func (pm *Manager) nextUDPState() map[uint32]*managedPort {
+	state := make(map[uint32]*managedPort)
+	for _, tunneled := range pm.tunneled {
+		if tunneled.Desc.Protocol != api.PortProtocol_udp {
+			continue
+		}
+		port := tunneled.Desc.LocalPort
+		state[port] = &managedPort{
+			LocalhostPort:      port,
+			Tunneled:           true,
+			TunneledTargetPort: tunneled.Desc.TargetPort,
+			TunneledVisibility: tunneled.Desc.Visibility,
+			TunneledClients:    tunneled.Clients,
+		}
+	}
+	for _, served := range pm.servedUDP {
+		if !pm.isUDPServer(served.Port) {
+			continue
+		}
+		mp, exists := state[served.Port]
+		if !exists {
+			mp = &managedPort{LocalhostPort: served.Port}
+			state[served.Port] = mp
+		}
+		mp.Served = true
+		mp.PID = served.PID
+		mp.ProcessName = served.ProcessName
+	}
+	return state
+}
+
 // clients should guard a call with check whether such port is already exposed or auto exposed
 func (pm *Manager) autoExpose(ctx context.Context, localPort uint32, globalPort uint32, public bool) *autoExposure {
 	exposing := pm.E.Expose(ctx, localPort, globalPort, public)
@@ -472,30 +548,37 @@ func (pm *Manager) forceUpdate() {
 
 func (pm *Manager) autoTunnel(ctx context.Context) {
 	if !pm.autoTunnelEnabled {
-		var localPorts []uint32
-		for localPort := range pm.autoTunneled {
-			localPorts = append(localPorts, localPort)
+		localPorts := make(map[api.PortProtocol][]uint32)
+		for key := range pm.autoTunneled {
+			localPorts[key.Protocol] = append(localPorts[key.Protocol], key.LocalPort)
 		}
 		// CloseTunnel ensures that everything is closed
-		pm.autoTunneled = make(map[uint32]struct{})
-		_, err := pm.T.CloseTunnel(ctx, localPorts...)
-		if err != nil {
-			log.WithError(err).Error("cannot close auto tunneled ports")
+		pm.autoTunneled = make(map[tunnelKey]struct{})
+		for protocol, ports := range localPorts {
+			_, err := pm.T.CloseTunnel(ctx, protocol, ports...)
+			if err != nil {
+				log.WithError(err).WithField("protocol", protocol.String()).Error("cannot close auto tunneled ports")
+			}
 		}
 		return
 	}
 	var descs []*PortTunnelDescription
-	for _, served := range pm.served {
-		if pm.boundInternally(served.Port) {
+	for _, served := range append(append([]ServedPort{}, pm.served...), pm.servedUDP...) {
+		if served.Protocol == api.PortProtocol_tcp && pm.boundInternally(served.Port) {
 			continue
 		}
-		_, autoTunneled := pm.autoTunneled[served.Port]
+		if served.Protocol == api.PortProtocol_udp && !pm.isUDPServer(served.Port) {
+			continue
+		}
+		desc := &PortTunnelDescription{
+			LocalPort:  served.Port,
+			TargetPort: served.Port,
+			Visibility: api.TunnelVisiblity_host,
+			Protocol:   served.Protocol,
+		}
+		_, autoTunneled := pm.autoTunneled[desc.key()]
 		if !autoTunneled {
-			descs = append(descs, &PortTunnelDescription{
-				LocalPort:  served.Port,
-				TargetPort: served.Port,
-				Visibility: api.TunnelVisiblity_host,
-			})
+			descs = append(descs, desc)
 		}
 	}
 	autoTunneled, err := pm.T.Tunnel(ctx, &TunnelOptions{
@@ -504,9 +587,18 @@ func (pm *Manager) autoTunnel(ctx context.Context) {
 	if err != nil {
 		log.WithError(err).Error("cannot auto tunnel ports")
 	}
-	for _, localPort := range autoTunneled {
-		pm.autoTunneled[localPort] = struct{}{}
+	for _, desc := range autoTunneled {
+		pm.autoTunneled[desc.key()] = struct{}{}
+	}
+}
+
+// isUDPServer returns true if a UDP socket bound to port most likely belongs to a server, i.e. if the port
+// is configured or not in the ephemeral port range. Callers are expected to hold mu.
+func (pm *Manager) isUDPServer(port uint32) bool {
+	if _, _, configured := pm.configs.Get(port); configured {
+		return true
 	}
+	return port < pm.ephemeralPortRangeLo || pm.ephemeralPortRangeHi < port
 }
 
 func (pm *Manager) updateProxies() {
@@ -602,6 +694,11 @@ func (pm *Manager) boundInternally(port uint32) bool {
 	return exists
 }
 
+// IsInternal returns true if the port is reserved for supervisor's internal services, e.g. the IDE
+func (pm *Manager) IsInternal(port uint32) bool {
+	return pm.boundInternally(port)
+}
+
 // Expose exposes a port
 func (pm *Manager) Expose(ctx context.Context, port uint32, targetPort uint32) error {
 	unlock := true
@@ -649,20 +746,20 @@ func (pm *Manager) Expose(ctx context.Context, port uint32, targetPort uint32) e
 func (pm *Manager) Tunnel(ctx context.Context, desc *PortTunnelDescription) error {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
-	if pm.boundInternally(desc.LocalPort) {
+	if desc.Protocol == api.PortProtocol_tcp && pm.boundInternally(desc.LocalPort) {
 		return xerrors.New("cannot tunnel internal port")
 	}
 	tunneled, err := pm.T.Tunnel(ctx, &TunnelOptions{
 		SkipIfExists: false,
 	}, desc)
-	for _, localPort := range tunneled {
-		delete(pm.autoTunneled, localPort)
+	for _, desc := range tunneled {
+		delete(pm.autoTunneled, desc.key())
 	}
 	return err
 }
 
 // CloseTunnel closes the tunnel.
-func (pm *Manager) CloseTunnel(ctx context.Context, port uint32) error {
+func (pm *Manager) CloseTunnel(ctx context.Context, protocol api.PortProtocol, port uint32) error {
 	unlock := true
 	pm.mu.RLock()
 	defer func() {
@@ -670,7 +767,7 @@ func (pm *Manager) CloseTunnel(ctx context.Context, port uint32) error {
 			pm.mu.RUnlock()
 		}
 	}()
-	if pm.boundInternally(port) {
+	if protocol == api.PortProtocol_tcp && pm.boundInternally(port) {
 		return xerrors.New("cannot close internal port tunnel")
 	}
 	// we don't need the lock anymore. Let's unlock and make sure the defer doesn't try
@@ -678,13 +775,18 @@ func (pm *Manager) CloseTunnel(ctx context.Context, port uint32) error {
 	pm.mu.RUnlock()
 	unlock = false
 
-	_, err := pm.T.CloseTunnel(ctx, port)
+	_, err := pm.T.CloseTunnel(ctx, protocol, port)
 	return err
 }
 
 // EstablishTunnel actually establishes the tunnel
-func (pm *Manager) EstablishTunnel(ctx context.Context, clientID string, localPort uint32, targetPort uint32) (net.Conn, error) {
-	return pm.T.EstablishTunnel(ctx, clientID, localPort, targetPort)
+func (pm *Manager) EstablishTunnel(ctx context.Context, clientID string, protocol api.PortProtocol, localPort uint32, targetPort uint32) (net.Conn, error) {
+	return pm.T.EstablishTunnel(ctx, clientID, protocol, localPort, targetPort)
+}
+
+// ReverseTunnel forwards connections to a local port to a port offered by a client
+func (pm *Manager) ReverseTunnel(ctx context.Context, localPort uint32, targetPort uint32) (<-chan string, error) {
+	return pm.T.ReverseTunnel(ctx, localPort, targetPort)
 }
 
 // AutoTunnel controls enablement of auto tunneling
@@ -738,19 +840,25 @@ func (pm *Manager) Subscribe() (*Subscription, error) {
 // getStatus produces an API compatible port status list.
 // Callers are expected to hold mu.
 func (pm *Manager) getStatus() []*api.PortsStatus {
-	res := make([]*api.PortsStatus, 0, len(pm.state))
-	for port := range pm.state {
-		res = append(res, pm.getPortStatus(port))
+	res := make([]*api.PortsStatus, 0, len(pm.state)+len(pm.udpState))
+	for _, mp := range pm.state {
+		res = append(res, getPortStatus(mp))
+	}
+	for _, mp := range pm.udpState {
+		ps := getPortStatus(mp)
+		ps.Protocol = api.PortProtocol_udp
+		res = append(res, ps)
 	}
 	return res
 }
 
-func (pm *Manager) getPortStatus(port uint32) *api.PortsStatus {
-	mp := pm.state[port]
+func getPortStatus(mp *managedPort) *api.PortsStatus {
 	ps := &api.PortsStatus{
-		GlobalPort: mp.GlobalPort,
-		LocalPort:  mp.LocalhostPort,
-		Served:     mp.Served,
+		GlobalPort:  mp.GlobalPort,
+		LocalPort:   mp.LocalhostPort,
+		Served:      mp.Served,
+		Pid:         mp.PID,
+		ProcessName: mp.ProcessName,
 	}
 	if mp.Exposed && mp.URL != "" {
 		ps.Exposed = &api.ExposedPortInfo{
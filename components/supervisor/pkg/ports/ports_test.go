@@ -49,10 +49,10 @@ func TestPortsUpdateState(t *testing.T) {
 		{
 			Desc: "basic locally served",
 			Changes: []Change{
-				{Served: []ServedPort{{"0100007F", 8080, true}}},
+				{Served: []ServedPort{{Address: "0100007F", Port: 8080, BoundToLocalhost: true}}},
 				{Exposed: []ExposedPort{{LocalPort: 8080, GlobalPort: 60000, URL: "foobar"}}},
-				{Served: []ServedPort{{"0100007F", 8080, true}, {"00000000", 60000, false}}},
-				{Served: []ServedPort{{"00000000", 60000, false}}},
+				{Served: []ServedPort{{Address: "0100007F", Port: 8080, BoundToLocalhost: true}, {Address: "00000000", Port: 60000}}},
+				{Served: []ServedPort{{Address: "00000000", Port: 60000}}},
 				{Served: []ServedPort{}},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -68,7 +68,7 @@ func TestPortsUpdateState(t *testing.T) {
 		{
 			Desc: "basic globally served",
 			Changes: []Change{
-				{Served: []ServedPort{{"00000000", 8080, false}}},
+				{Served: []ServedPort{{Address: "00000000", Port: 8080}}},
 				{Served: []ServedPort{}},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -80,6 +80,53 @@ func TestPortsUpdateState(t *testing.T) {
 				{},
 			},
 		},
+		{
+			Desc: "served with process",
+			Changes: []Change{
+				{Served: []ServedPort{{Address: "00000000", Port: 3000, PID: 42, ProcessName: "node server.js"}}},
+				{Served: []ServedPort{{Address: "00000000", Port: 3000, PID: 43, ProcessName: "node server.js"}}},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 3000, GlobalPort: 3000},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{},
+				[]*api.PortsStatus{{LocalPort: 3000, GlobalPort: 3000, Served: true, Pid: 42, ProcessName: "node server.js"}},
+				[]*api.PortsStatus{{LocalPort: 3000, GlobalPort: 3000, Served: true, Pid: 43, ProcessName: "node server.js"}},
+			},
+		},
+		{
+			Desc: "udp served",
+			Changes: []Change{
+				{Served: []ServedPort{{Address: "00000000", Port: 8080}, {Address: "00000000", Port: 5353, Protocol: api.PortProtocol_udp}}},
+				{Served: []ServedPort{}},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 8080, GlobalPort: 8080},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{},
+				[]*api.PortsStatus{{LocalPort: 5353, Served: true, Protocol: api.PortProtocol_udp}, {LocalPort: 8080, GlobalPort: 8080, Served: true}},
+				{},
+			},
+		},
+		{
+			Desc: "udp served on ephemeral port",
+			Changes: []Change{
+				{Served: []ServedPort{{Address: "00000000", Port: 5353, Protocol: api.PortProtocol_udp}, {Address: "00000000", Port: 45000, Protocol: api.PortProtocol_udp}}},
+				{Config: &ConfigChange{workspace: []*gitpod.PortConfig{
+					{Port: 45000},
+				}}},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 45000, GlobalPort: 45000},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{},
+				[]*api.PortsStatus{{LocalPort: 5353, Served: true, Protocol: api.PortProtocol_udp}},
+				[]*api.PortsStatus{{LocalPort: 5353, Served: true, Protocol: api.PortProtocol_udp}, {LocalPort: 45000, GlobalPort: 45000}, {LocalPort: 45000, Served: true, Protocol: api.PortProtocol_udp}},
+			},
+		},
 		{
 			Desc: "basic port publically exposed",
 			Changes: []Change{
@@ -99,7 +146,7 @@ func TestPortsUpdateState(t *testing.T) {
 			InternalPorts: []uint32{8080},
 			Changes: []Change{
 				{Served: []ServedPort{}},
-				{Served: []ServedPort{{"00000000", 8080, false}}},
+				{Served: []ServedPort{{Address: "00000000", Port: 8080}}},
 			},
 
 			ExpectedExposure: ExposureExpectation(nil),
@@ -122,8 +169,8 @@ func TestPortsUpdateState(t *testing.T) {
 				},
 				{
 					Served: []ServedPort{
-						{"00000000", 8080, false},
-						{"0100007F", 9229, true},
+						{Address: "00000000", Port: 8080},
+						{Address: "0100007F", Port: 9229, BoundToLocalhost: true},
 					},
 				},
 			},
@@ -154,9 +201,9 @@ func TestPortsUpdateState(t *testing.T) {
 						Port:   "4000-5000",
 					}},
 				}},
-				{Served: []ServedPort{{"0100007F", 4040, true}}},
+				{Served: []ServedPort{{Address: "0100007F", Port: 4040, BoundToLocalhost: true}}},
 				{Exposed: []ExposedPort{{LocalPort: 4040, GlobalPort: 60000, Public: true, URL: "4040-foobar"}}},
-				{Served: []ServedPort{{"0100007F", 4040, true}, {"00000000", 60000, false}}},
+				{Served: []ServedPort{{Address: "0100007F", Port: 4040, BoundToLocalhost: true}, {Address: "00000000", Port: 60000}}},
 			},
 			ExpectedExposure: []ExposedPort{
 				{LocalPort: 4040, GlobalPort: 60000},
@@ -184,22 +231,22 @@ func TestPortsUpdateState(t *testing.T) {
 					Exposed: []ExposedPort{{LocalPort: 8080, GlobalPort: 8080, Public: true, URL: "foobar"}},
 				},
 				{
-					Served: []ServedPort{{"0100007F", 8080, true}},
+					Served: []ServedPort{{Address: "0100007F", Port: 8080, BoundToLocalhost: true}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 8080, GlobalPort: 60000, Public: true, URL: "foobar"}},
 				},
 				{
-					Served: []ServedPort{{"0100007F", 8080, true}, {"00000000", 60000, false}},
+					Served: []ServedPort{{Address: "0100007F", Port: 8080, BoundToLocalhost: true}, {Address: "00000000", Port: 60000}},
 				},
 				{
-					Served: []ServedPort{{"00000000", 60000, false}},
+					Served: []ServedPort{{Address: "00000000", Port: 60000}},
 				},
 				{
 					Served: []ServedPort{},
 				},
 				{
-					Served: []ServedPort{{"0100007F", 8080, false}},
+					Served: []ServedPort{{Address: "0100007F", Port: 8080}},
 				},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -221,7 +268,7 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "starting multiple proxies for the same served event",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{"0100007F", 8080, true}, {"00000000", 3000, true}},
+					Served: []ServedPort{{Address: "0100007F", Port: 8080, BoundToLocalhost: true}, {Address: "00000000", Port: 3000, BoundToLocalhost: true}},
 				},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -245,7 +292,7 @@ func TestPortsUpdateState(t *testing.T) {
 					}},
 				},
 				{
-					Served: []ServedPort{{"00000000", 8080, false}},
+					Served: []ServedPort{{Address: "00000000", Port: 8080}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 8080, GlobalPort: 8080, Public: false, URL: "foobar"}},
@@ -265,13 +312,13 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served locally and then globally too, prefer globally (exposed in between)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{"0100007F", 5900, true}},
+					Served: []ServedPort{{Address: "0100007F", Port: 5900, BoundToLocalhost: true}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, GlobalPort: 60000, URL: "foobar"}},
 				},
 				{
-					Served: []ServedPort{{"0100007F", 5900, true}, {"00000000", 5900, false}},
+					Served: []ServedPort{{Address: "0100007F", Port: 5900, BoundToLocalhost: true}, {Address: "00000000", Port: 5900}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, GlobalPort: 5900, URL: "foobar"}},
@@ -292,10 +339,10 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served locally and then globally too, prefer globally (exposed after)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{"0100007F", 5900, true}},
+					Served: []ServedPort{{Address: "0100007F", Port: 5900, BoundToLocalhost: true}},
 				},
 				{
-					Served: []ServedPort{{"0100007F", 5900, true}, {"00000000", 5900, false}},
+					Served: []ServedPort{{Address: "0100007F", Port: 5900, BoundToLocalhost: true}, {Address: "00000000", Port: 5900}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, GlobalPort: 60000, URL: "foobar"}},
@@ -319,13 +366,13 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served globally and then locally too, prefer globally (exposed in between)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{"00000000", 5900, false}},
+					Served: []ServedPort{{Address: "00000000", Port: 5900}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, GlobalPort: 5900, URL: "foobar"}},
 				},
 				{
-					Served: []ServedPort{{"00000000", 5900, false}, {"0100007F", 5900, true}},
+					Served: []ServedPort{{Address: "00000000", Port: 5900}, {Address: "0100007F", Port: 5900, BoundToLocalhost: true}},
 				},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -341,10 +388,10 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served globally and then locally too, prefer globally (exposed after)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{"00000000", 5900, false}},
+					Served: []ServedPort{{Address: "00000000", Port: 5900}},
 				},
 				{
-					Served: []ServedPort{{"00000000", 5900, false}, {"0100007F", 5900, true}},
+					Served: []ServedPort{{Address: "00000000", Port: 5900}, {Address: "0100007F", Port: 5900, BoundToLocalhost: true}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, GlobalPort: 5900, URL: "foobar"}},
@@ -363,13 +410,13 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served locally on ip4 and then locally on ip6 too, prefer first (exposed in between)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{"0100007F", 5900, true}},
+					Served: []ServedPort{{Address: "0100007F", Port: 5900, BoundToLocalhost: true}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, GlobalPort: 60000, URL: "foobar"}},
 				},
 				{
-					Served: []ServedPort{{"0100007F", 5900, true}, {"00000000000000000000010000000000", 5900, true}},
+					Served: []ServedPort{{Address: "0100007F", Port: 5900, BoundToLocalhost: true}, {Address: "00000000000000000000010000000000", Port: 5900, BoundToLocalhost: true}},
 				},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -385,10 +432,10 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served locally on ip4 and then locally on ip6 too, prefer first (exposed after)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{"0100007F", 5900, true}},
+					Served: []ServedPort{{Address: "0100007F", Port: 5900, BoundToLocalhost: true}},
 				},
 				{
-					Served: []ServedPort{{"0100007F", 5900, true}, {"00000000000000000000010000000000", 5900, true}},
+					Served: []ServedPort{{Address: "0100007F", Port: 5900, BoundToLocalhost: true}, {Address: "00000000000000000000010000000000", Port: 5900, BoundToLocalhost: true}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, GlobalPort: 60000, URL: "foobar"}},
@@ -407,13 +454,13 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served locally on ip4 and then globally on ip6 too, prefer first (exposed in between)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{"00000000", 5900, false}},
+					Served: []ServedPort{{Address: "00000000", Port: 5900}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, GlobalPort: 5900, URL: "foobar"}},
 				},
 				{
-					Served: []ServedPort{{"00000000", 5900, false}, {"00000000000000000000000000000000", 5900, false}},
+					Served: []ServedPort{{Address: "00000000", Port: 5900}, {Address: "00000000000000000000000000000000", Port: 5900}},
 				},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -429,10 +476,10 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served locally on ip4 and then globally on ip6 too, prefer first (exposed after)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{"00000000", 5900, false}},
+					Served: []ServedPort{{Address: "00000000", Port: 5900}},
 				},
 				{
-					Served: []ServedPort{{"00000000", 5900, false}, {"00000000000000000000000000000000", 5900, false}},
+					Served: []ServedPort{{Address: "00000000", Port: 5900}, {Address: "00000000000000000000000000000000", Port: 5900}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, GlobalPort: 5900, URL: "foobar"}},
@@ -477,6 +524,7 @@ func TestPortsUpdateState(t *testing.T) {
 			pm.proxyStarter = func(localPort uint32, globalPort uint32) (io.Closer, error) {
 				return io.NopCloser(nil), nil
 			}
+			pm.ephemeralPortRangeLo, pm.ephemeralPortRangeHi = defaultEphemeralPortRangeLo, defaultEphemeralPortRangeHi
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
@@ -535,8 +583,10 @@ func TestPortsUpdateState(t *testing.T) {
 			wg.Wait()
 
 			var (
-				sorPorts         = cmpopts.SortSlices(func(x, y uint32) bool { return x < y })
-				sortPortStatus   = cmpopts.SortSlices(func(x, y *api.PortsStatus) bool { return x.LocalPort < y.LocalPort })
+				sorPorts       = cmpopts.SortSlices(func(x, y uint32) bool { return x < y })
+				sortPortStatus = cmpopts.SortSlices(func(x, y *api.PortsStatus) bool {
+					return x.LocalPort < y.LocalPort || (x.LocalPort == y.LocalPort && x.Protocol < y.Protocol)
+				})
 				sortExposed      = cmpopts.SortSlices(func(x, y ExposedPort) bool { return x.LocalPort < y.LocalPort })
 				ignoreUnexported = cmpopts.IgnoreUnexported(
 					api.PortsStatus{},
@@ -562,13 +612,16 @@ type testTunneledPorts struct {
 func (tep *testTunneledPorts) Observe(ctx context.Context) (<-chan []PortTunnelState, <-chan error) {
 	return tep.Changes, tep.Error
 }
-func (tep *testTunneledPorts) Tunnel(ctx context.Context, options *TunnelOptions, descs ...*PortTunnelDescription) ([]uint32, error) {
+func (tep *testTunneledPorts) Tunnel(ctx context.Context, options *TunnelOptions, descs ...*PortTunnelDescription) ([]*PortTunnelDescription, error) {
+	return nil, nil
+}
+func (tep *testTunneledPorts) CloseTunnel(ctx context.Context, protocol api.PortProtocol, localPorts ...uint32) ([]uint32, error) {
 	return nil, nil
 }
-func (tep *testTunneledPorts) CloseTunnel(ctx context.Context, localPorts ...uint32) ([]uint32, error) {
+func (tep *testTunneledPorts) EstablishTunnel(ctx context.Context, clientID string, protocol api.PortProtocol, localPort uint32, targetPort uint32) (net.Conn, error) {
 	return nil, nil
 }
-func (tep *testTunneledPorts) EstablishTunnel(ctx context.Context, clientID string, localPort uint32, targetPort uint32) (net.Conn, error) {
+func (tep *testTunneledPorts) ReverseTunnel(ctx context.Context, localPort uint32, targetPort uint32) (<-chan string, error) {
 	return nil, nil
 }
 
@@ -0,0 +1,130 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const procDir = "/proc"
+
+type processInfo struct {
+	PID  uint32
+	Name string
+}
+
+// socketOwners finds the processes serving ports by the inodes of their sockets
+type socketOwners struct {
+	procDir string
+	known   map[uint64]processInfo
+}
+
+func newSocketOwners(procDir string) *socketOwners {
+	return &socketOwners{
+		procDir: procDir,
+		known:   make(map[uint64]processInfo),
+	}
+}
+
+// Resolve returns the served ports of the sockets including their process.
+// Only sockets which were not seen before are looked up, since that requires
+// walking the file descriptors of all processes.
+func (o *socketOwners) Resolve(sockets []servedSocket) []ServedPort {
+	unknown := make(map[uint64]struct{})
+	for _, socket := range sockets {
+		if _, exists := o.known[socket.Inode]; !exists {
+			unknown[socket.Inode] = struct{}{}
+		}
+	}
+	if len(unknown) > 0 {
+		found := o.find(unknown)
+		for inode := range unknown {
+			// sockets of processes we cannot see remain unknown until they are closed
+			o.known[inode] = found[inode]
+		}
+	}
+
+	var (
+		ports  = make([]ServedPort, 0, len(sockets))
+		inodes = make(map[uint64]struct{}, len(sockets))
+	)
+	for _, socket := range sockets {
+		port := socket.ServedPort
+		process := o.known[socket.Inode]
+		port.PID = process.PID
+		port.ProcessName = process.Name
+		ports = append(ports, port)
+		inodes[socket.Inode] = struct{}{}
+	}
+	for inode := range o.known {
+		if _, exists := inodes[inode]; !exists {
+			delete(o.known, inode)
+		}
+	}
+	return ports
+}
+
+func (o *socketOwners) find(inodes map[uint64]struct{}) map[uint64]processInfo {
+	res := make(map[uint64]processInfo)
+	procs, err := os.ReadDir(o.procDir)
+	if err != nil {
+		return res
+	}
+	for _, proc := range procs {
+		pid, err := strconv.ParseUint(proc.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join(o.procDir, proc.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			// the process is gone or we are not allowed to look at it
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			inode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]"), 10, 64)
+			if err != nil {
+				continue
+			}
+			if _, wanted := inodes[inode]; !wanted {
+				continue
+			}
+			if owner, found := res[inode]; found && owner.PID < uint32(pid) {
+				// sockets shared by a parent and its children are attributed to the parent, i.e. usually the lower PID
+				continue
+			}
+			res[inode] = processInfo{
+				PID:  uint32(pid),
+				Name: o.processName(proc.Name()),
+			}
+		}
+	}
+	return res
+}
+
+// processName returns the command line of a process, e.g. "node server.js"
+func (o *socketOwners) processName(pid string) string {
+	cmdline, err := os.ReadFile(filepath.Join(o.procDir, pid, "cmdline"))
+	if err == nil {
+		name := string(bytes.TrimSpace(bytes.ReplaceAll(bytes.TrimRight(cmdline, "\x00"), []byte{0}, []byte{' '})))
+		if name != "" {
+			return name
+		}
+	}
+	// kernel threads and zombies have no command line
+	comm, err := os.ReadFile(filepath.Join(o.procDir, pid, "comm"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(comm))
+}
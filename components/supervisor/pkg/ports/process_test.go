@@ -0,0 +1,57 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSocketOwners(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	f, err := l.(*net.TCPListener).File()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	inode := stat.Sys().(*syscall.Stat_t).Ino
+
+	cmdline, err := os.ReadFile(filepath.Join(procDir, "self", "cmdline"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := (&socketOwners{procDir: procDir}).processName("self")
+	if len(name) != len(cmdline)-1 {
+		t.Errorf("unexpected process name %q for command line %q", name, cmdline)
+	}
+
+	port := ServedPort{Address: "0100007F", Port: uint32(l.Addr().(*net.TCPAddr).Port), BoundToLocalhost: true}
+	owners := newSocketOwners(procDir)
+	act := owners.Resolve([]servedSocket{{ServedPort: port, Inode: inode}})
+	exp := port
+	exp.PID = uint32(os.Getpid())
+	exp.ProcessName = name
+	if diff := cmp.Diff([]ServedPort{exp}, act); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+
+	act = owners.Resolve(nil)
+	if len(act) != 0 || len(owners.known) != 0 {
+		t.Errorf("closed sockets are still known: %v", owners.known)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// ReverseTunnelDialer connects to the ports a client offers for reverse tunnels.
+// Each client connection has its own dialer, which must be comparable.
+type ReverseTunnelDialer interface {
+	// DialReverse opens a connection to the target port of the client for a connection to the local port.
+	DialReverse(localPort uint32, targetPort uint32) (io.ReadWriteCloser, error)
+}
+
+type ReverseTunnelDescription struct {
+	LocalPort  uint32
+	TargetPort uint32
+	// ClientID is the client connections are forwarded to, empty if no client offers the target port
+	ClientID string
+}
+
+type reverseTunnel struct {
+	Desc  ReverseTunnelDescription
+	Conns map[io.Closer]struct{}
+
+	// dialer identifies the client connections are forwarded to
+	dialer ReverseTunnelDialer
+}
+
+type reverseTunnelClient struct {
+	// ID is the ID the client reports. Clients choose it themselves, hence it's for display only.
+	ID     string
+	Ports  map[uint32]struct{}
+	Dialer ReverseTunnelDialer
+}
+
+// OfferPorts makes local ports of a client available for reverse tunnels. Offers are keyed by the dialer
+// of the client connection: each offer replaces the previous one made on the same connection,
+// i.e. offering no ports withdraws the offer.
+func (p *TunneledPortsService) OfferPorts(dialer ReverseTunnelDialer, clientID string, ports ...uint32) {
+	p.cond.L.Lock()
+	defer p.cond.L.Unlock()
+
+	if len(ports) == 0 {
+		delete(p.reverseClients, dialer)
+	} else {
+		client := &reverseTunnelClient{
+			ID:     clientID,
+			Ports:  make(map[uint32]struct{}, len(ports)),
+			Dialer: dialer,
+		}
+		for _, port := range ports {
+			client.Ports[port] = struct{}{}
+		}
+		p.reverseClients[dialer] = client
+	}
+	p.cond.Broadcast()
+}
+
+// ReverseTunnel listens on a local port and forwards incoming connections to a port offered by a client.
+func (p *TunneledPortsService) ReverseTunnel(ctx context.Context, localPort uint32, targetPort uint32) (<-chan string, error) {
+	if localPort <= 0 || localPort > 0xFFFF {
+		return nil, xerrors.Errorf("bad local port: %d", localPort)
+	}
+	if targetPort <= 0 || targetPort > 0xFFFF {
+		return nil, xerrors.Errorf("bad target port: %d", targetPort)
+	}
+
+	p.cond.L.Lock()
+	defer p.cond.L.Unlock()
+	if _, exists := p.reverseTunnels[localPort]; exists {
+		return nil, xerrors.Errorf("port %d is already reverse tunneled", localPort)
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort("localhost", strconv.FormatInt(int64(localPort), 10)))
+	if err != nil {
+		return nil, err
+	}
+	tunnel := &reverseTunnel{
+		Desc: ReverseTunnelDescription{
+			LocalPort:  localPort,
+			TargetPort: targetPort,
+		},
+		Conns: make(map[io.Closer]struct{}),
+	}
+	p.reverseTunnels[localPort] = tunnel
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+
+		p.cond.L.Lock()
+		delete(p.reverseTunnels, localPort)
+		conns := tunnel.Conns
+		tunnel.Conns = nil
+		p.cond.Broadcast()
+		p.cond.L.Unlock()
+
+		for conn := range conns {
+			conn.Close()
+		}
+	}()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				log.WithError(err).WithField("port", localPort).Warn("reverse tunnel: failed to accept connection")
+				continue
+			}
+			go p.forwardReverse(tunnel, conn)
+		}
+	}()
+
+	updates := make(chan string)
+	go func() {
+		defer close(updates)
+
+		p.cond.L.Lock()
+		defer p.cond.L.Unlock()
+		var (
+			notified bool
+			dialer   ReverseTunnelDialer
+			clientID string
+		)
+		for ctx.Err() == nil {
+			p.reverseTunnelClient(tunnel)
+			if notified && dialer == tunnel.dialer && clientID == tunnel.Desc.ClientID {
+				p.cond.Wait()
+				continue
+			}
+			notified = true
+			dialer = tunnel.dialer
+			clientID = tunnel.Desc.ClientID
+
+			p.cond.L.Unlock()
+			select {
+			case updates <- clientID:
+			case <-ctx.Done():
+			}
+			p.cond.L.Lock()
+		}
+	}()
+	return updates, nil
+}
+
+// reverseTunnelClient selects the client connections of a reverse tunnel are forwarded to.
+// It sticks to the current client as long as it offers the target port. Must be called with the lock held.
+func (p *TunneledPortsService) reverseTunnelClient(tunnel *reverseTunnel) *reverseTunnelClient {
+	if client, exists := p.reverseClients[tunnel.dialer]; exists && tunnel.dialer != nil {
+		if _, offered := client.Ports[tunnel.Desc.TargetPort]; offered {
+			tunnel.Desc.ClientID = client.ID
+			return client
+		}
+	}
+
+	clients := make([]*reverseTunnelClient, 0, len(p.reverseClients))
+	for _, client := range p.reverseClients {
+		clients = append(clients, client)
+	}
+	sort.SliceStable(clients, func(i, j int) bool { return clients[i].ID < clients[j].ID })
+	for _, client := range clients {
+		if _, offered := client.Ports[tunnel.Desc.TargetPort]; offered {
+			tunnel.dialer = client.Dialer
+			tunnel.Desc.ClientID = client.ID
+			return client
+		}
+	}
+	tunnel.dialer = nil
+	tunnel.Desc.ClientID = ""
+	return nil
+}
+
+func (p *TunneledPortsService) forwardReverse(tunnel *reverseTunnel, conn net.Conn) {
+	defer conn.Close()
+
+	p.cond.L.Lock()
+	client := p.reverseTunnelClient(tunnel)
+	if client == nil || tunnel.Conns == nil {
+		p.cond.L.Unlock()
+		log.WithField("port", tunnel.Desc.LocalPort).WithField("targetPort", tunnel.Desc.TargetPort).Warn("reverse tunnel: no client offers the target port")
+		return
+	}
+	tunnel.Conns[conn] = struct{}{}
+	p.cond.L.Unlock()
+	defer func() {
+		p.cond.L.Lock()
+		delete(tunnel.Conns, conn)
+		p.cond.L.Unlock()
+	}()
+
+	remote, err := client.Dialer.DialReverse(tunnel.Desc.LocalPort, tunnel.Desc.TargetPort)
+	if err != nil {
+		log.WithError(err).WithField("port", tunnel.Desc.LocalPort).WithField("targetPort", tunnel.Desc.TargetPort).Warn("reverse tunnel: failed to dial client")
+		return
+	}
+	defer remote.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(remote, conn)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(conn, remote)
+		done <- struct{}{}
+	}()
+	<-done
+}
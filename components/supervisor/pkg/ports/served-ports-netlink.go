@@ -0,0 +1,351 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+const (
+	// eventDumpInterval is the interval of dumps while processes start or exit
+	eventDumpInterval = 100 * time.Millisecond
+	// eventDumpDuration is how long we keep dumping after a process event,
+	// since services start serving some time after they were started
+	eventDumpDuration = 5 * time.Second
+)
+
+// NetlinkServedPortsObserver observes the served ports by dumping the listening sockets using sock_diag netlink
+// requests. Dumps are triggered by process events, such that ports show up right after a service started
+// serving. If sock_diag is not available, the Fallback observer is used instead.
+type NetlinkServedPortsObserver struct {
+	// RefreshInterval is the interval of dumps which are not triggered by process events.
+	// Those catch services which start serving long after their process started, and
+	// provide updates if process events are not available, e.g. outside of the initial user namespace.
+	RefreshInterval time.Duration
+
+	// Fallback observes the served ports if sock_diag is not available
+	Fallback ServedPortsObserver
+}
+
+// Observe starts observing the served ports until the context is canceled.
+func (o *NetlinkServedPortsObserver) Observe(ctx context.Context) (<-chan []ServedPort, <-chan error) {
+	diag, err := newSockDiag()
+	if err != nil {
+		log.WithError(err).Warn("sock_diag is not available, polling served ports")
+		return o.Fallback.Observe(ctx)
+	}
+	events, err := listenProcEvents(ctx)
+	if err != nil {
+		log.WithError(err).Info("process events are not available, dumping served ports in intervals only")
+	}
+
+	var (
+		errchan = make(chan error, 1)
+		reschan = make(chan []ServedPort)
+		ticker  = time.NewTicker(eventDumpInterval)
+		owners  = newSocketOwners(procDir)
+	)
+	go func() {
+		defer close(errchan)
+		defer close(reschan)
+		defer ticker.Stop()
+		defer diag.Close()
+
+		var (
+			lastDump   time.Time
+			burstUntil time.Time
+			current    []ServedPort
+		)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-events:
+				if !ok {
+					events = nil
+					continue
+				}
+				burstUntil = time.Now().Add(eventDumpDuration)
+				continue
+			case now := <-ticker.C:
+				if now.After(burstUntil) && now.Sub(lastDump) < o.RefreshInterval {
+					continue
+				}
+				lastDump = now
+			}
+
+			sockets, err := diag.ServedSockets()
+			if err != nil {
+				errchan <- err
+				continue
+			}
+			ports := owners.Resolve(sockets)
+			if reflect.DeepEqual(current, ports) {
+				continue
+			}
+			current = ports
+			reschan <- ports
+		}
+	}()
+
+	return reschan, errchan
+}
+
+// nativeEndian is the byte order of netlink messages. Supervisor runs on amd64 only.
+var nativeEndian = binary.LittleEndian
+
+const (
+	nlmsgHeaderSize = 16
+
+	// see linux/sock_diag.h and linux/inet_diag.h
+	sockDiagByFamily = 20
+	inetDiagReqSize  = 56
+	inetDiagMsgSize  = 72
+
+	// TCP states as used by the kernel, see stateListen and stateUnconnected
+	tcpClose  = 7
+	tcpListen = 10
+)
+
+// sockDiag dumps sockets using the sock_diag netlink interface, see sock_diag(7)
+type sockDiag struct {
+	fd  int
+	seq uint32
+	buf []byte
+}
+
+func newSockDiag() (*sockDiag, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.NETLINK_SOCK_DIAG)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot open sock_diag socket: %w", err)
+	}
+	err = unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK})
+	if err != nil {
+		unix.Close(fd)
+		return nil, xerrors.Errorf("cannot bind sock_diag socket: %w", err)
+	}
+	d := &sockDiag{
+		fd:  fd,
+		buf: make([]byte, 32*1024),
+	}
+	// the kernel might not support inet_diag for all protocols
+	_, err = d.ServedSockets()
+	if err != nil {
+		d.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// ServedSockets returns listening TCP and unconnected UDP sockets
+func (d *sockDiag) ServedSockets() ([]servedSocket, error) {
+	var res []servedSocket
+	for _, family := range []uint8{unix.AF_INET, unix.AF_INET6} {
+		for _, q := range []struct {
+			Proto    uint8
+			State    uint8
+			Protocol api.PortProtocol
+		}{
+			{Proto: unix.IPPROTO_TCP, State: tcpListen, Protocol: api.PortProtocol_tcp},
+			{Proto: unix.IPPROTO_UDP, State: tcpClose, Protocol: api.PortProtocol_udp},
+		} {
+			sockets, err := d.dump(family, q.Proto, q.State, q.Protocol)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot dump %s sockets: %w", q.Protocol, err)
+			}
+			res = append(res, sockets...)
+		}
+	}
+
+	visited := make(map[string]struct{}, len(res))
+	sockets := res[:0]
+	for _, socket := range res {
+		key := fmt.Sprintf("%s:%d/%s", socket.Address, socket.Port, socket.Protocol)
+		if _, exists := visited[key]; exists {
+			continue
+		}
+		visited[key] = struct{}{}
+		sockets = append(sockets, socket)
+	}
+	// the order of dumps depends on kernel hash tables
+	sort.Slice(sockets, func(i, j int) bool {
+		if sockets[i].Protocol != sockets[j].Protocol {
+			return sockets[i].Protocol < sockets[j].Protocol
+		}
+		if sockets[i].Port != sockets[j].Port {
+			return sockets[i].Port < sockets[j].Port
+		}
+		return sockets[i].Address < sockets[j].Address
+	})
+	return sockets, nil
+}
+
+func (d *sockDiag) dump(family uint8, proto uint8, state uint8, protocol api.PortProtocol) ([]servedSocket, error) {
+	d.seq++
+	req := make([]byte, nlmsgHeaderSize+inetDiagReqSize)
+	nativeEndian.PutUint32(req[0:], uint32(len(req)))
+	nativeEndian.PutUint16(req[4:], sockDiagByFamily)
+	nativeEndian.PutUint16(req[6:], unix.NLM_F_REQUEST|unix.NLM_F_DUMP)
+	nativeEndian.PutUint32(req[8:], d.seq)
+	// struct inet_diag_req_v2
+	req[16] = family
+	req[17] = proto
+	nativeEndian.PutUint32(req[20:], 1<<state)
+
+	err := unix.Sendto(d.fd, req, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK})
+	if err != nil {
+		return nil, err
+	}
+
+	var res []servedSocket
+	for {
+		n, _, err := unix.Recvfrom(d.fd, d.buf, 0)
+		if err != nil {
+			return nil, err
+		}
+		msgs, err := syscall.ParseNetlinkMessage(d.buf[:n])
+		if err != nil {
+			return nil, err
+		}
+		for _, msg := range msgs {
+			if msg.Header.Seq != d.seq {
+				continue
+			}
+			switch msg.Header.Type {
+			case unix.NLMSG_DONE:
+				return res, nil
+			case unix.NLMSG_ERROR:
+				if len(msg.Data) < 4 {
+					return nil, xerrors.Errorf("invalid netlink error")
+				}
+				return nil, syscall.Errno(-int32(nativeEndian.Uint32(msg.Data)))
+			}
+			socket, ok := parseInetDiagMsg(msg.Data, protocol)
+			if ok {
+				res = append(res, socket)
+			}
+		}
+	}
+}
+
+// parseInetDiagMsg parses a struct inet_diag_msg. The address has the same format as in /proc/net/* files.
+func parseInetDiagMsg(data []byte, protocol api.PortProtocol) (socket servedSocket, ok bool) {
+	if len(data) < inetDiagMsgSize {
+		return socket, false
+	}
+	var (
+		family = data[0]
+		port   = binary.BigEndian.Uint16(data[4:6])
+		src    = data[8:24]
+		inode  = nativeEndian.Uint32(data[68:72])
+	)
+	if family == unix.AF_INET {
+		src = src[:4]
+	}
+	var addr string
+	for i := 0; i < len(src); i += 4 {
+		addr += fmt.Sprintf("%08X", nativeEndian.Uint32(src[i:i+4]))
+	}
+	globallyBound := addr == "00000000" || addr == "00000000000000000000000000000000"
+	return servedSocket{
+		ServedPort: ServedPort{
+			Address:          addr,
+			Port:             uint32(port),
+			BoundToLocalhost: !globallyBound,
+			Protocol:         protocol,
+		},
+		Inode: uint64(inode),
+	}, true
+}
+
+func (d *sockDiag) Close() error {
+	return unix.Close(d.fd)
+}
+
+const (
+	// see linux/connector.h and linux/cn_proc.h
+	cnIdxProc          = 1
+	cnValProc          = 1
+	cnMsgSize          = 20
+	procCnMcastListen  = 1
+	procEventWhatIndex = nlmsgHeaderSize + cnMsgSize
+	procEventExec      = 0x00000002
+	procEventExit      = 0x80000000
+)
+
+// listenProcEvents notifies about processes which start or exit using the proc connector.
+// The returned channel is closed once the context is canceled or reading events fails.
+func listenProcEvents(ctx context.Context) (<-chan struct{}, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC|unix.SOCK_NONBLOCK, unix.NETLINK_CONNECTOR)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot open connector socket: %w", err)
+	}
+	err = unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: cnIdxProc})
+	if err != nil {
+		unix.Close(fd)
+		return nil, xerrors.Errorf("cannot bind connector socket: %w", err)
+	}
+
+	req := make([]byte, nlmsgHeaderSize+cnMsgSize+4)
+	nativeEndian.PutUint32(req[0:], uint32(len(req)))
+	nativeEndian.PutUint16(req[4:], unix.NLMSG_DONE)
+	// struct cn_msg
+	nativeEndian.PutUint32(req[16:], cnIdxProc)
+	nativeEndian.PutUint32(req[20:], cnValProc)
+	nativeEndian.PutUint16(req[32:], 4)
+	nativeEndian.PutUint32(req[36:], procCnMcastListen)
+	err = unix.Sendto(fd, req, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK})
+	if err != nil {
+		unix.Close(fd)
+		return nil, xerrors.Errorf("cannot subscribe to process events: %w", err)
+	}
+
+	// a non-blocking file uses the runtime poller, hence closing it interrupts pending reads
+	f := os.NewFile(uintptr(fd), "proc-connector")
+	go func() {
+		<-ctx.Done()
+		f.Close()
+	}()
+
+	events := make(chan struct{}, 1)
+	go func() {
+		defer close(events)
+		buf := make([]byte, os.Getpagesize())
+		for {
+			n, err := f.Read(buf)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.WithError(err).Warn("cannot read process events")
+				}
+				return
+			}
+			if n < procEventWhatIndex+4 {
+				continue
+			}
+			what := nativeEndian.Uint32(buf[procEventWhatIndex:])
+			if what != procEventExec && what != procEventExit {
+				continue
+			}
+			select {
+			case events <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return events, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+func TestNetlinkServedPortsObserver(t *testing.T) {
+	diag, err := newSockDiag()
+	if err != nil {
+		t.Skipf("sock_diag is not available: %v", err)
+	}
+	diag.Close()
+
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tcp.Close()
+	udp, err := net.ListenPacket("udp4", "0.0.0.0:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer udp.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	obs := &NetlinkServedPortsObserver{
+		RefreshInterval: 100 * time.Millisecond,
+		Fallback:        &PollingServedPortsObserver{RefreshInterval: 100 * time.Millisecond},
+	}
+	updates, errs := obs.Observe(ctx)
+	go func() {
+		for range errs {
+		}
+	}()
+
+	expectations := map[ServedPort]bool{
+		{Address: "0100007F", Port: uint32(tcp.Addr().(*net.TCPAddr).Port), BoundToLocalhost: true, Protocol: api.PortProtocol_tcp, PID: uint32(os.Getpid())}: false,
+		{Address: "00000000", Port: uint32(udp.LocalAddr().(*net.UDPAddr).Port), Protocol: api.PortProtocol_udp, PID: uint32(os.Getpid())}:                    false,
+	}
+	for ports := range updates {
+		for _, port := range ports {
+			port.ProcessName = ""
+			if _, expected := expectations[port]; expected {
+				expectations[port] = true
+			}
+		}
+		var missing bool
+		for _, found := range expectations {
+			missing = missing || !found
+		}
+		if !missing {
+			return
+		}
+	}
+	t.Errorf("served ports were not observed: %v", expectations)
+}
+
+func TestParseInetDiagMsg(t *testing.T) {
+	msg := make([]byte, inetDiagMsgSize)
+	msg[0] = 10 // AF_INET6
+	msg[4], msg[5] = 0x17, 0x70
+	copy(msg[8:24], net.ParseIP("::1"))
+	nativeEndian.PutUint32(msg[68:], 4242)
+
+	socket, ok := parseInetDiagMsg(msg, api.PortProtocol_tcp)
+	if !ok {
+		t.Fatal("cannot parse message")
+	}
+	// the same representation as in /proc/net/tcp6
+	exp := servedSocket{
+		ServedPort: ServedPort{Address: "00000000000000000000000001000000", Port: 6000, BoundToLocalhost: true},
+		Inode:      4242,
+	}
+	if socket != exp {
+		t.Errorf("unexpected socket: %+v, expected %+v", socket, exp)
+	}
+
+	_, ok = parseInetDiagMsg(msg[:10], api.PortProtocol_tcp)
+	if ok {
+		t.Error("parsed a truncated message")
+	}
+}
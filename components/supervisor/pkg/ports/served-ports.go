@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
 )
 
 // ServedPort describes a port served by a local service
@@ -22,6 +23,17 @@ type ServedPort struct {
 	Address          string
 	Port             uint32
 	BoundToLocalhost bool
+	Protocol         api.PortProtocol
+
+	// PID and ProcessName describe the process serving the port, if known
+	PID         uint32
+	ProcessName string
+}
+
+// servedSocket is a served port and the inode of its socket
+type servedSocket struct {
+	ServedPort
+	Inode uint64
 }
 
 // ServedPortsObserver observes the locally served ports and provides
@@ -40,13 +52,68 @@ const (
 
 	fnNetTCP  = "/proc/net/tcp"
 	fnNetTCP6 = "/proc/net/tcp6"
+	fnNetUDP  = "/proc/net/udp"
+	fnNetUDP6 = "/proc/net/udp6"
+
+	// stateListen is the state of listening TCP sockets (TCP_LISTEN)
+	stateListen = "0A"
+	// stateUnconnected is the state of UDP sockets which receive from any peer (TCP_CLOSE)
+	stateUnconnected = "07"
+
+	fnEphemeralPortRange = "/proc/sys/net/ipv4/ip_local_port_range"
+	// defaultEphemeralPortRange{Lo,Hi} is Linux' default ephemeral port range
+	defaultEphemeralPortRangeLo uint32 = 32768
+	defaultEphemeralPortRangeHi uint32 = 60999
 )
 
+// readEphemeralPortRange returns the port range the kernel picks ports from for sockets which are not bound explicitly
+func readEphemeralPortRange() (lo, hi uint32) {
+	fc, err := os.ReadFile(fnEphemeralPortRange)
+	if err != nil {
+		log.WithError(err).Debug("cannot read ephemeral port range - using default")
+		return defaultEphemeralPortRangeLo, defaultEphemeralPortRangeHi
+	}
+	lo, hi, ok := parseEphemeralPortRange(string(fc))
+	if !ok {
+		log.WithField("content", string(fc)).Warn("cannot parse ephemeral port range - using default")
+		return defaultEphemeralPortRangeLo, defaultEphemeralPortRangeHi
+	}
+	return lo, hi
+}
+
+func parseEphemeralPortRange(content string) (lo, hi uint32, ok bool) {
+	fields := strings.Fields(content)
+	if len(fields) != 2 {
+		return 0, 0, false
+	}
+	l, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return 0, 0, false
+	}
+	h, err := strconv.ParseUint(fields[1], 10, 16)
+	if err != nil || h < l {
+		return 0, 0, false
+	}
+	return uint32(l), uint32(h), true
+}
+
+var procNetFiles = []struct {
+	Name     string
+	State    string
+	Protocol api.PortProtocol
+}{
+	{Name: fnNetTCP, State: stateListen, Protocol: api.PortProtocol_tcp},
+	{Name: fnNetTCP6, State: stateListen, Protocol: api.PortProtocol_tcp},
+	{Name: fnNetUDP, State: stateUnconnected, Protocol: api.PortProtocol_udp},
+	{Name: fnNetUDP6, State: stateUnconnected, Protocol: api.PortProtocol_udp},
+}
+
 // PollingServedPortsObserver regularly polls "/proc" to observe port changes
 type PollingServedPortsObserver struct {
 	RefreshInterval time.Duration
 
 	fileOpener func(fn string) (io.ReadCloser, error)
+	owners     *socketOwners
 }
 
 // Observe starts observing the served ports until the context is canceled.
@@ -56,6 +123,9 @@ func (p *PollingServedPortsObserver) Observe(ctx context.Context) (<-chan []Serv
 			return os.Open(fn)
 		}
 	}
+	if p.owners == nil {
+		p.owners = newSocketOwners(procDir)
+	}
 
 	var (
 		errchan = make(chan error, 1)
@@ -77,34 +147,34 @@ func (p *PollingServedPortsObserver) Observe(ctx context.Context) (<-chan []Serv
 
 			var (
 				visited = make(map[string]struct{})
-				ports   []ServedPort
+				sockets []servedSocket
 			)
-			for _, fn := range []string{fnNetTCP, fnNetTCP6} {
-				fc, err := p.fileOpener(fn)
+			for _, f := range procNetFiles {
+				fc, err := p.fileOpener(f.Name)
 				if err != nil {
 					errchan <- err
 					continue
 				}
-				ps, err := readNetTCPFile(fc, true)
+				ss, err := readNetFile(fc, f.State, f.Protocol)
 				fc.Close()
 
 				if err != nil {
 					errchan <- err
 					continue
 				}
-				for _, port := range ps {
-					key := fmt.Sprintf("%s:%d", port.Address, port.Port)
+				for _, socket := range ss {
+					key := fmt.Sprintf("%s:%d/%s", socket.Address, socket.Port, socket.Protocol)
 					_, exists := visited[key]
 					if exists {
 						continue
 					}
 					visited[key] = struct{}{}
-					ports = append(ports, port)
+					sockets = append(sockets, socket)
 				}
 			}
 
-			if len(ports) > 0 {
-				reschan <- ports
+			if len(sockets) > 0 {
+				reschan <- p.owners.Resolve(sockets)
 			}
 		}
 	}()
@@ -112,14 +182,55 @@ func (p *PollingServedPortsObserver) Observe(ctx context.Context) (<-chan []Serv
 	return reschan, errchan
 }
 
+// IsServed returns true if a local service listens on the given port
+func IsServed(port uint32) (bool, error) {
+	for _, fn := range []string{fnNetTCP, fnNetTCP6} {
+		fc, err := os.Open(fn)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		ps, err := readNetTCPFile(fc, true)
+		fc.Close()
+		if err != nil {
+			return false, err
+		}
+		for _, p := range ps {
+			if p.Port == port {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 func readNetTCPFile(fc io.Reader, listeningOnly bool) (ports []ServedPort, err error) {
+	var state string
+	if listeningOnly {
+		state = stateListen
+	}
+	sockets, err := readNetFile(fc, state, api.PortProtocol_tcp)
+	if err != nil {
+		return nil, err
+	}
+	for _, socket := range sockets {
+		ports = append(ports, socket.ServedPort)
+	}
+	return ports, nil
+}
+
+// readNetFile reads a /proc/net/{tcp,udp}* file. If state is not empty, only sockets in that state are returned.
+// Unconnected UDP sockets, i.e. those of servers, are in stateUnconnected.
+func readNetFile(fc io.Reader, state string, protocol api.PortProtocol) (sockets []servedSocket, err error) {
 	scanner := bufio.NewScanner(fc)
 	for scanner.Scan() {
 		fields := strings.Fields(scanner.Text())
-		if len(fields) < 4 {
+		if len(fields) < 10 {
 			continue
 		}
-		if listeningOnly && fields[3] != "0A" {
+		if state != "" && fields[3] != state {
 			continue
 		}
 
@@ -132,14 +243,24 @@ func readNetTCPFile(fc io.Reader, listeningOnly bool) (ports []ServedPort, err e
 		globallyBound := addr == "00000000" || addr == "00000000000000000000000000000000"
 		port, err := strconv.ParseUint(prt, 16, 32)
 		if err != nil {
-			log.WithError(err).WithField("port", prt).Warn("cannot parse port entry from /proc/net/tcp* file")
+			log.WithError(err).WithField("port", prt).Warn("cannot parse port entry from /proc/net/* file")
+			continue
+		}
+
+		inode, err := strconv.ParseUint(fields[9], 10, 64)
+		if err != nil {
+			log.WithError(err).WithField("inode", fields[9]).Warn("cannot parse inode entry from /proc/net/* file")
 			continue
 		}
 
-		ports = append(ports, ServedPort{
-			BoundToLocalhost: !globallyBound,
-			Address:          addr,
-			Port:             uint32(port),
+		sockets = append(sockets, servedSocket{
+			ServedPort: ServedPort{
+				BoundToLocalhost: !globallyBound,
+				Address:          addr,
+				Port:             uint32(port),
+				Protocol:         protocol,
+			},
+			Inode: inode,
 		})
 	}
 	if err = scanner.Err(); err != nil {
@@ -8,12 +8,15 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"net"
 	"os"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
 )
 
 const validTCPInput = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
@@ -35,6 +38,16 @@ const validTCP6Input = `  sl  local_address                         remote_addre
    7: 0000000000000000FFFF0000940C380A:59D7 0000000000000000FFFF00006100840A:E08A 06 00000000:00000000 03:000003E6 00000000     0        0 0 3 0000000000000000
   20: 0000000000000000FFFF00000100007F:59D7 0000000000000000FFFF00000100007F:EB64 01 00000000:00000000 02:000003D2 00000000 33333        0 57014424 2 0000000000000000 20 4 0 10 -1`
 
+const validUDPInput = `   sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+  123: 00000000:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000 33333        0 57031270 2 0000000000000000 0
+  456: 3500007F:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000   101        0 57030524 2 0000000000000000 0
+  789: 0100007F:D2A6 3500007F:0035 01 00000000:00000000 00:00000000 00000000 33333        0 57034411 2 0000000000000000 0
+`
+
+const validUDP6Input = `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+  301: 00000000000000000000000000000000:115C 00000000000000000000000000000000:0000 07 00000000:00000000 00:00000000 00000000 33333        0 57035718 2 0000000000000000 0
+`
+
 func TestObserve(t *testing.T) {
 	type Expectation [][]ServedPort
 	tests := []struct {
@@ -45,7 +58,7 @@ func TestObserve(t *testing.T) {
 		{
 			Name: "basic positive",
 			FileContents: []string{
-				"", "",
+				"", "", "", "",
 				validTCPInput, validTCP6Input,
 			},
 			Expectation: Expectation{
@@ -60,10 +73,27 @@ func TestObserve(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "tcp and udp",
+			FileContents: []string{
+				validTCPInput, "",
+				validUDPInput, validUDP6Input,
+			},
+			Expectation: Expectation{
+				{
+					{Address: "00000000", Port: 23000},
+					{Address: "00000000", Port: 6080},
+					{Address: "0100007F", Port: 5900, BoundToLocalhost: true},
+					{Address: "00000000", Port: 53, Protocol: api.PortProtocol_udp},
+					{Address: "3500007F", Port: 53, BoundToLocalhost: true, Protocol: api.PortProtocol_udp},
+					{Address: "00000000000000000000000000000000", Port: 4444, Protocol: api.PortProtocol_udp},
+				},
+			},
+		},
 		{
 			Name: "the same port bound locally on ip4 and ip6",
 			FileContents: []string{
-				"", "",
+				"", "", "", "",
 				`
 		   0: 00000000:17C0 00000000:0000 0A 00000000:00000000 00:00000000 00000000 33333        0 21757239 1 0000000000000000 100 0 0 10 0
 		   1: 0100007F:170C 00000000:0000 0A 00000000:00000000 00:00000000 00000000 33333        0 21752303 1 0000000000000000 100 0 0 10 0
@@ -87,7 +117,7 @@ func TestObserve(t *testing.T) {
 		{
 			Name: "the same port bound locally for ip4 and globally for ip6",
 			FileContents: []string{
-				"", "",
+				"", "", "", "",
 				`
    0: 00000000:17C0 00000000:0000 0A 00000000:00000000 00:00000000 00000000 33333        0 21757239 1 0000000000000000 100 0 0 10 0
    1: 0100007F:170C 00000000:0000 0A 00000000:00000000 00:00000000 00000000 33333        0 21752303 1 0000000000000000 100 0 0 10 0
@@ -111,7 +141,7 @@ func TestObserve(t *testing.T) {
 		{
 			Name: "the same port bound globally for ip4 and locally for ip6",
 			FileContents: []string{
-				"", "",
+				"", "", "", "",
 				`
    0: 00000000:17C0 00000000:0000 0A 00000000:00000000 00:00000000 00000000 33333        0 21757239 1 0000000000000000 100 0 0 10 0
    1: 00000000:170C 00000000:0000 0A 00000000:00000000 00:00000000 00000000 33333        0 21752303 1 0000000000000000 100 0 0 10 0
@@ -148,6 +178,7 @@ func TestObserve(t *testing.T) {
 					f++
 					return res, nil
 				},
+				owners: newSocketOwners(t.TempDir()),
 			}
 
 			ctx, cancel := context.WithCancel(context.Background())
@@ -228,3 +259,96 @@ func TestReadNetTCPFile(t *testing.T) {
 		})
 	}
 }
+
+func TestReadNetUDPFile(t *testing.T) {
+	type Expectation struct {
+		Sockets []servedSocket
+		Error   error
+	}
+	tests := []struct {
+		Name        string
+		Input       string
+		Expectation Expectation
+	}{
+		{
+			Name:  "valid udp4 input",
+			Input: validUDPInput,
+			Expectation: Expectation{
+				Sockets: []servedSocket{
+					{ServedPort: ServedPort{Address: "00000000", Port: 53, Protocol: api.PortProtocol_udp}, Inode: 57031270},
+					{ServedPort: ServedPort{Address: "3500007F", Port: 53, BoundToLocalhost: true, Protocol: api.PortProtocol_udp}, Inode: 57030524},
+				},
+			},
+		},
+		{
+			Name:  "valid udp6 input",
+			Input: validUDP6Input,
+			Expectation: Expectation{
+				Sockets: []servedSocket{
+					{ServedPort: ServedPort{Address: "00000000000000000000000000000000", Port: 4444, Protocol: api.PortProtocol_udp}, Inode: 57035718},
+				},
+			},
+		},
+		{
+			Name:        "tcp input",
+			Input:       validTCPInput,
+			Expectation: Expectation{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var act Expectation
+			act.Sockets, act.Error = readNetFile(bytes.NewReader([]byte(test.Input)), stateUnconnected, api.PortProtocol_udp)
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseEphemeralPortRange(t *testing.T) {
+	tests := []struct {
+		Content string
+		Lo, Hi  uint32
+		OK      bool
+	}{
+		{Content: "32768\t60999\n", Lo: 32768, Hi: 60999, OK: true},
+		{Content: "1024 65535", Lo: 1024, Hi: 65535, OK: true},
+		{Content: "60999 32768"},
+		{Content: "32768"},
+		{Content: "foo bar"},
+	}
+	for _, test := range tests {
+		lo, hi, ok := parseEphemeralPortRange(test.Content)
+		if lo != test.Lo || hi != test.Hi || ok != test.OK {
+			t.Errorf("parseEphemeralPortRange(%q) = %d, %d, %v; expected %d, %d, %v", test.Content, lo, hi, ok, test.Lo, test.Hi, test.OK)
+		}
+	}
+}
+
+func TestIsServed(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := uint32(l.Addr().(*net.TCPAddr).Port)
+
+	served, err := IsServed(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !served {
+		t.Errorf("port %d is not served while listening on it", port)
+	}
+
+	l.Close()
+	served, err = IsServed(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if served {
+		t.Errorf("port %d is served after closing its listener", port)
+	}
+}
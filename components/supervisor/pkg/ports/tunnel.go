@@ -21,6 +21,17 @@ type PortTunnelDescription struct {
 	LocalPort  uint32
 	TargetPort uint32
 	Visibility api.TunnelVisiblity
+	Protocol   api.PortProtocol
+}
+
+// tunnelKey identifies a tunnel: a TCP and a UDP port with the same number are tunneled independently
+type tunnelKey struct {
+	LocalPort uint32
+	Protocol  api.PortProtocol
+}
+
+func (desc *PortTunnelDescription) key() tunnelKey {
+	return tunnelKey{LocalPort: desc.LocalPort, Protocol: desc.Protocol}
 }
 
 type PortTunnelState struct {
@@ -48,20 +59,30 @@ type TunneledPortsInterface interface {
 
 	// Tunnel notifies clients to install listeners on remote machines.
 	// After that such clients should call EstablishTunnel to forward incoming connections.
-	Tunnel(ctx context.Context, options *TunnelOptions, descs ...*PortTunnelDescription) ([]uint32, error)
+	// It returns the descriptions of the tunnels which were opened or updated.
+	Tunnel(ctx context.Context, options *TunnelOptions, descs ...*PortTunnelDescription) ([]*PortTunnelDescription, error)
 
 	// CloseTunnel closes tunnels.
-	CloseTunnel(ctx context.Context, localPorts ...uint32) ([]uint32, error)
+	CloseTunnel(ctx context.Context, protocol api.PortProtocol, localPorts ...uint32) ([]uint32, error)
 
 	// EstablishTunnel actually establishes the tunnel for an incoming connection on a remote machine.
-	EstablishTunnel(ctx context.Context, clientID string, localPort uint32, targetPort uint32) (net.Conn, error)
+	// UDP tunnels carry datagrams framed by their length, see datagramConn.
+	EstablishTunnel(ctx context.Context, clientID string, protocol api.PortProtocol, localPort uint32, targetPort uint32) (net.Conn, error)
+
+	// ReverseTunnel listens on a local port and forwards incoming connections to a port offered by a client.
+	// The returned channel notifies about the client connections are forwarded to,
+	// and is closed once the context is canceled, which closes the reverse tunnel.
+	ReverseTunnel(ctx context.Context, localPort uint32, targetPort uint32) (<-chan string, error)
 }
 
 // TunneledPortsService observes the tunneled ports.
 type TunneledPortsService struct {
 	mu      *sync.RWMutex
 	cond    *sync.Cond
-	tunnels map[uint32]*PortTunnel
+	tunnels map[tunnelKey]*PortTunnel
+
+	reverseTunnels map[uint32]*reverseTunnel
+	reverseClients map[ReverseTunnelDialer]*reverseTunnelClient
 }
 
 // NewTunneledPortsService creates a new instance
@@ -70,7 +91,10 @@ func NewTunneledPortsService(debugEnable bool) *TunneledPortsService {
 	return &TunneledPortsService{
 		mu:      &mu,
 		cond:    sync.NewCond(&mu),
-		tunnels: make(map[uint32]*PortTunnel),
+		tunnels: make(map[tunnelKey]*PortTunnel),
+
+		reverseTunnels: make(map[uint32]*reverseTunnel),
+		reverseClients: make(map[ReverseTunnelDialer]*reverseTunnelClient),
 	}
 }
 
@@ -128,11 +152,14 @@ func (desc *PortTunnelDescription) validate() (err error) {
 	if desc.TargetPort < 0 || desc.TargetPort > 0xFFFF {
 		return xerrors.Errorf("bad target port: %d", desc.TargetPort)
 	}
+	if _, known := api.PortProtocol_name[int32(desc.Protocol)]; !known {
+		return xerrors.Errorf("bad protocol: %d", desc.Protocol)
+	}
 	return nil
 }
 
 // Tunnel opens new tunnels.
-func (p *TunneledPortsService) Tunnel(ctx context.Context, options *TunnelOptions, descs ...*PortTunnelDescription) (tunneled []uint32, err error) {
+func (p *TunneledPortsService) Tunnel(ctx context.Context, options *TunnelOptions, descs ...*PortTunnelDescription) (tunneled []*PortTunnelDescription, err error) {
 	var shouldNotify bool
 	p.cond.L.Lock()
 	defer p.cond.L.Unlock()
@@ -146,7 +173,7 @@ func (p *TunneledPortsService) Tunnel(ctx context.Context, options *TunnelOption
 			}
 			continue
 		}
-		tunnel, tunnelExists := p.tunnels[desc.LocalPort]
+		tunnel, tunnelExists := p.tunnels[desc.key()]
 		if !tunnelExists {
 			tunnel = &PortTunnel{
 				State: PortTunnelState{
@@ -154,13 +181,13 @@ func (p *TunneledPortsService) Tunnel(ctx context.Context, options *TunnelOption
 				},
 				Conns: make(map[string]map[net.Conn]struct{}),
 			}
-			p.tunnels[desc.LocalPort] = tunnel
+			p.tunnels[desc.key()] = tunnel
 		} else if options.SkipIfExists {
 			continue
 		}
 		tunnel.State.Desc = *desc
 		shouldNotify = true
-		tunneled = append(tunneled, desc.LocalPort)
+		tunneled = append(tunneled, desc)
 	}
 	if shouldNotify {
 		p.cond.Broadcast()
@@ -169,15 +196,16 @@ func (p *TunneledPortsService) Tunnel(ctx context.Context, options *TunnelOption
 }
 
 // CloseTunnel closes tunnels.
-func (p *TunneledPortsService) CloseTunnel(ctx context.Context, localPorts ...uint32) (closedPorts []uint32, err error) {
+func (p *TunneledPortsService) CloseTunnel(ctx context.Context, protocol api.PortProtocol, localPorts ...uint32) (closedPorts []uint32, err error) {
 	var closed []*PortTunnel
 	p.cond.L.Lock()
 	for _, localPort := range localPorts {
-		tunnel, existsTunnel := p.tunnels[localPort]
+		key := tunnelKey{LocalPort: localPort, Protocol: protocol}
+		tunnel, existsTunnel := p.tunnels[key]
 		if !existsTunnel {
 			continue
 		}
-		delete(p.tunnels, localPort)
+		delete(p.tunnels, key)
 		closed = append(closed, tunnel)
 		closedPorts = append(closedPorts, localPort)
 	}
@@ -204,11 +232,12 @@ func (p *TunneledPortsService) CloseTunnel(ctx context.Context, localPorts ...ui
 }
 
 // EstablishTunnel actually establishes the tunnel
-func (p *TunneledPortsService) EstablishTunnel(ctx context.Context, clientID string, localPort uint32, targetPort uint32) (net.Conn, error) {
+func (p *TunneledPortsService) EstablishTunnel(ctx context.Context, clientID string, protocol api.PortProtocol, localPort uint32, targetPort uint32) (net.Conn, error) {
 	p.cond.L.Lock()
 	defer p.cond.L.Unlock()
 
-	tunnel, tunnelExists := p.tunnels[localPort]
+	key := tunnelKey{LocalPort: localPort, Protocol: protocol}
+	tunnel, tunnelExists := p.tunnels[key]
 	if tunnelExists {
 		expectedTargetPort, clientExists := tunnel.State.Clients[clientID]
 		if clientExists && expectedTargetPort != targetPort {
@@ -219,17 +248,20 @@ func (p *TunneledPortsService) EstablishTunnel(ctx context.Context, clientID str
 	}
 
 	addr := net.JoinHostPort("localhost", strconv.FormatInt(int64(localPort), 10))
-	conn, err := net.Dial("tcp", addr)
+	conn, err := net.Dial(protocol.String(), addr)
 	if err != nil {
 		return nil, err
 	}
+	if protocol == api.PortProtocol_udp {
+		conn = newDatagramConn(conn)
+	}
 	var result net.Conn
 	result = &tunnelConn{
 		Conn: conn,
 		onDidClose: func() {
 			p.cond.L.Lock()
 			defer p.cond.L.Unlock()
-			_, existsTunnel := p.tunnels[localPort]
+			_, existsTunnel := p.tunnels[key]
 			if !existsTunnel {
 				return
 			}
@@ -254,15 +286,21 @@ func (p *TunneledPortsService) Snapshot(w io.Writer) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	localPorts := make([]uint32, 0, len(p.tunnels))
+	keys := make([]tunnelKey, 0, len(p.tunnels))
 	for k := range p.tunnels {
-		localPorts = append(localPorts, k)
+		keys = append(keys, k)
 	}
-	sort.Slice(localPorts, func(i, j int) bool { return localPorts[i] < localPorts[j] })
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].LocalPort == keys[j].LocalPort {
+			return keys[i].Protocol < keys[j].Protocol
+		}
+		return keys[i].LocalPort < keys[j].LocalPort
+	})
 
-	for _, localPort := range localPorts {
-		tunnel := p.tunnels[localPort]
+	for _, key := range keys {
+		tunnel := p.tunnels[key]
 		fmt.Fprintf(w, "Local Port: %d\n", tunnel.State.Desc.LocalPort)
+		fmt.Fprintf(w, "Protocol: %s\n", tunnel.State.Desc.Protocol)
 		fmt.Fprintf(w, "Target Port: %d\n", tunnel.State.Desc.TargetPort)
 		visibilty := api.TunnelVisiblity_name[int32(tunnel.State.Desc.Visibility)]
 		fmt.Fprintf(w, "Visibility: %s\n", visibilty)
@@ -273,4 +311,19 @@ func (p *TunneledPortsService) Snapshot(w io.Writer) {
 		}
 		fmt.Fprintf(w, "\n")
 	}
+
+	reversePorts := make([]uint32, 0, len(p.reverseTunnels))
+	for port := range p.reverseTunnels {
+		reversePorts = append(reversePorts, port)
+	}
+	sort.Slice(reversePorts, func(i, j int) bool { return reversePorts[i] < reversePorts[j] })
+
+	for _, port := range reversePorts {
+		tunnel := p.reverseTunnels[port]
+		fmt.Fprintf(w, "Reverse Port: %d\n", tunnel.Desc.LocalPort)
+		fmt.Fprintf(w, "Target Port: %d\n", tunnel.Desc.TargetPort)
+		fmt.Fprintf(w, "Client: %s\n", tunnel.Desc.ClientID)
+		fmt.Fprintf(w, "Tunnel Count: %d\n", len(tunnel.Conns))
+		fmt.Fprintf(w, "\n")
+	}
 }
@@ -21,7 +21,6 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-// TODO(ak) add reverse test
 func TestLocalPortTunneling(t *testing.T) {
 	updates := make(chan []PortTunnelState, 4)
 	assertUpdate := func(expectation []PortTunnelState) {
@@ -118,7 +117,7 @@ func TestLocalPortTunneling(t *testing.T) {
 		}
 		defer src.Close()
 
-		dst, err := service.EstablishTunnel(ctx, "test", localPort, targetPort)
+		dst, err := service.EstablishTunnel(ctx, "test", api.PortProtocol_tcp, localPort, targetPort)
 		if err != nil {
 			return err
 		}
@@ -152,7 +151,7 @@ func TestLocalPortTunneling(t *testing.T) {
 	}
 	assertUpdate([]PortTunnelState{{Desc: desc, Clients: map[string]uint32{"test": targetPort}}})
 
-	_, err = service.CloseTunnel(ctx, localPort)
+	_, err = service.CloseTunnel(ctx, api.PortProtocol_tcp, localPort)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -166,6 +165,173 @@ func TestLocalPortTunneling(t *testing.T) {
 	}
 }
 
+func TestUDPPortTunneling(t *testing.T) {
+	echo, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer echo.Close()
+	go func() {
+		buf := make([]byte, maxDatagramSize)
+		for {
+			n, addr, err := echo.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = echo.WriteTo(buf[:n], addr)
+		}
+	}()
+	localPort := uint32(echo.LocalAddr().(*net.UDPAddr).Port)
+
+	ctx := context.Background()
+	service := NewTunneledPortsService(false)
+	_, err = service.EstablishTunnel(ctx, "test", api.PortProtocol_udp, localPort, localPort)
+	if err == nil {
+		t.Fatal("established a tunnel which does not exist")
+	}
+	desc := &PortTunnelDescription{
+		LocalPort:  localPort,
+		TargetPort: localPort,
+		Visibility: api.TunnelVisiblity_host,
+		Protocol:   api.PortProtocol_udp,
+	}
+	_, err = service.Tunnel(ctx, &TunnelOptions{}, desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = service.EstablishTunnel(ctx, "test", api.PortProtocol_tcp, localPort, localPort)
+	if err == nil {
+		t.Fatal("established a tcp tunnel for a udp port")
+	}
+	tunnel, err := service.EstablishTunnel(ctx, "test", api.PortProtocol_udp, localPort, localPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tunnel.Close()
+
+	// two datagrams, the second one split across writes
+	frames := []byte{0, 5, 'h', 'e', 'l', 'l', 'o', 0, 5, 'w', 'o'}
+	if _, err = tunnel.Write(frames); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = tunnel.Write([]byte{'r', 'l', 'd'}); err != nil {
+		t.Fatal(err)
+	}
+	for _, expectation := range []string{"hello", "world"} {
+		header := make([]byte, 2)
+		if _, err = io.ReadFull(tunnel, header); err != nil {
+			t.Fatal(err)
+		}
+		datagram := make([]byte, int(header[0])<<8|int(header[1]))
+		if _, err = io.ReadFull(tunnel, datagram); err != nil {
+			t.Fatal(err)
+		}
+		if string(datagram) != expectation {
+			t.Errorf("unexpected datagram: %q, expected %q", datagram, expectation)
+		}
+	}
+
+	closed, err := service.CloseTunnel(ctx, api.PortProtocol_udp, localPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]uint32{localPort}, closed); diff != "" {
+		t.Errorf("unexpected closed tunnels (-want +got):\n%s", diff)
+	}
+}
+
+type testReverseTunnelDialer struct {
+	// keeps the dialers of different clients distinguishable
+	_ byte
+}
+
+func (*testReverseTunnelDialer) DialReverse(localPort uint32, targetPort uint32) (io.ReadWriteCloser, error) {
+	return net.Dial("tcp", "127.0.0.1:"+strconv.FormatInt(int64(targetPort), 10))
+}
+
+func TestReverseTunneling(t *testing.T) {
+	echo, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer echo.Close()
+	go func() {
+		for {
+			conn, err := echo.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	targetPort := uint32(echo.Addr().(*net.TCPAddr).Port)
+	localPort, err := availablePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service := NewTunneledPortsService(false)
+	updates, err := service.ReverseTunnel(ctx, localPort, targetPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = service.ReverseTunnel(ctx, localPort, targetPort)
+	if err == nil {
+		t.Fatal("reverse tunneled the same port twice")
+	}
+	assertUpdate := func(expectation string) {
+		if clientID := <-updates; clientID != expectation {
+			t.Errorf("unexpected client: %q, expected %q", clientID, expectation)
+		}
+	}
+	assertUpdate("")
+
+	var (
+		other  = &testReverseTunnelDialer{}
+		client = &testReverseTunnelDialer{}
+		spoof  = &testReverseTunnelDialer{}
+	)
+	service.OfferPorts(other, "other", targetPort+1)
+	service.OfferPorts(client, "test", targetPort)
+	assertUpdate("test")
+	// offers of other connections must not replace the offer, even if they claim the same client ID
+	service.OfferPorts(spoof, "test")
+
+	conn, err := net.Dial("tcp", "127.0.0.1:"+strconv.FormatInt(int64(localPort), 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_, err = conn.Write([]byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply := make([]byte, 5)
+	_, err = io.ReadFull(conn, reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(reply) != "hello" {
+		t.Errorf("unexpected reply: %q", reply)
+	}
+
+	service.OfferPorts(client, "test")
+	assertUpdate("")
+
+	cancel()
+	for range updates {
+	}
+	_, err = conn.Read(reply)
+	if err == nil {
+		t.Error("connection is still open after closing the reverse tunnel")
+	}
+}
+
 func availablePort() (uint32, error) {
 	l, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
@@ -0,0 +1,239 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package sshd
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// payloads as defined in RFC 4254, section 7
+type (
+	directTCPIPRequest struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}
+	tcpipForwardRequest struct {
+		Addr string
+		Port uint32
+	}
+	tcpipForwardResponse struct {
+		Port uint32
+	}
+	forwardedTCPIPRequest struct {
+		Addr       string
+		Port       uint32
+		OriginAddr string
+		OriginPort uint32
+	}
+)
+
+// handleDirectTCPIP serves local port forwarding, i.e. connects the client to an address reachable from the workspace
+func (s *Server) handleDirectTCPIP(newCh ssh.NewChannel) {
+	var req directTCPIPRequest
+	err := ssh.Unmarshal(newCh.ExtraData(), &req)
+	if err != nil {
+		_ = newCh.Reject(ssh.ConnectionFailed, "invalid request")
+		return
+	}
+
+	conn, err := net.Dial("tcp", net.JoinHostPort(req.Host, strconv.Itoa(int(req.Port))))
+	if err != nil {
+		_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	ch, reqs, err := newCh.Accept()
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	pipe(ch, conn)
+}
+
+// remoteForwards serves remote port forwarding of a connection, i.e. listens in the workspace
+// and forwards incoming connections to the client. Ports forwarded this way are served ports
+// to the port management, and hence get exposed like any other port.
+type remoteForwards struct {
+	srv  *Server
+	conn *ssh.ServerConn
+
+	mu        sync.Mutex
+	listeners map[string]net.Listener
+	closed    bool
+}
+
+func (f *remoteForwards) handleRequests(reqs <-chan *ssh.Request) {
+	for req := range reqs {
+		var (
+			ok      bool
+			payload []byte
+		)
+		switch req.Type {
+		case "tcpip-forward":
+			ok, payload = f.listen(req.Payload)
+		case "cancel-tcpip-forward":
+			ok = f.cancel(req.Payload)
+		case AuthorizedKeyRequest:
+			ok = f.isAuthorizedKey(req.Payload)
+		}
+		if req.WantReply {
+			_ = req.Reply(ok, payload)
+		}
+	}
+}
+
+func (f *remoteForwards) listen(payload []byte) (ok bool, resp []byte) {
+	var req tcpipForwardRequest
+	err := ssh.Unmarshal(payload, &req)
+	if err != nil {
+		return false, nil
+	}
+	if f.srv.Ports != nil && f.srv.Ports.IsInternal(req.Port) {
+		log.WithField("port", req.Port).Warn("ssh: refusing to forward internal port")
+		return false, nil
+	}
+
+	addr := net.JoinHostPort(req.Addr, strconv.Itoa(int(req.Port)))
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.WithError(err).WithField("addr", addr).Warn("ssh: cannot listen for remote forward")
+		return false, nil
+	}
+	port := uint32(l.Addr().(*net.TCPAddr).Port)
+	// the client refers to forwards with port 0 by the port we picked
+	addr = net.JoinHostPort(req.Addr, strconv.Itoa(int(port)))
+
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		l.Close()
+		return false, nil
+	}
+	if f.listeners == nil {
+		f.listeners = make(map[string]net.Listener)
+	}
+	f.listeners[addr] = l
+	f.mu.Unlock()
+	log.WithField("addr", addr).Info("ssh: forwarding remote port")
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go f.forward(req.Addr, port, conn)
+		}
+	}()
+
+	if req.Port == 0 {
+		// the client asked us to pick a port
+		return true, ssh.Marshal(tcpipForwardResponse{Port: port})
+	}
+	return true, nil
+}
+
+func (f *remoteForwards) forward(addr string, port uint32, conn net.Conn) {
+	origin := conn.RemoteAddr().(*net.TCPAddr)
+	ch, reqs, err := f.conn.OpenChannel("forwarded-tcpip", ssh.Marshal(forwardedTCPIPRequest{
+		Addr:       addr,
+		Port:       port,
+		OriginAddr: origin.IP.String(),
+		OriginPort: uint32(origin.Port),
+	}))
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	pipe(ch, conn)
+}
+
+func (f *remoteForwards) isAuthorizedKey(payload []byte) bool {
+	if f.conn.Permissions == nil || f.conn.Permissions.Extensions[gatewayExtension] == "" {
+		return false
+	}
+	key, err := ssh.ParsePublicKey(payload)
+	if err != nil {
+		return false
+	}
+	return f.srv.isAuthorizedKey(key)
+}
+
+func (f *remoteForwards) cancel(payload []byte) bool {
+	var req tcpipForwardRequest
+	err := ssh.Unmarshal(payload, &req)
+	if err != nil {
+		return false
+	}
+	addr := net.JoinHostPort(req.Addr, strconv.Itoa(int(req.Port)))
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	l, ok := f.listeners[addr]
+	if !ok {
+		return false
+	}
+	l.Close()
+	delete(f.listeners, addr)
+	return true
+}
+
+// Close stops all remote forwards
+func (f *remoteForwards) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, l := range f.listeners {
+		l.Close()
+	}
+	f.listeners = nil
+	f.closed = true
+	return nil
+}
+
+// pipe copies data between a channel and a connection in both directions. Once one direction reached EOF,
+// pipe closes the writing side of the other end only, so that its peer can still respond. Both ends are closed
+// once both directions are done, or one of them failed.
+func pipe(ch ssh.Channel, conn net.Conn) {
+	defer ch.Close()
+	defer conn.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, err := io.Copy(ch, conn)
+		if err != nil {
+			ch.Close()
+			conn.Close()
+			return
+		}
+		_ = ch.CloseWrite()
+	}()
+	go func() {
+		defer wg.Done()
+		_, err := io.Copy(conn, ch)
+		if err != nil {
+			ch.Close()
+			conn.Close()
+			return
+		}
+		if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+			_ = cw.CloseWrite()
+		} else {
+			conn.Close()
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package sshd
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/sys/unix"
+	"golang.org/x/xerrors"
+)
+
+// LoadOrGenerateHostKey loads the host key stored in fn, or generates and stores a new
+// ed25519 host key if there is none yet. Persisting the host key keeps it stable across
+// workspace restarts, so that clients don't see a changed host key on every start.
+//
+// fn may live in a location the workspace user can modify. Hence we never follow symlinks on
+// the way to fn, create missing directories accessible to the current user only, and never
+// overwrite an existing file.
+func LoadOrGenerateHostKey(fn string) (ssh.Signer, error) {
+	fn = filepath.Clean(fn)
+	if !filepath.IsAbs(fn) {
+		return nil, xerrors.Errorf("host key location must be absolute: %s", fn)
+	}
+	dir, err := openDirNoFollow(filepath.Dir(fn))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot open host key location: %w", err)
+	}
+	defer unix.Close(dir)
+	name := filepath.Base(fn)
+
+	fd, err := unix.Openat(dir, name, unix.O_RDONLY|unix.O_NOFOLLOW|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err == nil {
+		return readHostKey(fd)
+	}
+	if err != unix.ENOENT {
+		return nil, xerrors.Errorf("cannot read host key: %w", err)
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot generate host key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot marshal host key: %w", err)
+	}
+	content := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	fd, err = unix.Openat(dir, name, unix.O_WRONLY|unix.O_CREAT|unix.O_EXCL|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0600)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot store host key: %w", err)
+	}
+	f := os.NewFile(uintptr(fd), fn)
+	_, err = f.Write(content)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		// never leave a partial key behind
+		_ = unix.Unlinkat(dir, name, 0)
+		return nil, xerrors.Errorf("cannot store host key: %w", err)
+	}
+
+	return ssh.NewSignerFromKey(key)
+}
+
+// openDirNoFollow opens the absolute path dir without following symlinks, creating missing directories
+func openDirNoFollow(dir string) (int, error) {
+	fd, err := unix.Open("/", unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return -1, err
+	}
+	for _, seg := range strings.Split(strings.TrimPrefix(dir, "/"), "/") {
+		if seg == "" {
+			continue
+		}
+		next, err := unix.Openat(fd, seg, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+		if err == unix.ENOENT {
+			err = unix.Mkdirat(fd, seg, 0700)
+			if err != nil && err != unix.EEXIST {
+				unix.Close(fd)
+				return -1, xerrors.Errorf("cannot create %s: %w", seg, err)
+			}
+			next, err = unix.Openat(fd, seg, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+		}
+		unix.Close(fd)
+		if err != nil {
+			return -1, xerrors.Errorf("cannot open %s: %w", seg, err)
+		}
+		fd = next
+	}
+	return fd, nil
+}
+
+func readHostKey(fd int) (ssh.Signer, error) {
+	f := os.NewFile(uintptr(fd), "host-key")
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read host key: %w", err)
+	}
+	if !stat.Mode().IsRegular() {
+		return nil, xerrors.Errorf("cannot read host key: not a regular file")
+	}
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read host key: %w", err)
+	}
+	return parseHostKey(content)
+}
+
+func parseHostKey(content []byte) (ssh.Signer, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, xerrors.Errorf("cannot parse host key: no PEM data found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot parse host key: %w", err)
+	}
+	return ssh.NewSignerFromKey(key)
+}
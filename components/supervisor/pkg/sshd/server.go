@@ -0,0 +1,215 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package sshd
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"net"
+	"os"
+	"syscall"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// PortsManager is the part of the port management the SSH server needs for port forwarding,
+// see ports.Manager.
+type PortsManager interface {
+	// IsInternal returns true if the port is reserved for supervisor's internal services
+	IsInternal(port uint32) bool
+}
+
+// New creates a new SSH server
+func New(hostKey ssh.Signer) *Server {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/bash"
+	}
+	return &Server{
+		HostKey: hostKey,
+		User:    "gitpod",
+		Shell:   shell,
+		Workdir: "/home/gitpod",
+		Env:     os.Environ(),
+	}
+}
+
+// Server is an SSH server which runs sessions as the workspace user.
+// Besides shells and commands, it serves the sftp subsystem and supports
+// local (direct-tcpip) and remote (tcpip-forward) port forwarding.
+type Server struct {
+	HostKey ssh.Signer
+
+	// User is the only user clients may log in as
+	User string
+	// AuthorizedKeysFiles are the files listing the public keys that may log in.
+	// The files are read on every login attempt, hence keys can be added at runtime.
+	AuthorizedKeysFiles []string
+	// AuthorizedKeys returns further public keys that may log in, e.g. those the workspace
+	// owner registered with Gitpod. It's called on every login attempt, hence should cache. May be nil.
+	AuthorizedKeys func(ctx context.Context) ([]ssh.PublicKey, error)
+	// GatewayToken lets the SSH gateway of ws-proxy log in as User, using the token as password.
+	// The gateway may then ask whether the keys of its clients are authorized, see AuthorizedKeyRequest.
+	// Leave empty to disable password logins.
+	GatewayToken string
+
+	Shell   string
+	Workdir string
+	Env     []string
+	Creds   *syscall.Credential
+
+	// SFTPServer is the command serving the sftp subsystem on stdin/stdout.
+	// Leave empty to disable the sftp subsystem.
+	SFTPServer []string
+
+	// Ports is notified about forwarded ports. May be nil.
+	Ports PortsManager
+}
+
+// Serve accepts SSH connections on l until the context is canceled
+func (s *Server) Serve(ctx context.Context, l net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: s.authorize,
+	}
+	if s.GatewayToken != "" {
+		config.PasswordCallback = s.authorizeGateway
+	}
+	config.AddHostKey(s.HostKey)
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go s.handleConn(ctx, conn, config)
+	}
+}
+
+func (s *Server) handleConn(ctx context.Context, conn net.Conn, config *ssh.ServerConfig) {
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		log.WithError(err).WithField("remote", conn.RemoteAddr().String()).Debug("ssh: handshake failed")
+		conn.Close()
+		return
+	}
+	log := log.WithField("remote", sshConn.RemoteAddr().String()).WithField("key", sshConn.Permissions.Extensions[fingerprintExtension])
+	log.Info("ssh: new connection")
+	defer log.Info("ssh: connection closed")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		sshConn.Close()
+	}()
+
+	fwd := &remoteForwards{srv: s, conn: sshConn}
+	defer fwd.Close()
+	go fwd.handleRequests(reqs)
+
+	for newCh := range chans {
+		switch newCh.ChannelType() {
+		case "session":
+			go s.handleSession(newCh)
+		case "direct-tcpip":
+			go s.handleDirectTCPIP(newCh)
+		default:
+			_ = newCh.Reject(ssh.UnknownChannelType, "unsupported channel type")
+		}
+	}
+}
+
+const (
+	fingerprintExtension = "pubkey-fp"
+	gatewayExtension     = "gateway"
+)
+
+// AuthorizedKeyRequest is the global request the SSH gateway sends to find out whether
+// a public key (in wire format) may log in. Only connections of the gateway may send it.
+const AuthorizedKeyRequest = "authorized-key@gitpod.io"
+
+func (s *Server) authorize(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+	if meta.User() != s.User {
+		return nil, xerrors.Errorf("unknown user %s", meta.User())
+	}
+	if !s.isAuthorizedKey(key) {
+		return nil, xerrors.Errorf("unknown public key for %s", meta.User())
+	}
+	return &ssh.Permissions{
+		Extensions: map[string]string{
+			fingerprintExtension: ssh.FingerprintSHA256(key),
+		},
+	}, nil
+}
+
+func (s *Server) authorizeGateway(meta ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+	if meta.User() != s.User {
+		return nil, xerrors.Errorf("unknown user %s", meta.User())
+	}
+	if subtle.ConstantTimeCompare(password, []byte(s.GatewayToken)) != 1 {
+		return nil, xerrors.Errorf("invalid gateway token for %s", meta.User())
+	}
+	return &ssh.Permissions{
+		Extensions: map[string]string{
+			gatewayExtension: "true",
+		},
+	}, nil
+}
+
+// isAuthorizedKeyTimeout bounds the time we wait for AuthorizedKeys during a login attempt
+const isAuthorizedKeyTimeout = 10 * time.Second
+
+// isAuthorizedKey returns true if one of the authorized keys files or AuthorizedKeys lists the key
+func (s *Server) isAuthorizedKey(key ssh.PublicKey) bool {
+	marshalled := key.Marshal()
+	if s.AuthorizedKeys != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), isAuthorizedKeyTimeout)
+		keys, err := s.AuthorizedKeys(ctx)
+		cancel()
+		if err != nil {
+			log.WithError(err).Warn("ssh: cannot get authorized keys")
+		}
+		for _, authorized := range keys {
+			if bytes.Equal(authorized.Marshal(), marshalled) {
+				return true
+			}
+		}
+	}
+	for _, fn := range s.AuthorizedKeysFiles {
+		content, err := os.ReadFile(fn)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			log.WithError(err).WithField("file", fn).Warn("ssh: cannot read authorized keys")
+			continue
+		}
+		for len(content) > 0 {
+			authorized, _, _, rest, err := ssh.ParseAuthorizedKey(content)
+			if err != nil {
+				// no further valid keys in this file
+				break
+			}
+			if bytes.Equal(authorized.Marshal(), marshalled) {
+				return true
+			}
+			content = rest
+		}
+	}
+	return false
+}
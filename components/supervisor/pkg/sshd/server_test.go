@@ -0,0 +1,486 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package sshd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/gitpod-io/gitpod/supervisor/pkg/exitstatus"
+)
+
+func TestLoadOrGenerateHostKey(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "ssh", "host-key")
+
+	generated, err := LoadOrGenerateHostKey(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadOrGenerateHostKey(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act, exp := ssh.FingerprintSHA256(loaded.PublicKey()), ssh.FingerprintSHA256(generated.PublicKey()); act != exp {
+		t.Errorf("host key changed: %s, expected %s", act, exp)
+	}
+	stat, err := os.Stat(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := stat.Mode().Perm(); perm != 0600 {
+		t.Errorf("unexpected host key permissions: %v", perm)
+	}
+}
+
+func TestLoadOrGenerateHostKeySymlinks(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target")
+	err := os.Mkdir(target, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Symlink(target, filepath.Join(dir, "linked-dir"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Symlink(filepath.Join(target, "key"), filepath.Join(dir, "linked-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fn := range []string{
+		filepath.Join(dir, "linked-dir", "host-key"),
+		filepath.Join(dir, "linked-key"),
+	} {
+		_, err = LoadOrGenerateHostKey(fn)
+		if err == nil {
+			t.Errorf("expected %s to be rejected", fn)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(target, "key")); !os.IsNotExist(err) {
+		t.Errorf("host key was written through a symlink")
+	}
+}
+
+type testServer struct {
+	Addr    string
+	HostKey ssh.PublicKey
+	Client  ssh.Signer
+	Owner   ssh.Signer
+}
+
+func generateTestKey(t *testing.T) ssh.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func startTestServer(t *testing.T, ports PortsManager) *testServer {
+	dir := t.TempDir()
+	hostKey, err := LoadOrGenerateHostKey(filepath.Join(dir, "host-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := generateTestKey(t)
+	owner := generateTestKey(t)
+	authorizedKeys := filepath.Join(dir, "authorized_keys")
+	err = os.WriteFile(authorizedKeys, append([]byte("# comment\n"), ssh.MarshalAuthorizedKey(client.PublicKey())...), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := New(hostKey)
+	srv.Shell = "/bin/sh"
+	srv.Workdir = dir
+	srv.AuthorizedKeysFiles = []string{filepath.Join(dir, "does-not-exist"), authorizedKeys}
+	srv.AuthorizedKeys = func(ctx context.Context) ([]ssh.PublicKey, error) {
+		return []ssh.PublicKey{owner.PublicKey()}, nil
+	}
+	srv.Ports = ports
+	srv.GatewayToken = testGatewayToken
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		_ = srv.Serve(ctx, l)
+	}()
+
+	return &testServer{
+		Addr:    l.Addr().String(),
+		HostKey: hostKey.PublicKey(),
+		Client:  client,
+		Owner:   owner,
+	}
+}
+
+func (ts *testServer) dial(user string, key ssh.Signer) (*ssh.Client, error) {
+	return ssh.Dial("tcp", ts.Addr, &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(key)},
+		HostKeyCallback: ssh.FixedHostKey(ts.HostKey),
+	})
+}
+
+const testGatewayToken = "gateway-token"
+
+func TestAuthorize(t *testing.T) {
+	ts := startTestServer(t, nil)
+
+	_, unknownKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown, err := ssh.NewSignerFromKey(unknownKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Desc        string
+		User        string
+		Key         ssh.Signer
+		ExpectError bool
+	}{
+		{Desc: "authorized key", User: "gitpod", Key: ts.Client},
+		{Desc: "owner key", User: "gitpod", Key: ts.Owner},
+		{Desc: "unknown key", User: "gitpod", Key: unknown, ExpectError: true},
+		{Desc: "unknown user", User: "root", Key: ts.Client, ExpectError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			client, err := ts.dial(test.User, test.Key)
+			if (err != nil) != test.ExpectError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if client != nil {
+				client.Close()
+			}
+		})
+	}
+}
+
+func TestGateway(t *testing.T) {
+	ts := startTestServer(t, nil)
+
+	_, err := ssh.Dial("tcp", ts.Addr, &ssh.ClientConfig{
+		User:            "gitpod",
+		Auth:            []ssh.AuthMethod{ssh.Password("wrong-token")},
+		HostKeyCallback: ssh.FixedHostKey(ts.HostKey),
+	})
+	if err == nil {
+		t.Fatal("expected login with wrong gateway token to fail")
+	}
+
+	gateway, err := ssh.Dial("tcp", ts.Addr, &ssh.ClientConfig{
+		User:            "gitpod",
+		Auth:            []ssh.AuthMethod{ssh.Password(testGatewayToken)},
+		HostKeyCallback: ssh.FixedHostKey(ts.HostKey),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gateway.Close()
+	client, err := ts.dial("gitpod", ts.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	_, unknownKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown, err := ssh.NewSignerFromKey(unknownKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Desc     string
+		Conn     *ssh.Client
+		Key      ssh.PublicKey
+		Expected bool
+	}{
+		{Desc: "authorized key", Conn: gateway, Key: ts.Client.PublicKey(), Expected: true},
+		{Desc: "unknown key", Conn: gateway, Key: unknown.PublicKey()},
+		{Desc: "not the gateway", Conn: client, Key: ts.Client.PublicKey()},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			ok, _, err := test.Conn.SendRequest(AuthorizedKeyRequest, true, test.Key.Marshal())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != test.Expected {
+				t.Errorf("unexpected reply: got %v, expected %v", ok, test.Expected)
+			}
+		})
+	}
+}
+
+func TestExec(t *testing.T) {
+	ts := startTestServer(t, nil)
+	client, err := ts.dial("gitpod", ts.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	sess, err := client.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sess.Close()
+	err = sess.Setenv("GREETING", "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	sess.Stdout = &stdout
+	sess.Stderr = &stderr
+	sess.Stdin = strings.NewReader("world\n")
+
+	err = sess.Run(`read name; echo "$GREETING $name"; echo oops >&2; exit 3`)
+	if exitErr, ok := err.(*ssh.ExitError); !ok || exitErr.ExitStatus() != 3 {
+		t.Errorf("unexpected exit: %v", err)
+	}
+	if act := stdout.String(); act != "hello world\n" {
+		t.Errorf("unexpected stdout: %q", act)
+	}
+	if act := stderr.String(); act != "oops\n" {
+		t.Errorf("unexpected stderr: %q", act)
+	}
+}
+
+// startTestReaper waits for any child process like supervisor's reaper does
+func startTestReaper(t *testing.T) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGCHLD)
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		signal.Stop(sigs)
+		close(done)
+	})
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-sigs:
+			}
+			for {
+				var status syscall.WaitStatus
+				pid, err := syscall.Wait4(-1, &status, syscall.WNOHANG, nil)
+				if err != nil || pid <= 0 {
+					break
+				}
+				exitstatus.Record(pid, status)
+			}
+		}
+	}()
+}
+
+func TestExecExitStatusWithReaper(t *testing.T) {
+	startTestReaper(t)
+	ts := startTestServer(t, nil)
+	client, err := ts.dial("gitpod", ts.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	run := func(cmd string) error {
+		sess, err := client.NewSession()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sess.Close()
+		return sess.Run(cmd)
+	}
+	for i := 0; i < 100; i++ {
+		if err := run("true"); err != nil {
+			t.Fatalf("run %d: unexpected exit: %v", i, err)
+		}
+	}
+	if exitErr, ok := run("exit 3").(*ssh.ExitError); !ok || exitErr.ExitStatus() != 3 {
+		t.Errorf("unexpected exit of failing command")
+	}
+}
+
+func TestLocalForward(t *testing.T) {
+	target := startEchoServer(t)
+	ts := startTestServer(t, nil)
+	client, err := ts.dial("gitpod", ts.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	conn, err := client.Dial("tcp", target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	expectEcho(t, conn)
+}
+
+func TestLocalForwardHalfClose(t *testing.T) {
+	// the target responds once the client is done sending
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		req, err := io.ReadAll(conn)
+		if err != nil {
+			return
+		}
+		_, _ = conn.Write(append([]byte("got "), req...))
+	}()
+
+	ts := startTestServer(t, nil)
+	client, err := ts.dial("gitpod", ts.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	conn, err := client.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_, err = conn.Write([]byte("ping"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = conn.(interface{ CloseWrite() error }).CloseWrite()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act := string(resp); act != "got ping" {
+		t.Errorf("unexpected response: %q", act)
+	}
+}
+
+type testPortsManager map[uint32]struct{}
+
+func (m testPortsManager) IsInternal(port uint32) bool {
+	_, ok := m[port]
+	return ok
+}
+
+func TestRemoteForward(t *testing.T) {
+	internal, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	internalPort := uint32(internal.Addr().(*net.TCPAddr).Port)
+	internal.Close()
+
+	ts := startTestServer(t, testPortsManager{internalPort: struct{}{}})
+	client, err := ts.dial("gitpod", ts.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	_, err = client.Listen("tcp", internal.Addr().String())
+	if err == nil {
+		t.Errorf("forwarding an internal port succeeded")
+	}
+
+	l, err := client.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = io.Copy(conn, conn)
+	}()
+
+	// the listener lives in the "workspace", i.e. next to the server
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	expectEcho(t, conn)
+}
+
+func startEchoServer(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return l.Addr().String()
+}
+
+func expectEcho(t *testing.T, conn net.Conn) {
+	msg := []byte("ping")
+	_, err := conn.Write(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, len(msg))
+	_, err = io.ReadFull(conn, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf, msg) {
+		t.Errorf("unexpected echo: %q", buf)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package sshd
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/creack/pty"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/exitstatus"
+)
+
+// request payloads as defined in RFC 4254, section 6
+type (
+	ptyRequest struct {
+		Term     string
+		Columns  uint32
+		Rows     uint32
+		WidthPx  uint32
+		HeightPx uint32
+		Modes    string
+	}
+	windowChangeRequest struct {
+		Columns  uint32
+		Rows     uint32
+		WidthPx  uint32
+		HeightPx uint32
+	}
+	envRequest struct {
+		Name  string
+		Value string
+	}
+	execRequest struct {
+		Command string
+	}
+	subsystemRequest struct {
+		Name string
+	}
+	exitStatus struct {
+		Status uint32
+	}
+)
+
+type session struct {
+	srv *Server
+	ch  ssh.Channel
+
+	env  []string
+	term string
+
+	mu      sync.Mutex
+	size    *pty.Winsize
+	ptmx    *os.File
+	cmd     *exec.Cmd
+	started bool
+	exited  chan struct{}
+}
+
+func (s *Server) handleSession(newCh ssh.NewChannel) {
+	ch, reqs, err := newCh.Accept()
+	if err != nil {
+		log.WithError(err).Warn("ssh: cannot accept session")
+		return
+	}
+	sess := &session{srv: s, ch: ch}
+	for req := range reqs {
+		ok := sess.handleRequest(req)
+		if req.WantReply {
+			_ = req.Reply(ok, nil)
+		}
+	}
+	sess.close()
+}
+
+func (sess *session) handleRequest(req *ssh.Request) bool {
+	switch req.Type {
+	case "env":
+		var r envRequest
+		if ssh.Unmarshal(req.Payload, &r) != nil {
+			return false
+		}
+		sess.env = append(sess.env, r.Name+"="+r.Value)
+		return true
+
+	case "pty-req":
+		var r ptyRequest
+		if ssh.Unmarshal(req.Payload, &r) != nil {
+			return false
+		}
+		sess.mu.Lock()
+		sess.term = r.Term
+		sess.size = &pty.Winsize{Cols: uint16(r.Columns), Rows: uint16(r.Rows), X: uint16(r.WidthPx), Y: uint16(r.HeightPx)}
+		sess.mu.Unlock()
+		return true
+
+	case "window-change":
+		var r windowChangeRequest
+		if ssh.Unmarshal(req.Payload, &r) != nil {
+			return false
+		}
+		sess.mu.Lock()
+		defer sess.mu.Unlock()
+		sess.size = &pty.Winsize{Cols: uint16(r.Columns), Rows: uint16(r.Rows), X: uint16(r.WidthPx), Y: uint16(r.HeightPx)}
+		if sess.ptmx != nil {
+			_ = pty.Setsize(sess.ptmx, sess.size)
+		}
+		return true
+
+	case "shell":
+		return sess.start([]string{sess.srv.Shell, "-l"}) == nil
+
+	case "exec":
+		var r execRequest
+		if ssh.Unmarshal(req.Payload, &r) != nil {
+			return false
+		}
+		return sess.start([]string{sess.srv.Shell, "-c", r.Command}) == nil
+
+	case "subsystem":
+		var r subsystemRequest
+		if ssh.Unmarshal(req.Payload, &r) != nil {
+			return false
+		}
+		if r.Name != "sftp" || len(sess.srv.SFTPServer) == 0 {
+			return false
+		}
+		return sess.start(sess.srv.SFTPServer) == nil
+
+	default:
+		return false
+	}
+}
+
+// start runs the command of the session. A session runs at most one command.
+func (sess *session) start(args []string) (err error) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if sess.started {
+		return xerrors.Errorf("session already runs a command")
+	}
+	defer func() {
+		if err != nil {
+			log.WithError(err).WithField("cmd", args).Warn("ssh: cannot start command")
+		}
+	}()
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = sess.srv.Workdir
+	cmd.Env = append(append([]string{}, sess.srv.Env...), sess.env...)
+	if sess.srv.Creds != nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{Credential: sess.srv.Creds}
+	}
+
+	var copied sync.WaitGroup
+	// the reaper can only have collected processes which ended after this point in time
+	startedAt := time.Now()
+	if sess.size != nil {
+		if sess.term != "" {
+			cmd.Env = append(cmd.Env, "TERM="+sess.term)
+		}
+		ptmx, err := pty.StartWithSize(cmd, sess.size)
+		if err != nil {
+			return err
+		}
+		sess.ptmx = ptmx
+		//nolint:errcheck
+		go io.Copy(ptmx, sess.ch)
+		copied.Add(1)
+		go func() {
+			defer copied.Done()
+			// reading fails with EIO once the process is gone and the output was drained
+			_, _ = io.Copy(sess.ch, ptmx)
+		}()
+	} else {
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return err
+		}
+		cmd.Stdout = sess.ch
+		cmd.Stderr = sess.ch.Stderr()
+		err = cmd.Start()
+		if err != nil {
+			return err
+		}
+		go func() {
+			_, _ = io.Copy(stdin, sess.ch)
+			stdin.Close()
+		}()
+	}
+	sess.cmd = cmd
+	sess.started = true
+	sess.exited = make(chan struct{})
+
+	go func() {
+		_ = cmd.Wait()
+		status, ok := waitStatus(cmd, startedAt)
+		close(sess.exited)
+		copied.Wait()
+
+		code := uint32(255)
+		if ok {
+			code = exitCode(status)
+		}
+		_, _ = sess.ch.SendRequest("exit-status", false, ssh.Marshal(exitStatus{Status: code}))
+		sess.ch.Close()
+	}()
+	return nil
+}
+
+// reapedStatusTimeout is the time we wait for the reaper to record the exit status of a process it collected
+const reapedStatusTimeout = 5 * time.Second
+
+// waitStatus returns the wait status of a command which was waited for. If supervisor's reaper collected
+// the process first, its wait status is taken from what the reaper recorded.
+func waitStatus(cmd *exec.Cmd, startedAt time.Time) (status syscall.WaitStatus, ok bool) {
+	if cmd.ProcessState != nil {
+		status, ok = cmd.ProcessState.Sys().(syscall.WaitStatus)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), reapedStatusTimeout)
+	defer cancel()
+	return exitstatus.Await(ctx, cmd.Process.Pid, startedAt)
+}
+
+// close ends the session once the client closed the channel
+func (sess *session) close() {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	if sess.ptmx != nil {
+		// closing the pseudo-terminal sends SIGHUP to the session's processes
+		sess.ptmx.Close()
+	} else if sess.cmd != nil {
+		select {
+		case <-sess.exited:
+		default:
+			_ = sess.cmd.Process.Signal(syscall.SIGHUP)
+		}
+	}
+	sess.ch.Close()
+}
+
+// exitCode returns the exit status of a process the way shells report it
+func exitCode(status syscall.WaitStatus) uint32 {
+	switch {
+	case status.Signaled():
+		return 128 + uint32(status.Signal())
+	case status.Exited():
+		return uint32(status.ExitStatus())
+	default:
+		return 255
+	}
+}
@@ -191,6 +191,12 @@ type WorkspaceConfig struct {
 	// Tokens is a JSON encoded list of WorkspaceGitpodToken
 	Tokens string `env:"THEIA_SUPERVISOR_TOKENS"`
 
+	// PortShareSecret signs the share tokens of ports, see portshare
+	PortShareSecret string `env:"THEIA_SUPERVISOR_PORT_SHARE_SECRET"`
+
+	// SSHGatewayToken lets ws-proxy's SSH gateway log into the SSH server
+	SSHGatewayToken string `env:"THEIA_SUPERVISOR_SSH_GATEWAY_TOKEN"`
+
 	// WorkspaceID is the ID of the workspace
 	WorkspaceID string `env:"GITPOD_WORKSPACE_ID"`
 
@@ -214,6 +220,10 @@ type WorkspaceConfig struct {
 
 	// WorkspaceClusterHost is a host under which this workspace is served, e.g. ws-eu11.gitpod.io
 	WorkspaceClusterHost string `env:"GITPOD_WORKSPACE_CLUSTER_HOST"`
+
+	// TerminalRecording controls whether the output of all terminals is recorded as asciicast files.
+	// Terminals of headless workspaces are always recorded. Only the most recent recordings are kept.
+	TerminalRecording bool `env:"SUPERVISOR_TERMINAL_RECORDING"`
 }
 
 // WorkspaceGitpodToken is a list of tokens that should be added to supervisor's token service
@@ -232,6 +242,36 @@ type TaskConfig struct {
 	Env      *map[string]interface{} `json:"env,omitempty"`
 	OpenIn   *string                 `json:"openIn,omitempty"`
 	OpenMode *string                 `json:"openMode,omitempty"`
+
+	// DependsOn names the tasks which must be running before this task starts.
+	// In prebuilds these tasks must have completed successfully instead.
+	DependsOn *[]string `json:"dependsOn,omitempty"`
+	// WaitFor holds the task back until all of its conditions are met. Ignored in prebuilds.
+	WaitFor *TaskWaitForConfig `json:"waitFor,omitempty"`
+	// Restart determines if the task is started again once it closed. Ignored in prebuilds.
+	Restart *TaskRestartPolicy `json:"restart,omitempty"`
+}
+
+// TaskRestartPolicy determines if a task is started again once it closed
+type TaskRestartPolicy string
+
+const (
+	// TaskRestartNever never starts a task again
+	TaskRestartNever TaskRestartPolicy = "never"
+	// TaskRestartOnFailure starts a task again with an exponential backoff if it failed
+	TaskRestartOnFailure TaskRestartPolicy = "on-failure"
+	// TaskRestartAlways starts a task again with an exponential backoff whenever it closed
+	TaskRestartAlways TaskRestartPolicy = "always"
+)
+
+// TaskWaitForConfig defines the conditions a task waits for before it starts
+type TaskWaitForConfig struct {
+	// Port waits until a local service listens on this port
+	Port *uint32 `json:"port,omitempty"`
+	// File waits until this file exists. Relative paths are resolved against the workspace location.
+	File *string `json:"file,omitempty"`
+	// Command waits until this shell command exits successfully
+	Command *string `json:"command,omitempty"`
 }
 
 // Validate validates this configuration
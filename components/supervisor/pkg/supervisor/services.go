@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
@@ -17,8 +18,10 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/portshare"
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	"github.com/gitpod-io/gitpod/supervisor/api"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
@@ -712,8 +715,59 @@ func (state *InMemoryContentState) ContentSource() (src csapi.WorkspaceInitSourc
 	return state.contentSource, true
 }
 
+type taskService struct {
+	tasksManager *tasksManager
+
+	api.UnimplementedTaskServiceServer
+}
+
+func (s *taskService) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterTaskServiceServer(srv, s)
+}
+
+func (s *taskService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error {
+	return api.RegisterTaskServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+const defaultTaskStopGracePeriod = 10 * time.Second
+
+func taskStopGracePeriod(seconds int64) time.Duration {
+	if seconds <= 0 {
+		return defaultTaskStopGracePeriod
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// Start starts a closed task again.
+func (s *taskService) Start(ctx context.Context, req *api.StartTaskRequest) (*api.StartTaskResponse, error) {
+	err := s.tasksManager.StartTask(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &api.StartTaskResponse{}, nil
+}
+
+// Stop ends a running task or cancels its pending restart.
+func (s *taskService) Stop(ctx context.Context, req *api.StopTaskRequest) (*api.StopTaskResponse, error) {
+	err := s.tasksManager.StopTask(req.Id, taskStopGracePeriod(req.GracePeriodSeconds))
+	if err != nil {
+		return nil, err
+	}
+	return &api.StopTaskResponse{}, nil
+}
+
+// Restart stops a running task and starts it again right away.
+func (s *taskService) Restart(ctx context.Context, req *api.RestartTaskRequest) (*api.RestartTaskResponse, error) {
+	err := s.tasksManager.RestartTask(req.Id, taskStopGracePeriod(req.GracePeriodSeconds))
+	if err != nil {
+		return nil, err
+	}
+	return &api.RestartTaskResponse{}, nil
+}
+
 type portService struct {
-	portsManager *ports.Manager
+	portsManager    *ports.Manager
+	portShareSecret string
 
 	api.UnimplementedPortServiceServer
 }
@@ -732,6 +786,7 @@ func (s *portService) Tunnel(ctx context.Context, req *api.TunnelPortRequest) (*
 		LocalPort:  req.Port,
 		TargetPort: req.TargetPort,
 		Visibility: req.Visibility,
+		Protocol:   req.Protocol,
 	})
 	if err != nil {
 		return nil, err
@@ -741,7 +796,7 @@ func (s *portService) Tunnel(ctx context.Context, req *api.TunnelPortRequest) (*
 
 // CloseTunnel closes the tunnel.
 func (s *portService) CloseTunnel(ctx context.Context, req *api.CloseTunnelRequest) (*api.CloseTunnelResponse, error) {
-	err := s.portsManager.CloseTunnel(ctx, req.Port)
+	err := s.portsManager.CloseTunnel(ctx, req.Protocol, req.Port)
 	if err != nil {
 		return nil, err
 	}
@@ -759,7 +814,7 @@ func (s *portService) EstablishTunnel(stream api.PortService_EstablishTunnelServ
 		return status.Error(codes.FailedPrecondition, "first request should be a desc")
 	}
 
-	tunnel, err := s.portsManager.EstablishTunnel(stream.Context(), desc.ClientId, desc.Port, desc.TargetPort)
+	tunnel, err := s.portsManager.EstablishTunnel(stream.Context(), desc.ClientId, desc.Protocol, desc.Port, desc.TargetPort)
 	if err != nil {
 		return status.Errorf(codes.Internal, "failed establish the tunnel: %v", err)
 	}
@@ -812,6 +867,64 @@ func (s *portService) EstablishTunnel(stream api.PortService_EstablishTunnelServ
 	return status.Error(codes.Internal, returnedError.Error())
 }
 
+// ReverseTunnel forwards connections to a workspace port to a port offered by a client
+func (s *portService) ReverseTunnel(req *api.ReverseTunnelRequest, stream api.PortService_ReverseTunnelServer) error {
+	updates, err := s.portsManager.ReverseTunnel(stream.Context(), req.Port, req.TargetPort)
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "failed to reverse tunnel: %v", err)
+	}
+	for clientID := range updates {
+		err := stream.Send(&api.ReverseTunnelResponse{ClientId: clientID})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxPortShareTTL is the longest time a share link can grant access to a port for
+const maxPortShareTTL = 7 * 24 * time.Hour
+
+// SharePort mints a link to an exposed port which grants access until it expires
+func (s *portService) SharePort(ctx context.Context, req *api.SharePortRequest) (*api.SharePortResponse, error) {
+	if s.portShareSecret == "" {
+		return nil, status.Error(codes.FailedPrecondition, "ports of this workspace cannot be shared")
+	}
+	ttl := time.Duration(req.TtlSeconds) * time.Second
+	if ttl <= 0 || ttl > maxPortShareTTL {
+		return nil, status.Errorf(codes.InvalidArgument, "ttl must be positive and at most %s", maxPortShareTTL)
+	}
+
+	var portURL string
+	for _, p := range s.portsManager.Status() {
+		if p.LocalPort == req.Port && p.Exposed != nil {
+			portURL = p.Exposed.Url
+			break
+		}
+	}
+	if portURL == "" {
+		return nil, status.Errorf(codes.NotFound, "port %d is not exposed", req.Port)
+	}
+	shareURL, err := url.Parse(portURL)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid port URL: %v", err)
+	}
+
+	expires := time.Now().Add(ttl)
+	token := portshare.Sign(s.portShareSecret, portshare.Token{
+		Port:     req.Port,
+		Expires:  expires,
+		ReadOnly: req.ReadOnly,
+	})
+	query := shareURL.Query()
+	query.Set(portshare.QueryParam, token)
+	shareURL.RawQuery = query.Encode()
+	return &api.SharePortResponse{
+		Url:       shareURL.String(),
+		ExpiresAt: timestamppb.New(expires),
+	}, nil
+}
+
 // AutoTunnel controls enablement of auto tunneling
 func (s *portService) AutoTunnel(ctx context.Context, req *api.AutoTunnelRequest) (*api.AutoTunnelResponse, error) {
 	s.portsManager.AutoTunnel(ctx, req.Enabled)
@@ -43,11 +43,14 @@ import (
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	"github.com/gitpod-io/gitpod/content-service/pkg/executor"
 	"github.com/gitpod-io/gitpod/content-service/pkg/initializer"
+	"github.com/gitpod-io/gitpod/content-service/pkg/logs"
 	gitpod "github.com/gitpod-io/gitpod/gitpod-protocol"
 	"github.com/gitpod-io/gitpod/supervisor/api"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/activation"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/dropwriter"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/exitstatus"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/sshd"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/terminal"
 
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
@@ -175,8 +178,11 @@ func Run(options ...RunOption) {
 		gitpodConfigService = gitpod.NewConfigService(cfg.RepoRoot+"/.gitpod.yml", cstate.ContentReady(), log.Log)
 		portMgmt            = ports.NewManager(
 			createExposedPortsImpl(cfg, gitpodService),
-			&ports.PollingServedPortsObserver{
+			&ports.NetlinkServedPortsObserver{
 				RefreshInterval: 2 * time.Second,
+				Fallback: &ports.PollingServedPortsObserver{
+					RefreshInterval: 2 * time.Second,
+				},
 			},
 			ports.NewConfigService(cfg.WorkspaceID, gitpodConfigService, gitpodService),
 			tunneledPortsService,
@@ -195,6 +201,9 @@ func Run(options ...RunOption) {
 	defer analytics.Close()
 	go analyseConfigChanges(ctx, cfg, analytics, gitpodConfigService)
 
+	if cfg.TerminalRecording || cfg.isHeadless() {
+		termMux.RecordingLocation = filepath.Join(logs.TerminalStoreLocation, "recordings")
+	}
 	termMuxSrv.DefaultWorkdir = cfg.RepoRoot
 	if cfg.WorkspaceRoot != "" {
 		termMuxSrv.DefaultWorkdirProvider = func() string {
@@ -226,7 +235,8 @@ func Run(options ...RunOption) {
 		notificationService,
 		&InfoService{cfg: cfg, ContentState: cstate},
 		&ControlService{portsManager: portMgmt},
-		&portService{portsManager: portMgmt},
+		&portService{portsManager: portMgmt, portShareSecret: cfg.PortShareSecret},
+		&taskService{tasksManager: taskManager},
 	}
 	apiServices = append(apiServices, additionalServices...)
 
@@ -250,7 +260,7 @@ func Run(options ...RunOption) {
 	wg.Add(1)
 	go startAPIEndpoint(ctx, cfg, &wg, apiServices, tunneledPortsService, apiEndpointOpts...)
 	wg.Add(1)
-	go startSSHServer(ctx, cfg, &wg)
+	go startSSHServer(ctx, cfg, &wg, cstate, portMgmt, gitpodService)
 	wg.Add(1)
 	tasksSuccessChan := make(chan taskSuccess, 1)
 	go taskManager.Run(ctx, &wg, tasksSuccessChan)
@@ -313,6 +323,7 @@ func createGitpodService(cfg *Config, tknsrv api.TokenServiceServer) *gitpod.API
 			"function:openPort",
 			"function:getOpenPorts",
 			"function:guessGitTokenScopes",
+			"function:getSSHPublicKeys",
 		},
 	})
 	if err != nil {
@@ -440,10 +451,11 @@ func reaper(terminatingReaper <-chan bool) {
 		}
 
 		// wait on the process, hence remove it from the process table
-		pid, err := unix.Wait4(-1, nil, 0, nil)
+		var status unix.WaitStatus
+		pid, err := unix.Wait4(-1, &status, 0, nil)
 		// if we've been interrupted, try again until we're done
 		for err == syscall.EINTR {
-			pid, err = unix.Wait4(-1, nil, 0, nil)
+			pid, err = unix.Wait4(-1, &status, 0, nil)
 		}
 		if err == unix.ECHILD {
 			// The calling process does not have any unwaited-for children.
@@ -453,6 +465,10 @@ func reaper(terminatingReaper <-chan bool) {
 		if err != nil {
 			log.WithField("pid", pid).WithError(err).Debug("cannot call waitpid() for re-parented child")
 		}
+		if pid > 0 {
+			// the process might have been started by us, e.g. for an SSH session, which still wants to learn its exit status
+			exitstatus.Record(pid, syscall.WaitStatus(status))
+		}
 
 		if !terminating {
 			continue
@@ -824,7 +840,7 @@ func tunnelOverWebSocket(tunneled *ports.TunneledPortsService, conn *gitpod.Webs
 		conn.Wait()
 		sshConn.Close()
 	}()
-	go ssh.DiscardRequests(reqs)
+	go handleReverseTunnelOffers(tunneled, sshConn, reqs)
 	go func() {
 		for ch := range chans {
 			go tunnelOverSSH(conn.Ctx, tunneled, ch)
@@ -836,6 +852,57 @@ func tunnelOverWebSocket(tunneled *ports.TunneledPortsService, conn *gitpod.Webs
 	}
 }
 
+// handleReverseTunnelOffers registers the ports offered by the client of a tunnel connection until it is closed
+func handleReverseTunnelOffers(tunneled *ports.TunneledPortsService, sshConn ssh.Conn, reqs <-chan *ssh.Request) {
+	// offers are keyed by the connection, s.t. clients cannot replace the offers of other clients
+	dialer := &sshReverseTunnelDialer{conn: sshConn}
+	defer tunneled.OfferPorts(dialer, "")
+
+	for req := range reqs {
+		if req.Type != "offer-ports" {
+			if req.WantReply {
+				_ = req.Reply(false, nil)
+			}
+			continue
+		}
+		offer := &api.ReverseTunnelOffer{}
+		err := proto.Unmarshal(req.Payload, offer)
+		if err != nil || offer.ClientId == "" {
+			log.WithError(err).Error("tunnel: invalid reverse tunnel offer")
+			if req.WantReply {
+				_ = req.Reply(false, nil)
+			}
+			continue
+		}
+		log.WithField("id", offer.ClientId).WithField("ports", offer.Ports).Debug("tunnel: client offers ports for reverse tunnels")
+		tunneled.OfferPorts(dialer, offer.ClientId, offer.Ports...)
+		if req.WantReply {
+			_ = req.Reply(true, nil)
+		}
+	}
+}
+
+// sshReverseTunnelDialer opens reverse tunnels as channels on the ssh connection of a client
+type sshReverseTunnelDialer struct {
+	conn ssh.Conn
+}
+
+func (d *sshReverseTunnelDialer) DialReverse(localPort uint32, targetPort uint32) (io.ReadWriteCloser, error) {
+	payload, err := proto.Marshal(&api.TunnelPortRequest{
+		Port:       localPort,
+		TargetPort: targetPort,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sshChan, reqs, err := d.conn.OpenChannel("reverse-tunnel", payload)
+	if err != nil {
+		return nil, err
+	}
+	go ssh.DiscardRequests(reqs)
+	return sshChan, nil
+}
+
 func generateHostKey() (ssh.Signer, error) {
 	key, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
@@ -853,7 +920,7 @@ func tunnelOverSSH(ctx context.Context, tunneled *ports.TunneledPortsService, ne
 		return
 	}
 
-	tunnel, err := tunneled.EstablishTunnel(ctx, tunnelReq.ClientId, tunnelReq.Port, tunnelReq.TargetPort)
+	tunnel, err := tunneled.EstablishTunnel(ctx, tunnelReq.ClientId, tunnelReq.Protocol, tunnelReq.Port, tunnelReq.TargetPort)
 	if err != nil {
 		log.WithError(err).Error("tunnel: failed to establish")
 		newCh.Reject(ssh.Prohibited, err.Error())
@@ -898,79 +965,92 @@ func stopWhenTasksAreDone(ctx context.Context, wg *sync.WaitGroup, shutdown chan
 	shutdown <- ShutdownReasonSuccess
 }
 
-func startSSHServer(ctx context.Context, cfg *Config, wg *sync.WaitGroup) {
+// sshHostKeyLocation is where we persist the host keys of the SSH server. The keys are named after the
+// workspace, s.t. workspaces started from a prebuild don't share the host key of the prebuild.
+// The workspace user controls this location, see sshd.LoadOrGenerateHostKey for how we deal with that.
+const sshHostKeyLocation = "/workspace/.gitpod/ssh"
+
+// ownerSSHKeysTTL is how long we cache the SSH keys the workspace owner registered with Gitpod
+const ownerSSHKeysTTL = 1 * time.Minute
+
+func startSSHServer(ctx context.Context, cfg *Config, wg *sync.WaitGroup, cst ContentState, portMgmt *ports.Manager, gitpodService *gitpod.APIoverJSONRPC) {
 	defer wg.Done()
 
-	bin, err := os.Executable()
-	if err != nil {
-		log.WithError(err).Error("cannot find executable path")
-		return
-	}
-	dropbear := filepath.Join(filepath.Dir(bin), "dropbear", "dropbear")
-	if _, err := os.Stat(dropbear); err != nil {
-		log.WithError(err).WithField("path", dropbear).Error("cannot locate dropebar binary")
-		return
-	}
-	dropbearkey := filepath.Join(filepath.Dir(bin), "dropbear", "dropbearkey")
-	if _, err := os.Stat(dropbearkey); err != nil {
-		log.WithError(err).WithField("path", dropbearkey).Error("cannot locate dropebarkey")
+	// the host key lives in the workspace content - we must not write it before the content is initialized
+	select {
+	case <-ctx.Done():
 		return
+	case <-cst.ContentReady():
 	}
 
-	hostkeyFN, err := ioutil.TempFile("", "hostkey")
+	hostKey, err := sshd.LoadOrGenerateHostKey(filepath.Join(sshHostKeyLocation, "host-key-"+cfg.WorkspaceID))
 	if err != nil {
-		log.WithError(err).Error("cannot create hostkey file")
+		log.WithError(err).Error("cannot load SSH host key")
 		return
 	}
-	hostkeyFN.Close()
-	os.Remove(hostkeyFN.Name())
-
-	keycmd := exec.Command(dropbearkey, "-t", "rsa", "-f", hostkeyFN.Name())
-	// We need to force HOME because the Gitpod user might not have existed at the start of the container
-	// which makes the container runtime set an invalid HOME value.
-	keycmd.Env = func() []string {
-		env := os.Environ()
-		res := make([]string, 0, len(env))
-		for _, e := range env {
-			if strings.HasPrefix(e, "HOME=") {
-				e = "HOME=/root"
-			}
-			res = append(res, e)
-		}
-		return res
-	}()
-	out, err := keycmd.CombinedOutput()
-	if err != nil {
-		log.WithError(err).WithField("out", string(out)).Error("cannot create hostkey file")
-		return
+
+	srv := sshd.New(hostKey)
+	srv.AuthorizedKeysFiles = []string{"/home/gitpod/.ssh/authorized_keys"}
+	if gitpodService != nil {
+		srv.AuthorizedKeys = ownerSSHKeys(gitpodService)
+	} else {
+		log.Warn("SSH keys registered with Gitpod cannot log in")
+	}
+	srv.GatewayToken = cfg.SSHGatewayToken
+	srv.Env = buildChildProcEnv(cfg, nil)
+	srv.Creds = &syscall.Credential{
+		Uid: gitpodUID,
+		Gid: gitpodGID,
+	}
+	srv.Ports = portMgmt
+	if bin, err := os.Executable(); err == nil {
+		srv.SFTPServer = []string{bin, "sftp-server"}
+	} else {
+		log.WithError(err).Warn("cannot find executable path - SFTP is not available")
 	}
-	_ = os.Chown(hostkeyFN.Name(), gitpodUID, gitpodGID)
 
-	cmd := exec.Command(dropbear, "-F", "-E", "-w", "-s", "-p", fmt.Sprintf(":%d", cfg.SSHPort), "-r", hostkeyFN.Name())
-	cmd = runAsGitpodUser(cmd)
-	cmd.Env = buildChildProcEnv(cfg, nil)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	err = cmd.Start()
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.SSHPort))
 	if err != nil {
 		log.WithError(err).Error("cannot start SSH server")
 		return
 	}
+	log.WithField("fingerprint", ssh.FingerprintSHA256(hostKey.PublicKey())).Info("SSH server is listening")
+	err = srv.Serve(ctx, l)
+	if err != nil {
+		log.WithError(err).Error("SSH server stopped")
+	}
+}
 
-	done := make(chan error, 1)
-	go func() {
-		done <- cmd.Wait()
-	}()
-	select {
-	case <-ctx.Done():
-		if cmd.Process != nil {
-			cmd.Process.Kill()
+// ownerSSHKeys returns the SSH keys the workspace owner registered with Gitpod, cached for ownerSSHKeysTTL
+func ownerSSHKeys(gitpodService gitpod.APIInterface) func(ctx context.Context) ([]ssh.PublicKey, error) {
+	var (
+		mu      sync.Mutex
+		keys    []ssh.PublicKey
+		fetched time.Time
+	)
+	return func(ctx context.Context) ([]ssh.PublicKey, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !fetched.IsZero() && time.Since(fetched) < ownerSSHKeysTTL {
+			return keys, nil
 		}
-		return
-	case err = <-done:
+
+		res, err := gitpodService.GetSSHPublicKeys(ctx)
 		if err != nil {
-			log.WithError(err).Error("SSH server stopped")
+			// keep serving the keys we know until the server is back
+			return keys, err
+		}
+		keys = make([]ssh.PublicKey, 0, len(res))
+		for _, k := range res {
+			key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(k.Key))
+			if err != nil {
+				log.WithError(err).WithField("name", k.Name).Warn("ignoring invalid SSH key")
+				continue
+			}
+			keys = append(keys, key)
 		}
+		fetched = time.Now()
+		return keys, nil
 	}
 }
 
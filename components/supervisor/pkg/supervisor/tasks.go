@@ -11,16 +11,24 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/gitpod-io/gitpod/common-go/log"
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	"github.com/gitpod-io/gitpod/content-service/pkg/logs"
 	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/terminal"
 )
 
@@ -69,6 +77,45 @@ type task struct {
 	successChan chan taskSuccess
 	title       string
 	lastOutput  string
+
+	// deps are the tasks this task depends on
+	deps []*task
+	// started is closed once the task is running or closed
+	started     chan struct{}
+	startedOnce sync.Once
+	// done is closed once the task is closed, result is its outcome then
+	done       chan struct{}
+	result     taskSuccess
+	finishOnce sync.Once
+
+	// restartPolicy determines if the task is started again once it closed
+	restartPolicy TaskRestartPolicy
+	// failures is the number of consecutive short-lived runs, which determines the restart backoff
+	failures int
+	// lastResult is the outcome of the last run of the task
+	lastResult taskSuccess
+	// rerun is true once the task is started again after it closed. Only its before and command are run then.
+	rerun bool
+
+	// stopRequested, restartRequested and cancelRestart are guarded by tasksManager.mu
+	stopRequested    bool
+	restartRequested bool
+	cancelRestart    context.CancelFunc
+}
+
+func (t *task) markStarted() {
+	t.startedOnce.Do(func() { close(t.started) })
+}
+
+// finish records the outcome of a task once it closed for the first time without being restarted.
+// Later outcomes of the task are ignored.
+func (t *task) finish(result taskSuccess) {
+	t.finishOnce.Do(func() {
+		t.result = result
+		t.successChan <- result
+		t.markStarted()
+		close(t.done)
+	})
 }
 
 type headlessTaskProgressReporter interface {
@@ -96,6 +143,7 @@ func taskFailed(msg string) taskSuccess {
 }
 
 type tasksManager struct {
+	ctx             context.Context
 	config          *Config
 	storeLocation   string
 	contentSource   csapi.WorkspaceInitSource
@@ -217,99 +265,134 @@ func (tm *tasksManager) init(ctx context.Context) {
 			config:      config,
 			successChan: make(chan taskSuccess, 1),
 			title:       title,
+			started:     make(chan struct{}),
+			done:        make(chan struct{}),
 		}
+		task.restartPolicy = getRestartPolicy(config)
 		task.command = getCommand(task, tm.config.isHeadless(), tm.contentSource, tm.storeLocation)
 		if tm.config.isHeadless() && task.command == "exit" {
 			task.State = api.TaskState_closed
-			task.successChan <- taskSuccessful
+			task.finish(taskSuccessful)
 		}
 		tm.tasks = append(tm.tasks, task)
 	}
-}
-
-func (tm *tasksManager) Run(ctx context.Context, wg *sync.WaitGroup, successChan chan taskSuccess) {
-	defer wg.Done()
-	defer log.Debug("tasksManager shutdown")
-
-	tm.init(ctx)
 
+	tm.resolveDependencies()
 	for _, t := range tm.tasks {
 		if t.State == api.TaskState_closed {
 			continue
 		}
-		taskLog := log.WithField("command", t.command)
-		taskLog.Info("starting a task terminal...")
-		openRequest := &api.OpenTerminalRequest{}
-		if t.config.Env != nil {
-			openRequest.Env = make(map[string]string, len(*t.config.Env))
-			for key, value := range *t.config.Env {
-				v, err := json.Marshal(value)
-				if err != nil {
-					taskLog.WithError(err).WithField("key", key).Error("cannot marshal env var")
-				} else {
-					openRequest.Env[key] = string(v)
-				}
-			}
+		if len(t.deps) > 0 || tm.waitsFor(t) {
+			t.State = api.TaskState_waiting
 		}
-		var readTimeout time.Duration
-		if !tm.config.isHeadless() {
-			readTimeout = 5 * time.Second
+	}
+}
+
+// resolveDependencies resolves the task names in dependsOn to tasks and validates that the
+// resulting graph is acyclic. Tasks which are part of a cycle are closed as failed.
+func (tm *tasksManager) resolveDependencies() {
+	byName := make(map[string][]*task)
+	for _, t := range tm.tasks {
+		if t.config.Name != nil {
+			byName[*t.config.Name] = append(byName[*t.config.Name], t)
 		}
-		resp, err := tm.terminalService.OpenWithOptions(ctx, openRequest, terminal.TermOptions{
-			ReadTimeout: readTimeout,
-			Title:       t.title,
-		})
-		if err != nil {
-			taskLog.WithError(err).Error("cannot open new task terminal")
-			t.successChan <- taskFailed("cannot open new task terminal")
-			tm.setTaskState(t, api.TaskState_closed)
+	}
+	for _, t := range tm.tasks {
+		if t.config.DependsOn == nil {
 			continue
 		}
+		for _, name := range *t.config.DependsOn {
+			deps, ok := byName[name]
+			if !ok {
+				log.WithField("task", t.title).WithField("dependsOn", name).Warn("task depends on unknown task - ignoring the dependency")
+				continue
+			}
+			t.deps = append(t.deps, deps...)
+		}
+	}
 
-		taskLog = taskLog.WithField("terminal", resp.Terminal.Alias)
-		term, ok := tm.terminalService.Mux.Get(resp.Terminal.Alias)
-		if !ok {
-			taskLog.Error("cannot find a task terminal")
-			t.successChan <- taskFailed("cannot find a task terminal")
-			tm.setTaskState(t, api.TaskState_closed)
-			continue
+	for _, cycle := range findDependencyCycles(tm.tasks) {
+		names := make([]string, 0, len(cycle)+1)
+		for _, t := range cycle {
+			names = append(names, t.title)
 		}
+		names = append(names, cycle[0].title)
+		msg := "task dependencies form a cycle: " + strings.Join(names, " -> ")
+		log.WithField("tasks", names).Error(msg)
 
-		taskLog = taskLog.WithField("pid", term.Command.Process.Pid)
-		taskLog.Info("task terminal has been started")
-		tm.updateState(func() bool {
-			t.Terminal = resp.Terminal.Alias
-			t.State = api.TaskState_running
-			return true
-		})
-
-		go func(t *task, term *terminal.Term) {
-			state, err := term.Wait()
-			if state != nil {
-				if state.Success() {
-					t.successChan <- taskSuccessful
-				} else {
-					t.successChan <- taskFailed(state.String())
-				}
-			} else if err != nil && strings.Contains(err.Error(), "no child process") {
-				// our own reaper broke Go's child process handling
-				t.successChan <- taskSuccessful
-			} else {
-				msg := "cannot wait for task"
-				if err != nil {
-					msg = err.Error()
-				}
+		for _, t := range cycle {
+			if t.State == api.TaskState_closed {
+				continue
+			}
+			t.State = api.TaskState_closed
+			t.finish(taskFailed(msg))
+		}
+	}
+}
 
-				t.successChan <- taskFailed(fmt.Sprintf("%s: %s", msg, t.lastOutput))
+// findDependencyCycles returns the cycles in the dependency graph of tasks
+func findDependencyCycles(tasks []*task) (cycles [][]*task) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var (
+		state = make(map[*task]int, len(tasks))
+		path  []*task
+		visit func(t *task)
+	)
+	visit = func(t *task) {
+		state[t] = visiting
+		path = append(path, t)
+		for _, dep := range t.deps {
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				for i := len(path) - 1; i >= 0; i-- {
+					if path[i] == dep {
+						cycles = append(cycles, append([]*task(nil), path[i:]...))
+						break
+					}
+				}
 			}
-			taskLog.Info("task terminal has been closed")
-			tm.setTaskState(t, api.TaskState_closed)
-		}(t, term)
+		}
+		path = path[:len(path)-1]
+		state[t] = visited
+	}
+	for _, t := range tasks {
+		if state[t] == unvisited {
+			visit(t)
+		}
+	}
+	return cycles
+}
+
+// waitsFor returns true if the task has readiness gates. Prebuilds do not wait for readiness gates.
+func (tm *tasksManager) waitsFor(t *task) bool {
+	if tm.config.isHeadless() || t.config.WaitFor == nil {
+		return false
+	}
+	w := t.config.WaitFor
+	return w.Port != nil || w.File != nil || w.Command != nil
+}
+
+func (tm *tasksManager) Run(ctx context.Context, wg *sync.WaitGroup, successChan chan taskSuccess) {
+	defer wg.Done()
+	defer log.Debug("tasksManager shutdown")
 
-		tm.watch(t, term)
+	tm.ctx = ctx
+	tm.init(ctx)
 
-		if t.command != "" {
-			term.PTY.Write([]byte(t.command + "\n"))
+	for _, t := range tm.tasks {
+		switch t.State {
+		case api.TaskState_closed:
+			continue
+		case api.TaskState_waiting:
+			go tm.startWhenReady(ctx, t)
+		default:
+			tm.startTask(ctx, t)
 		}
 	}
 
@@ -331,6 +414,432 @@ func (tm *tasksManager) Run(ctx context.Context, wg *sync.WaitGroup, successChan
 	successChan <- success
 }
 
+// startTask opens the terminal of a task and runs its command in it
+func (tm *tasksManager) startTask(ctx context.Context, t *task) {
+	taskLog := log.WithField("command", t.command)
+	taskLog.Info("starting a task terminal...")
+	openRequest := &api.OpenTerminalRequest{}
+	if t.config.Env != nil {
+		openRequest.Env = make(map[string]string, len(*t.config.Env))
+		for key, value := range *t.config.Env {
+			v, err := json.Marshal(value)
+			if err != nil {
+				taskLog.WithError(err).WithField("key", key).Error("cannot marshal env var")
+			} else {
+				openRequest.Env[key] = string(v)
+			}
+		}
+	}
+	var readTimeout time.Duration
+	if !tm.config.isHeadless() {
+		readTimeout = 5 * time.Second
+	}
+	resp, err := tm.terminalService.OpenWithOptions(ctx, openRequest, terminal.TermOptions{
+		ReadTimeout: readTimeout,
+		Title:       t.title,
+	})
+	if err != nil {
+		taskLog.WithError(err).Error("cannot open new task terminal")
+		tm.closeTask(ctx, t, taskFailed("cannot open new task terminal"), -1)
+		return
+	}
+
+	taskLog = taskLog.WithField("terminal", resp.Terminal.Alias)
+	term, ok := tm.terminalService.Mux.Get(resp.Terminal.Alias)
+	if !ok {
+		taskLog.Error("cannot find a task terminal")
+		tm.closeTask(ctx, t, taskFailed("cannot find a task terminal"), -1)
+		return
+	}
+
+	taskLog = taskLog.WithField("pid", term.Command.Process.Pid)
+	taskLog.Info("task terminal has been started")
+	tm.updateState(func() bool {
+		t.Terminal = resp.Terminal.Alias
+		t.State = api.TaskState_running
+		t.StartedAt = timestamppb.Now()
+		t.EndedAt = nil
+		t.ExitCode = 0
+		return true
+	})
+	t.markStarted()
+
+	go func(t *task, term *terminal.Term) {
+		var (
+			result   taskSuccess
+			exitCode int32
+		)
+		state, err := term.Wait()
+		if state != nil {
+			exitCode = int32(state.ExitCode())
+			if !state.Success() {
+				result = taskFailed(state.String())
+			}
+		} else if err != nil && strings.Contains(err.Error(), "no child process") {
+			// our own reaper broke Go's child process handling
+		} else {
+			msg := "cannot wait for task"
+			if err != nil {
+				msg = err.Error()
+			}
+
+			result = taskFailed(fmt.Sprintf("%s: %s", msg, t.lastOutput))
+			exitCode = -1
+		}
+		taskLog.Info("task terminal has been closed")
+		tm.closeTask(ctx, t, result, exitCode)
+	}(t, term)
+
+	tm.watch(t, term)
+
+	if t.command != "" {
+		term.PTY.Write([]byte(t.command + "\n"))
+	}
+}
+
+const maxRestartBackoff = 1 * time.Minute
+
+// restartBackoff returns the time to wait before restarting a task after a number of consecutive short-lived runs
+func restartBackoff(failures int) time.Duration {
+	if failures > 6 {
+		return maxRestartBackoff
+	}
+	backoff := time.Second << failures
+	if backoff > maxRestartBackoff {
+		return maxRestartBackoff
+	}
+	return backoff
+}
+
+func getRestartPolicy(config TaskConfig) TaskRestartPolicy {
+	if config.Restart == nil {
+		return TaskRestartNever
+	}
+	switch p := *config.Restart; p {
+	case TaskRestartNever, TaskRestartOnFailure, TaskRestartAlways:
+		return p
+	default:
+		log.WithField("restart", p).Warn("unknown task restart policy - never restarting the task")
+		return TaskRestartNever
+	}
+}
+
+// closeTask records the end of a run of a task and restarts the task if its restart policy or a restart request says so
+func (tm *tasksManager) closeTask(ctx context.Context, t *task, result taskSuccess, exitCode int32) {
+	var (
+		restart bool
+		delay   time.Duration
+		rctx    context.Context
+	)
+	tm.updateState(func() bool {
+		t.State = api.TaskState_closed
+		t.ExitCode = exitCode
+		t.EndedAt = timestamppb.Now()
+		t.lastResult = result
+
+		switch {
+		case t.restartRequested:
+			restart = true
+		case t.stopRequested || tm.config.isHeadless():
+			restart = false
+		case t.restartPolicy == TaskRestartAlways:
+			restart = true
+		case t.restartPolicy == TaskRestartOnFailure:
+			restart = result.Failed()
+		}
+		if restart && !t.restartRequested {
+			if t.StartedAt != nil && t.EndedAt.AsTime().Sub(t.StartedAt.AsTime()) >= maxRestartBackoff {
+				t.failures = 0
+			}
+			delay = restartBackoff(t.failures)
+			t.failures++
+		}
+		t.stopRequested = false
+		t.restartRequested = false
+
+		if restart {
+			t.State = api.TaskState_waiting
+			rctx, t.cancelRestart = context.WithCancel(ctx)
+		}
+		return true
+	})
+	if !restart {
+		t.finish(result)
+		return
+	}
+
+	log.WithField("task", t.Id).WithField("delay", delay.String()).Info("restarting task")
+	go tm.restartTask(rctx, t, delay)
+}
+
+// restartTask starts a closed task again after a delay, unless the restart is canceled in the meantime
+func (tm *tasksManager) restartTask(ctx context.Context, t *task, delay time.Duration) {
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+	}
+
+	var canceled bool
+	tm.updateState(func() bool {
+		// the restart could have been canceled while we were waiting for the lock
+		if ctx.Err() != nil {
+			canceled = true
+			return false
+		}
+		t.cancelRestart = nil
+		t.State = api.TaskState_opening
+		t.RestartCount++
+		return true
+	})
+	if canceled {
+		return
+	}
+
+	tm.rerunTask(t)
+}
+
+// rerunTask starts a task which ran before again. Only its before and command are run.
+func (tm *tasksManager) rerunTask(t *task) {
+	t.rerun = true
+	t.command = getCommand(t, tm.config.isHeadless(), tm.contentSource, tm.storeLocation)
+	tm.startTask(tm.ctx, t)
+}
+
+// getTask returns the task with the given ID, once tasks are initialized
+func (tm *tasksManager) getTask(id string) (*task, error) {
+	select {
+	case <-tm.ready:
+	default:
+		return nil, status.Error(codes.Unavailable, "tasks are not initialized yet")
+	}
+	if tm.config.isHeadless() {
+		return nil, status.Error(codes.FailedPrecondition, "tasks cannot be controlled in prebuilds")
+	}
+	for _, t := range tm.tasks {
+		if t.Id == id {
+			return t, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "task %s not found", id)
+}
+
+// StartTask starts a closed task again
+func (tm *tasksManager) StartTask(id string) error {
+	t, err := tm.getTask(id)
+	if err != nil {
+		return err
+	}
+
+	var start bool
+	tm.updateState(func() bool {
+		if t.State != api.TaskState_closed {
+			return false
+		}
+		t.State = api.TaskState_opening
+		t.RestartCount++
+		t.failures = 0
+		start = true
+		return true
+	})
+	if !start {
+		return status.Errorf(codes.FailedPrecondition, "task %s is not closed", id)
+	}
+
+	tm.rerunTask(t)
+	return nil
+}
+
+// StopTask ends a running task or cancels its pending restart. The task is not restarted by its restart policy.
+func (tm *tasksManager) StopTask(id string, gracePeriod time.Duration) error {
+	t, err := tm.getTask(id)
+	if err != nil {
+		return err
+	}
+
+	var (
+		alias  string
+		cancel context.CancelFunc
+	)
+	tm.updateState(func() bool {
+		switch {
+		case t.State == api.TaskState_running:
+			t.stopRequested = true
+			alias = t.Terminal
+			return false
+		case t.State == api.TaskState_waiting && t.cancelRestart != nil:
+			cancel = t.cancelRestart
+			t.cancelRestart = nil
+			t.State = api.TaskState_closed
+			return true
+		default:
+			return false
+		}
+	})
+	if cancel != nil {
+		cancel()
+		t.finish(t.lastResult)
+		return nil
+	}
+	if alias == "" {
+		return status.Errorf(codes.FailedPrecondition, "task %s is not running", id)
+	}
+	return tm.closeTerminal(alias, gracePeriod)
+}
+
+// RestartTask stops a running task and starts it again right away. Closed tasks are started.
+func (tm *tasksManager) RestartTask(id string, gracePeriod time.Duration) error {
+	t, err := tm.getTask(id)
+	if err != nil {
+		return err
+	}
+
+	var (
+		alias string
+		start bool
+	)
+	tm.updateState(func() bool {
+		switch {
+		case t.State == api.TaskState_running:
+			t.restartRequested = true
+			alias = t.Terminal
+			return false
+		case t.State == api.TaskState_closed, t.State == api.TaskState_waiting && t.cancelRestart != nil:
+			if t.cancelRestart != nil {
+				t.cancelRestart()
+				t.cancelRestart = nil
+			}
+			t.State = api.TaskState_opening
+			t.RestartCount++
+			t.failures = 0
+			start = true
+			return true
+		default:
+			return false
+		}
+	})
+	if start {
+		tm.rerunTask(t)
+		return nil
+	}
+	if alias == "" {
+		return status.Errorf(codes.FailedPrecondition, "task %s is neither running nor closed", id)
+	}
+	return tm.closeTerminal(alias, gracePeriod)
+}
+
+func (tm *tasksManager) closeTerminal(alias string, gracePeriod time.Duration) error {
+	err := tm.terminalService.Mux.CloseTerminal(alias, gracePeriod)
+	if err == terminal.ErrNotFound {
+		// the terminal closed in the meantime
+		return nil
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+// startWhenReady starts a task once its dependencies and readiness gates allow it to.
+// In prebuilds a task waits for its dependencies to complete and fails if any of them failed.
+func (tm *tasksManager) startWhenReady(ctx context.Context, t *task) {
+	canceled := func() {
+		// waiters must learn that this task is not going to run
+		t.finish(taskFailed("canceled before the task started"))
+		tm.setTaskState(t, api.TaskState_closed)
+	}
+
+	for _, dep := range t.deps {
+		if tm.config.isHeadless() {
+			select {
+			case <-ctx.Done():
+				canceled()
+				return
+			case <-dep.done:
+			}
+			if dep.result.Failed() {
+				t.finish(taskFailed(fmt.Sprintf("dependency %s failed", dep.title)))
+				tm.setTaskState(t, api.TaskState_closed)
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			canceled()
+			return
+		case <-dep.started:
+		}
+	}
+
+	if tm.waitsFor(t) {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for !tm.isReady(ctx, t) {
+			select {
+			case <-ctx.Done():
+				canceled()
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+
+	tm.startTask(ctx, t)
+}
+
+// waitForCommandTimeout is the time a wait-for command may take before it is killed and considered failed
+const waitForCommandTimeout = 10 * time.Second
+
+// isReady returns true if all readiness gates of a task are met
+func (tm *tasksManager) isReady(ctx context.Context, t *task) bool {
+	w := t.config.WaitFor
+	if w.Port != nil {
+		served, err := ports.IsServed(*w.Port)
+		if err != nil {
+			log.WithError(err).WithField("port", *w.Port).Warn("cannot determine if port is served")
+		}
+		if !served {
+			return false
+		}
+	}
+	if w.File != nil {
+		fn := *w.File
+		if !filepath.IsAbs(fn) {
+			fn = filepath.Join(tm.terminalService.DefaultWorkdir, fn)
+		}
+		if _, err := os.Stat(fn); err != nil {
+			return false
+		}
+	}
+	if w.Command != nil {
+		ctx, cancel := context.WithTimeout(ctx, waitForCommandTimeout)
+		defer cancel()
+
+		cmd := exec.CommandContext(ctx, "/bin/sh", "-c", *w.Command)
+		cmd.Dir = tm.terminalService.DefaultWorkdir
+		cmd.Env = tm.terminalService.Env
+		if tm.terminalService.DefaultCreds != nil {
+			cmd.SysProcAttr = &syscall.SysProcAttr{
+				Credential: tm.terminalService.DefaultCreds,
+			}
+		}
+		if err := cmd.Run(); err != nil {
+			if strings.Contains(err.Error(), "no child process") {
+				// our own reaper broke Go's child process handling, hence the exit status is unknown - try again next time
+				log.WithField("command", *w.Command).Debug("cannot determine exit status of wait-for command")
+			}
+			return false
+		}
+	}
+	return true
+}
+
 func getCommand(task *task, isHeadless bool, contentSource csapi.WorkspaceInitSource, storeLocation string) string {
 	commands := getCommands(task, isHeadless, contentSource, storeLocation)
 	command := composeCommand(composeCommandOptions{
@@ -352,6 +861,10 @@ func getCommand(task *task, isHeadless bool, contentSource csapi.WorkspaceInitSo
 	if strings.TrimSpace(command) == "" {
 		return histfileCommand
 	}
+	if task.restartPolicy != "" && task.restartPolicy != TaskRestartNever {
+		// the terminal needs to close with the command for the task to be restarted
+		command += "; exit"
+	}
 	if histfileCommand == "" {
 		return command
 	}
@@ -388,6 +901,10 @@ func getCommands(task *task, isHeadless bool, contentSource csapi.WorkspaceInitS
 		// prebuild
 		return []*string{task.config.Before, task.config.Init, task.config.Prebuild}
 	}
+	if task.rerun {
+		// started again after it closed
+		return []*string{task.config.Before, task.config.Command}
+	}
 	if contentSource == csapi.WorkspaceInitFromPrebuild {
 		// prebuilt
 		prebuildLogFileName := prebuildLogFileName(task, storeLocation)
@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
@@ -24,6 +25,9 @@ import (
 var skipCommand = "echo \"skip\""
 var failCommand = "exit 1"
 
+var taskA = "a"
+var taskB = "b"
+
 var testEnv = &map[string]interface{}{
 	"object": map[string]interface{}{"baz": 3},
 }
@@ -115,6 +119,61 @@ func TestTaskManager(t *testing.T) {
 				Success: true,
 			},
 		},
+		{
+			Desc:     "headless prebuild should run dependent tasks",
+			Headless: true,
+			Source:   csapi.WorkspaceInitFromOther,
+			GitpodTasks: &[]TaskConfig{
+				{Name: &taskB, Init: &skipCommand, DependsOn: &[]string{taskA}},
+				{Name: &taskA, Init: &skipCommand},
+			},
+
+			ExpectedReporter: testHeadlessTaskProgressReporter{
+				Done:    true,
+				Success: true,
+			},
+		},
+		{
+			Desc:     "headless prebuild should fail dependents of failed tasks",
+			Headless: true,
+			Source:   csapi.WorkspaceInitFromOther,
+			GitpodTasks: &[]TaskConfig{
+				{Name: &taskA, Init: &failCommand},
+				{Name: &taskB, Init: &skipCommand, DependsOn: &[]string{taskA}},
+			},
+
+			ExpectedReporter: testHeadlessTaskProgressReporter{
+				Done:    true,
+				Success: false,
+			},
+		},
+		{
+			Desc:     "headless prebuild should ignore unknown dependencies",
+			Headless: true,
+			Source:   csapi.WorkspaceInitFromOther,
+			GitpodTasks: &[]TaskConfig{
+				{Name: &taskA, Init: &skipCommand, DependsOn: &[]string{"unknown"}},
+			},
+
+			ExpectedReporter: testHeadlessTaskProgressReporter{
+				Done:    true,
+				Success: true,
+			},
+		},
+		{
+			Desc:     "headless prebuild should fail cyclic dependencies",
+			Headless: true,
+			Source:   csapi.WorkspaceInitFromOther,
+			GitpodTasks: &[]TaskConfig{
+				{Name: &taskA, Init: &skipCommand, DependsOn: &[]string{taskB}},
+				{Name: &taskB, Init: &skipCommand, DependsOn: &[]string{taskA}},
+			},
+
+			ExpectedReporter: testHeadlessTaskProgressReporter{
+				Done:    true,
+				Success: false,
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.Desc, func(t *testing.T) {
@@ -185,6 +244,8 @@ func TestGetTask(t *testing.T) {
 		Name          string
 		Task          TaskConfig
 		IsHeadless    bool
+		RestartPolicy TaskRestartPolicy
+		Rerun         bool
 		ContentSource csapi.WorkspaceInitSource
 		Expectation   string
 	}{
@@ -213,11 +274,33 @@ func TestGetTask(t *testing.T) {
 			ContentSource: csapi.WorkspaceInitFromOther,
 			Expectation:   "{\nbefore\n} && {\ninit\n} && {\ncommand\n}",
 		},
+		{
+			Name:          "restart",
+			Task:          TaskConfig{Before: allTasks.Before, Init: allTasks.Init, Command: allTasks.Command},
+			RestartPolicy: TaskRestartOnFailure,
+			ContentSource: csapi.WorkspaceInitFromBackup,
+			Expectation:   "{\nbefore\n} && {\ncommand\n}; exit",
+		},
+		{
+			Name:          "rerun",
+			Task:          allTasks,
+			Rerun:         true,
+			ContentSource: csapi.WorkspaceInitFromPrebuild,
+			Expectation:   "{\nbefore\n} && {\ncommand\n}",
+		},
+		{
+			Name:          "restart in prebuild",
+			Task:          allTasks,
+			RestartPolicy: TaskRestartAlways,
+			IsHeadless:    true,
+			ContentSource: csapi.WorkspaceInitFromOther,
+			Expectation:   "{\nbefore\n} && {\ninit\n} && {\nprebuild\n}; exit",
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
-			command := getCommand(&task{config: test.Task, TaskStatus: api.TaskStatus{Id: "0"}}, test.IsHeadless, test.ContentSource, "/")
+			command := getCommand(&task{config: test.Task, TaskStatus: api.TaskStatus{Id: "0"}, restartPolicy: test.RestartPolicy, rerun: test.Rerun}, test.IsHeadless, test.ContentSource, "/")
 			if diff := cmp.Diff(test.Expectation, command); diff != "" {
 				t.Errorf("unexpected getCommand() (-want +got):\n%s", diff)
 			}
@@ -270,3 +353,153 @@ func TestTaskSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestFindDependencyCycles(t *testing.T) {
+	tests := []struct {
+		Name        string
+		DependsOn   map[string][]string
+		Expectation [][]string
+	}{
+		{
+			Name:      "no cycle",
+			DependsOn: map[string][]string{"a": nil, "b": {"a"}, "c": {"a", "b"}},
+		},
+		{
+			Name:        "self dependency",
+			DependsOn:   map[string][]string{"a": {"a"}, "b": {"a"}},
+			Expectation: [][]string{{"a"}},
+		},
+		{
+			Name:        "cycle",
+			DependsOn:   map[string][]string{"a": {"c"}, "b": {"a"}, "c": {"b"}, "d": {"c"}},
+			Expectation: [][]string{{"a", "c", "b"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				tasks  []*task
+				byName = make(map[string]*task)
+			)
+			for _, name := range []string{"a", "b", "c", "d"} {
+				if _, ok := test.DependsOn[name]; !ok {
+					continue
+				}
+				tk := &task{title: name}
+				tasks = append(tasks, tk)
+				byName[name] = tk
+			}
+			for _, tk := range tasks {
+				for _, dep := range test.DependsOn[tk.title] {
+					tk.deps = append(tk.deps, byName[dep])
+				}
+			}
+
+			var act [][]string
+			for _, cycle := range findDependencyCycles(tasks) {
+				var names []string
+				for _, tk := range cycle {
+					names = append(names, tk.title)
+				}
+				act = append(act, names)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected findDependencyCycles() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRestartBackoff(t *testing.T) {
+	tests := []struct {
+		Failures    int
+		Expectation time.Duration
+	}{
+		{0, 1 * time.Second},
+		{1, 2 * time.Second},
+		{5, 32 * time.Second},
+		{6, maxRestartBackoff},
+		{100, maxRestartBackoff},
+	}
+	for _, test := range tests {
+		if act := restartBackoff(test.Failures); act != test.Expectation {
+			t.Errorf("restartBackoff(%d) = %v, expected %v", test.Failures, act, test.Expectation)
+		}
+	}
+}
+
+func TestCloseTask(t *testing.T) {
+	log.Log.Logger.SetLevel(logrus.FatalLevel)
+	tests := []struct {
+		Desc          string
+		Headless      bool
+		RestartPolicy TaskRestartPolicy
+		Result        taskSuccess
+
+		ExpectedState api.TaskState
+	}{
+		{Desc: "never", RestartPolicy: TaskRestartNever, Result: taskFailed("failed"), ExpectedState: api.TaskState_closed},
+		{Desc: "on-failure succeeded", RestartPolicy: TaskRestartOnFailure, ExpectedState: api.TaskState_closed},
+		{Desc: "on-failure failed", RestartPolicy: TaskRestartOnFailure, Result: taskFailed("failed"), ExpectedState: api.TaskState_waiting},
+		{Desc: "always", RestartPolicy: TaskRestartAlways, ExpectedState: api.TaskState_waiting},
+		{Desc: "prebuild", Headless: true, RestartPolicy: TaskRestartAlways, ExpectedState: api.TaskState_closed},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			tm := newTasksManager(&Config{
+				WorkspaceConfig: WorkspaceConfig{
+					GitpodHeadless: strconv.FormatBool(test.Headless),
+				},
+			}, terminal.NewMuxTerminalService(terminal.NewMux()), nil, nil)
+			tm.ctx = ctx
+			tk := &task{
+				TaskStatus:    api.TaskStatus{Id: "0", State: api.TaskState_running},
+				restartPolicy: test.RestartPolicy,
+				successChan:   make(chan taskSuccess, 1),
+				started:       make(chan struct{}),
+				done:          make(chan struct{}),
+			}
+			tm.tasks = append(tm.tasks, tk)
+			close(tm.ready)
+
+			tm.closeTask(ctx, tk, test.Result, 1)
+			if tk.State != test.ExpectedState {
+				t.Fatalf("unexpected task state: got %v, expected %v", tk.State, test.ExpectedState)
+			}
+			if tk.ExitCode != 1 || tk.EndedAt == nil {
+				t.Errorf("task end was not recorded: exit code %d, ended at %v", tk.ExitCode, tk.EndedAt)
+			}
+			if test.ExpectedState == api.TaskState_closed {
+				select {
+				case <-tk.done:
+				default:
+					t.Fatalf("closed task did not finish")
+				}
+				return
+			}
+
+			// stopping a task cancels its pending restart
+			err := tm.StopTask(tk.Id, time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tk.State != api.TaskState_closed {
+				t.Errorf("stopped task is not closed: %v", tk.State)
+			}
+			select {
+			case <-tk.done:
+			case <-time.After(time.Second):
+				t.Fatalf("stopped task did not finish")
+			}
+			if tk.result != test.Result {
+				t.Errorf("unexpected task result: got %q, expected %q", tk.result, test.Result)
+			}
+			if tk.RestartCount != 0 {
+				t.Errorf("stopped task was restarted")
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/creack/pty"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// maxRecordingSize is the number of bytes after which we stop recording a terminal.
+// This keeps long running or very chatty terminals from filling up the workspace.
+const maxRecordingSize = 64 << 20
+
+const (
+	// maxRecordings is the number of recordings we keep. The oldest recordings are deleted first.
+	maxRecordings = 32
+	// maxRecordingsTotalSize is the number of bytes all recordings may take up. Recordings end up in workspace backups,
+	// hence the oldest recordings are deleted before a new recording could exceed this size.
+	maxRecordingsTotalSize = 256 << 20
+)
+
+const (
+	asciicastOutputEvent = "o"
+	asciicastResizeEvent = "r"
+)
+
+// asciicastHeader is the first line of an asciicast v2 file,
+// see https://github.com/asciinema/asciinema/blob/develop/doc/asciicast-v2.md
+type asciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// asciicastEvent is a single event of an asciicast v2 recording
+type asciicastEvent struct {
+	// Time is the time since the beginning of the recording
+	Time time.Duration
+	Type string
+	Data string
+}
+
+// recordingFile returns the path of the recording of the terminal with the given alias
+func recordingFile(location, alias string) string {
+	return filepath.Join(location, alias+".cast")
+}
+
+// pruneRecordings deletes the oldest recordings in location, so that a new recording fits within maxRecordings and
+// maxRecordingsTotalSize. The recordings of the active terminals are never deleted, but count towards both limits.
+func pruneRecordings(location string, active map[string]*Term) error {
+	entries, err := os.ReadDir(location)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var (
+		inactive []os.FileInfo
+		count    int
+		size     int64
+	)
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".cast" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		count++
+		size += info.Size()
+		if _, ok := active[strings.TrimSuffix(e.Name(), ".cast")]; ok {
+			continue
+		}
+		inactive = append(inactive, info)
+	}
+	sort.Slice(inactive, func(i, j int) bool { return inactive[i].ModTime().Before(inactive[j].ModTime()) })
+
+	for _, info := range inactive {
+		if count < maxRecordings && size+maxRecordingSize <= maxRecordingsTotalSize {
+			break
+		}
+		err := os.Remove(filepath.Join(location, info.Name()))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		count--
+		size -= info.Size()
+	}
+	return nil
+}
+
+// asciicastRecorder records the output of a terminal with timing as asciicast v2 file.
+// It is not safe for concurrent use - multiWriter serialises all calls.
+type asciicastRecorder struct {
+	out   *os.File
+	fn    string
+	start time.Time
+	size  int64
+
+	// pending holds an incomplete UTF-8 sequence at the end of the last write.
+	// asciicast events are JSON strings, hence we must not split runes across events.
+	pending []byte
+	stopped bool
+}
+
+func newAsciicastRecorder(fn string, cmd *exec.Cmd, options TermOptions) (*asciicastRecorder, error) {
+	err := os.MkdirAll(filepath.Dir(fn), 0755)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create recording location: %w", err)
+	}
+	out, err := os.OpenFile(fn, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create recording: %w", err)
+	}
+	if cmd.SysProcAttr != nil && cmd.SysProcAttr.Credential != nil {
+		// the recording belongs to whoever runs in the terminal
+		uid, gid := int(cmd.SysProcAttr.Credential.Uid), int(cmd.SysProcAttr.Credential.Gid)
+		_ = os.Chown(filepath.Dir(fn), uid, gid)
+		_ = os.Chown(fn, uid, gid)
+	}
+
+	rec := &asciicastRecorder{
+		out:   out,
+		fn:    fn,
+		start: time.Now(),
+	}
+	hdr := asciicastHeader{
+		Version:   2,
+		Width:     80,
+		Height:    24,
+		Timestamp: rec.start.Unix(),
+		Title:     options.Title,
+	}
+	if options.Size != nil && options.Size.Cols > 0 && options.Size.Rows > 0 {
+		hdr.Width = int(options.Size.Cols)
+		hdr.Height = int(options.Size.Rows)
+	}
+	for _, e := range cmd.Env {
+		segs := strings.SplitN(e, "=", 2)
+		if len(segs) == 2 && (segs[0] == "SHELL" || segs[0] == "TERM") {
+			if hdr.Env == nil {
+				hdr.Env = make(map[string]string)
+			}
+			hdr.Env[segs[0]] = segs[1]
+		}
+	}
+	err = rec.writeLine(hdr)
+	if err != nil {
+		out.Close()
+		return nil, xerrors.Errorf("cannot write recording header: %w", err)
+	}
+	return rec, nil
+}
+
+// Write records terminal output
+func (rec *asciicastRecorder) Write(p []byte) (n int, err error) {
+	n = len(p)
+	if len(rec.pending) > 0 {
+		p = append(rec.pending, p...)
+		rec.pending = nil
+	}
+	data, rest := splitIncompleteUTF8(p)
+	if len(rest) > 0 {
+		rec.pending = append([]byte(nil), rest...)
+	}
+	if len(data) == 0 {
+		return n, nil
+	}
+	return n, rec.writeEvent(asciicastOutputEvent, string(data))
+}
+
+// Resize records a change of the terminal size
+func (rec *asciicastRecorder) Resize(size *pty.Winsize) error {
+	return rec.writeEvent(asciicastResizeEvent, fmt.Sprintf("%dx%d", size.Cols, size.Rows))
+}
+
+func (rec *asciicastRecorder) writeEvent(tpe, data string) error {
+	elapsed := math.Round(time.Since(rec.start).Seconds()*1e6) / 1e6
+	return rec.writeLine([]interface{}{elapsed, tpe, data})
+}
+
+func (rec *asciicastRecorder) writeLine(v interface{}) error {
+	if rec.stopped {
+		return nil
+	}
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if rec.size+int64(len(line)) > maxRecordingSize {
+		log.WithField("recording", rec.fn).Warn("terminal recording is too large - not recording any further output")
+		rec.stopped = true
+		return nil
+	}
+	n, err := rec.out.Write(append(line, '\n'))
+	rec.size += int64(n)
+	return err
+}
+
+// Close flushes pending output and closes the recording
+func (rec *asciicastRecorder) Close() error {
+	if len(rec.pending) > 0 {
+		_ = rec.writeEvent(asciicastOutputEvent, string(rec.pending))
+		rec.pending = nil
+	}
+	rec.stopped = true
+	return rec.out.Close()
+}
+
+// splitIncompleteUTF8 splits an incomplete UTF-8 sequence off the end of p
+func splitIncompleteUTF8(p []byte) (complete, rest []byte) {
+	for i := 1; i < utf8.UTFMax && i <= len(p); i++ {
+		if !utf8.RuneStart(p[len(p)-i]) {
+			continue
+		}
+		if utf8.FullRune(p[len(p)-i:]) {
+			return p, nil
+		}
+		return p[:len(p)-i], p[len(p)-i:]
+	}
+	return p, nil
+}
+
+// readAsciicast reads an asciicast v2 recording and calls onEvent for every event it contains
+func readAsciicast(in io.Reader, onEvent func(asciicastEvent) error) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordingSize)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		return xerrors.Errorf("recording is empty")
+	}
+	var hdr asciicastHeader
+	err := json.Unmarshal(scanner.Bytes(), &hdr)
+	if err != nil {
+		return xerrors.Errorf("cannot parse recording header: %w", err)
+	}
+	if hdr.Version != 2 {
+		return xerrors.Errorf("unsupported recording version %d", hdr.Version)
+	}
+
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var (
+			raw [3]json.RawMessage
+			t   float64
+			ev  asciicastEvent
+		)
+		err = json.Unmarshal(scanner.Bytes(), &raw)
+		if err == nil {
+			err = json.Unmarshal(raw[0], &t)
+		}
+		if err == nil {
+			err = json.Unmarshal(raw[1], &ev.Type)
+		}
+		if err == nil {
+			err = json.Unmarshal(raw[2], &ev.Data)
+		}
+		if err != nil {
+			return xerrors.Errorf("cannot parse recording event: %w", err)
+		}
+		ev.Time = time.Duration(t * float64(time.Second))
+
+		err = onEvent(ev)
+		if err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/creack/pty"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+func TestSplitIncompleteUTF8(t *testing.T) {
+	euro := []byte("€")
+	tests := []struct {
+		Desc     string
+		Input    []byte
+		Complete []byte
+		Rest     []byte
+	}{
+		{Desc: "empty", Input: nil},
+		{Desc: "ascii", Input: []byte("hello"), Complete: []byte("hello")},
+		{Desc: "complete rune", Input: []byte("a€"), Complete: []byte("a€")},
+		{Desc: "incomplete rune", Input: append([]byte("a"), euro[:2]...), Complete: []byte("a"), Rest: euro[:2]},
+		{Desc: "rune start only", Input: euro[:1], Rest: euro[:1]},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			complete, rest := splitIncompleteUTF8(test.Input)
+			if !bytes.Equal(complete, test.Complete) {
+				t.Errorf("unexpected complete part: %q, expected %q", complete, test.Complete)
+			}
+			if !bytes.Equal(rest, test.Rest) {
+				t.Errorf("unexpected rest: %q, expected %q", rest, test.Rest)
+			}
+		})
+	}
+}
+
+func TestAsciicastRecorder(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "recordings", "test.cast")
+	cmd := exec.Command("/bin/sh")
+	cmd.Env = []string{"TERM=xterm-color", "FOO=bar"}
+
+	rec, err := newAsciicastRecorder(fn, cmd, TermOptions{Title: "test", Size: &pty.Winsize{Cols: 120, Rows: 40}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	euro := []byte("€")
+	for _, chunk := range [][]byte{[]byte("hello "), append([]byte("wo"), euro[:1]...), append(euro[1:], []byte("rld\n")...)} {
+		_, err = rec.Write(chunk)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = rec.Resize(&pty.Winsize{Cols: 80, Rows: 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rec.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hdr := strings.SplitN(string(content), "\n", 2)[0]
+	if !strings.Contains(hdr, `"version":2,"width":120,"height":40,`) || !strings.Contains(hdr, `"env":{"TERM":"xterm-color"}`) {
+		t.Errorf("unexpected header: %s", hdr)
+	}
+
+	type event struct {
+		Type string
+		Data string
+	}
+	var act []event
+	err = readAsciicast(bytes.NewReader(content), func(ev asciicastEvent) error {
+		act = append(act, event{Type: ev.Type, Data: ev.Data})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectation := []event{
+		{Type: "o", Data: "hello "},
+		{Type: "o", Data: "wo"},
+		{Type: "o", Data: "€rld\n"},
+		{Type: "r", Data: "80x24"},
+	}
+	if diff := cmp.Diff(expectation, act); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
+
+func TestPruneRecordings(t *testing.T) {
+	location := t.TempDir()
+	now := time.Now()
+	write := func(alias string, size int, age time.Duration) {
+		fn := recordingFile(location, alias)
+		err := os.WriteFile(fn, make([]byte, size), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.Chtimes(fn, now.Add(-age), now.Add(-age))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the oldest recording belongs to a terminal which is still running
+	write("active", 1, 3*time.Hour)
+	write("large", maxRecordingsTotalSize-2*maxRecordingSize, 2*time.Hour)
+	for i := 0; i < maxRecordings; i++ {
+		write(fmt.Sprintf("small-%02d", i), 1, time.Duration(maxRecordings-i)*time.Minute)
+	}
+
+	err := pruneRecordings(location, map[string]*Term{"active": nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var act []string
+	entries, err := os.ReadDir(location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		act = append(act, strings.TrimSuffix(e.Name(), ".cast"))
+	}
+	// the large and the two oldest small recordings make room for a new recording
+	expectation := []string{"active"}
+	for i := 2; i < maxRecordings; i++ {
+		expectation = append(expectation, fmt.Sprintf("small-%02d", i))
+	}
+	if diff := cmp.Diff(expectation, act); diff != "" {
+		t.Errorf("unexpected recordings (-want +got):\n%s", diff)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	const alias = "5c8e1b6a-6f2e-4f0d-8b0a-0f2a9c1e7d3b"
+	location := t.TempDir()
+	recording := `{"version":2,"width":80,"height":24,"timestamp":1609459200}
+[0.1,"o","hello "]
+[0.2,"r","100x30"]
+[0.3,"o","world"]
+`
+	err := os.WriteFile(recordingFile(location, alias), []byte(recording), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Desc              string
+		RecordingLocation string
+		Alias             string
+		Expectation       []*api.ReplayTerminalResponse
+		ExpectedCode      codes.Code
+	}{
+		{
+			Desc:              "replay",
+			RecordingLocation: location,
+			Alias:             alias,
+			Expectation: []*api.ReplayTerminalResponse{
+				{Data: []byte("hello "), Time: 0.1},
+				{Data: []byte("world"), Time: 0.3},
+			},
+		},
+		{Desc: "recording disabled", Alias: alias, ExpectedCode: codes.FailedPrecondition},
+		{Desc: "unknown terminal", RecordingLocation: location, Alias: "4b1e6a3c-9f7d-4c2b-a8e1-3d5f7b9c1e2a", ExpectedCode: codes.NotFound},
+		{Desc: "invalid alias", RecordingLocation: location, Alias: "../" + alias, ExpectedCode: codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			mux := NewMux()
+			mux.RecordingLocation = test.RecordingLocation
+			srv := NewMuxTerminalService(mux)
+
+			listener := &testReplayServer{ctx: context.Background()}
+			err := srv.Replay(&api.ReplayTerminalRequest{Alias: test.Alias}, listener)
+			if status.Code(err) != test.ExpectedCode {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(test.Expectation, listener.resps, cmp.Comparer(func(a, b *api.ReplayTerminalResponse) bool {
+				return bytes.Equal(a.Data, b.Data) && a.Time == b.Time
+			})); diff != "" {
+				t.Errorf("unexpected replay (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type testReplayServer struct {
+	resps []*api.ReplayTerminalResponse
+	ctx   context.Context
+	grpc.ServerStream
+}
+
+func (s *testReplayServer) Send(resp *api.ReplayTerminalResponse) error {
+	s.resps = append(s.resps, resp)
+	return nil
+}
+
+func (s *testReplayServer) Context() context.Context {
+	return s.ctx
+}
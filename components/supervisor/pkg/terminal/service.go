@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/creack/pty"
+	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -106,14 +107,7 @@ func (srv *MuxTerminalService) OpenWithOptions(ctx context.Context, req *api.Ope
 	for k, v := range req.Annotations {
 		options.Annotations[k] = v
 	}
-	if req.Size != nil {
-		options.Size = &pty.Winsize{
-			Cols: uint16(req.Size.Cols),
-			Rows: uint16(req.Size.Rows),
-			X:    uint16(req.Size.WidthPx),
-			Y:    uint16(req.Size.HeightPx),
-		}
-	}
+	options.Size = toWinsize(req.Size)
 	alias, err := srv.Mux.Start(cmd, options)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -212,6 +206,7 @@ func (srv *MuxTerminalService) get(alias string) (*api.Terminal, bool) {
 		Annotations:    term.GetAnnotations(),
 		Title:          title,
 		TitleSource:    titleSource,
+		Session:        term.session.Status(),
 	}, true
 }
 
@@ -223,11 +218,22 @@ func (srv *MuxTerminalService) Listen(req *api.ListenTerminalRequest, resp api.T
 	if !ok {
 		return status.Error(codes.NotFound, "terminal not found")
 	}
+	if req.Client != "" {
+		size, err := term.session.Attach(req.Client, req.Role, toWinsize(req.Size))
+		if err != nil {
+			return sessionError(err)
+		}
+		srv.resizeSession(req.Alias, term, size)
+		defer func() {
+			srv.resizeSession(req.Alias, term, term.session.Detach(req.Client))
+		}()
+	}
+
 	stdout := term.Stdout.Listen()
 	defer stdout.Close()
 
-	log.WithField("alias", req.Alias).Info("new terminal client")
-	defer log.WithField("alias", req.Alias).Info("terminal client left")
+	log.WithField("alias", req.Alias).WithField("client", req.Client).Info("new terminal client")
+	defer log.WithField("alias", req.Alias).WithField("client", req.Client).Info("terminal client left")
 
 	errchan := make(chan error, 1)
 	messages := make(chan *api.ListenTerminalResponse, 1)
@@ -258,6 +264,13 @@ func (srv *MuxTerminalService) Listen(req *api.ListenTerminalRequest, resp api.T
 		title, titleSource, _ := term.GetTitle()
 		messages <- &api.ListenTerminalResponse{Output: &api.ListenTerminalResponse_Title{Title: title}, TitleSource: titleSource}
 
+		// anonymous listeners of terminals without named clients never hear about sessions
+		var sessionVersion uint64
+		if v := term.session.Version(); v != sessionVersion {
+			sessionVersion = v
+			messages <- &api.ListenTerminalResponse{Output: &api.ListenTerminalResponse_Session{Session: term.session.Status()}}
+		}
+
 		t := time.NewTicker(200 * time.Millisecond)
 		defer t.Stop()
 		for {
@@ -265,6 +278,11 @@ func (srv *MuxTerminalService) Listen(req *api.ListenTerminalRequest, resp api.T
 			case <-resp.Context().Done():
 				return
 			case <-t.C:
+				if v := term.session.Version(); v != sessionVersion {
+					sessionVersion = v
+					messages <- &api.ListenTerminalResponse{Output: &api.ListenTerminalResponse_Session{Session: term.session.Status()}}
+				}
+
 				newTitle, newTitleSource, _ := term.GetTitle()
 				if title == newTitle && titleSource == newTitleSource {
 					continue
@@ -303,6 +321,11 @@ func (srv *MuxTerminalService) Write(ctx context.Context, req *api.WriteTerminal
 		return nil, status.Error(codes.NotFound, "terminal not found")
 	}
 
+	err := term.session.CanWrite(req.Client)
+	if err != nil {
+		return nil, sessionError(err)
+	}
+
 	n, err := term.PTY.Write(req.Stdin)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -319,18 +342,27 @@ func (srv *MuxTerminalService) SetSize(ctx context.Context, req *api.SetTerminal
 		return nil, status.Error(codes.NotFound, "terminal not found")
 	}
 
-	// Setting the size only works with the starter token or when forcing it.
-	// This protects us from multiple listener mangling the terminal.
-	if !(req.GetForce() || req.GetToken() == term.StarterToken) {
+	size := toWinsize(req.Size)
+	if req.Client != "" {
+		// named clients negotiate the size with all other clients
+		var err error
+		size, err = term.session.SetSize(req.Client, size)
+		if err != nil {
+			return nil, sessionError(err)
+		}
+		if size == nil {
+			return &api.SetTerminalSizeResponse{}, nil
+		}
+	} else if !(req.GetForce() || req.GetToken() == term.StarterToken) {
+		// Setting the size only works with the starter token or when forcing it.
+		// This protects us from multiple listener mangling the terminal.
 		return nil, status.Error(codes.FailedPrecondition, "wrong token or force not set")
 	}
+	if size == nil {
+		return nil, status.Error(codes.InvalidArgument, "size is required")
+	}
 
-	err := pty.Setsize(term.PTY, &pty.Winsize{
-		Cols: uint16(req.Size.Cols),
-		Rows: uint16(req.Size.Rows),
-		X:    uint16(req.Size.WidthPx),
-		Y:    uint16(req.Size.HeightPx),
-	})
+	err := term.resize(size)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -361,3 +393,137 @@ func (srv *MuxTerminalService) UpdateAnnotations(ctx context.Context, req *api.U
 	term.UpdateAnnotations(req.Changed, req.Deleted)
 	return &api.UpdateTerminalAnnotationsResponse{}, nil
 }
+
+// TransferWriteLock hands the write lock of a terminal over to another client
+func (srv *MuxTerminalService) TransferWriteLock(ctx context.Context, req *api.TransferTerminalWriteLockRequest) (*api.TransferTerminalWriteLockResponse, error) {
+	srv.Mux.mu.RLock()
+	term, ok := srv.Mux.terms[req.Alias]
+	srv.Mux.mu.RUnlock()
+	if !ok {
+		return nil, status.Error(codes.NotFound, "terminal not found")
+	}
+	err := term.session.TransferWriteLock(req.Client, req.Target, req.Force)
+	if err != nil {
+		return nil, sessionError(err)
+	}
+	return &api.TransferTerminalWriteLockResponse{}, nil
+}
+
+// resizeSession applies the size negotiated by the clients of a terminal session
+func (srv *MuxTerminalService) resizeSession(alias string, term *Term, size *pty.Winsize) {
+	if size == nil {
+		return
+	}
+	err := term.resize(size)
+	if err != nil {
+		log.WithError(err).WithField("alias", alias).Warn("cannot resize terminal")
+	}
+}
+
+func sessionError(err error) error {
+	switch err {
+	case ErrClientExists:
+		return status.Error(codes.AlreadyExists, err.Error())
+	case ErrClientNotAttached:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case ErrReadOnly, ErrWriteLockHeld:
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func toWinsize(size *api.TerminalSize) *pty.Winsize {
+	if size == nil {
+		return nil
+	}
+	return &pty.Winsize{
+		Cols: uint16(size.Cols),
+		Rows: uint16(size.Rows),
+		X:    uint16(size.WidthPx),
+		Y:    uint16(size.HeightPx),
+	}
+}
+
+// GetRecording streams the asciicast v2 recording of a terminal
+func (srv *MuxTerminalService) GetRecording(req *api.GetTerminalRecordingRequest, resp api.TerminalService_GetRecordingServer) error {
+	f, err := srv.openRecording(req.Alias)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			serr := resp.Send(&api.GetTerminalRecordingResponse{Data: buf[:n]})
+			if serr != nil {
+				return serr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+}
+
+// Replay streams the recorded output of a terminal with its original timing
+func (srv *MuxTerminalService) Replay(req *api.ReplayTerminalRequest, resp api.TerminalService_ReplayServer) error {
+	f, err := srv.openRecording(req.Alias)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var last time.Duration
+	err = readAsciicast(f, func(ev asciicastEvent) error {
+		if ev.Type != asciicastOutputEvent {
+			return nil
+		}
+		if req.Speed > 0 && ev.Time > last {
+			delay := time.Duration(float64(ev.Time-last) / req.Speed)
+			select {
+			case <-resp.Context().Done():
+				return resp.Context().Err()
+			case <-time.After(delay):
+			}
+		}
+		last = ev.Time
+		return resp.Send(&api.ReplayTerminalResponse{
+			Data: []byte(ev.Data),
+			Time: ev.Time.Seconds(),
+		})
+	})
+	if err == nil {
+		return nil
+	}
+	if err == context.Canceled || err == context.DeadlineExceeded {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (srv *MuxTerminalService) openRecording(alias string) (*os.File, error) {
+	if srv.Mux.RecordingLocation == "" {
+		return nil, status.Error(codes.FailedPrecondition, "terminal recording is disabled")
+	}
+	// aliases are UUIDs - anything else could point outside of the recording location
+	if _, err := uuid.Parse(alias); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid terminal alias")
+	}
+	f, err := os.Open(recordingFile(srv.Mux.RecordingLocation, alias))
+	if os.IsNotExist(err) {
+		return nil, status.Error(codes.NotFound, "recording not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return f, nil
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/creack/pty"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+var (
+	// ErrClientExists means a client with the same name is already attached to the terminal
+	ErrClientExists = errors.New("client already attached")
+	// ErrClientNotAttached means the client is not attached to the terminal
+	ErrClientNotAttached = errors.New("client not attached")
+	// ErrReadOnly means a read-only client attempted to write to the terminal or to hold its write lock
+	ErrReadOnly = errors.New("client is read-only")
+	// ErrWriteLockHeld means another client holds the terminal's write lock
+	ErrWriteLockHeld = errors.New("write lock is held by another client")
+)
+
+// session tracks the named clients attached to a terminal.
+//
+// At most one read-write client holds the write lock of a terminal at a time, and only that
+// client may write to it. The first read-write client to attach gets the lock; it passes
+// to the next read-write client when its holder detaches, or is handed over explicitly.
+// Anonymous clients may write as long as nobody holds the lock, i.e. if no named
+// read-write client is attached.
+//
+// The terminal size is the largest size that fits all attached clients which reported one.
+type session struct {
+	mu        sync.RWMutex
+	clients   []*sessionClient
+	writeLock string
+
+	// version increases on every change so that listeners can detect them
+	version uint64
+}
+
+type sessionClient struct {
+	Name string
+	Role api.TerminalClientRole
+	Size *pty.Winsize
+}
+
+func (s *session) find(name string) *sessionClient {
+	for _, c := range s.clients {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Attach adds a named client to the session and returns the negotiated terminal size
+func (s *session) Attach(name string, role api.TerminalClientRole, size *pty.Winsize) (*pty.Winsize, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.find(name) != nil {
+		return nil, ErrClientExists
+	}
+	s.clients = append(s.clients, &sessionClient{Name: name, Role: role, Size: size})
+	if s.writeLock == "" && role == api.TerminalClientRole_read_write {
+		s.writeLock = name
+	}
+	s.version++
+	return s.negotiateSize(), nil
+}
+
+// Detach removes a named client from the session and returns the negotiated terminal size
+func (s *session) Detach(name string) *pty.Winsize {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, c := range s.clients {
+		if c.Name == name {
+			s.clients = append(s.clients[:i], s.clients[i+1:]...)
+			break
+		}
+	}
+	if s.writeLock == name {
+		s.writeLock = ""
+		for _, c := range s.clients {
+			if c.Role == api.TerminalClientRole_read_write {
+				s.writeLock = c.Name
+				break
+			}
+		}
+	}
+	s.version++
+	return s.negotiateSize()
+}
+
+// CanWrite returns an error if the client must not write to the terminal.
+// Use an empty name for anonymous clients.
+func (s *session) CanWrite(name string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if name == "" {
+		if s.writeLock != "" {
+			return ErrWriteLockHeld
+		}
+		return nil
+	}
+	c := s.find(name)
+	if c == nil {
+		return ErrClientNotAttached
+	}
+	if c.Role != api.TerminalClientRole_read_write {
+		return ErrReadOnly
+	}
+	if s.writeLock != name {
+		return ErrWriteLockHeld
+	}
+	return nil
+}
+
+// TransferWriteLock hands the write lock from client over to target.
+// Unless force is set, client must hold the write lock.
+func (s *session) TransferWriteLock(client, target string, force bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.find(client) == nil {
+		return ErrClientNotAttached
+	}
+	if !force && s.writeLock != client {
+		return ErrWriteLockHeld
+	}
+	t := s.find(target)
+	if t == nil {
+		return ErrClientNotAttached
+	}
+	if t.Role != api.TerminalClientRole_read_write {
+		return ErrReadOnly
+	}
+	s.writeLock = target
+	s.version++
+	return nil
+}
+
+// SetSize sets the size of a client's terminal and returns the negotiated terminal size
+func (s *session) SetSize(name string, size *pty.Winsize) (*pty.Winsize, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.find(name)
+	if c == nil {
+		return nil, ErrClientNotAttached
+	}
+	c.Size = size
+	s.version++
+	return s.negotiateSize(), nil
+}
+
+// negotiateSize computes the terminal size from the sizes of all clients,
+// or returns nil if no client reported a size.
+//
+// Callers are expected to hold mu.
+func (s *session) negotiateSize() *pty.Winsize {
+	var res *pty.Winsize
+	for _, c := range s.clients {
+		if c.Size == nil || c.Size.Cols == 0 || c.Size.Rows == 0 {
+			continue
+		}
+		if res == nil {
+			res = &pty.Winsize{Cols: c.Size.Cols, Rows: c.Size.Rows}
+			continue
+		}
+		if c.Size.Cols < res.Cols {
+			res.Cols = c.Size.Cols
+		}
+		if c.Size.Rows < res.Rows {
+			res.Rows = c.Size.Rows
+		}
+	}
+	return res
+}
+
+// Version returns a number that changes whenever the session changes
+func (s *session) Version() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.version
+}
+
+// Status produces an API compatible session status
+func (s *session) Status() *api.TerminalSession {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := &api.TerminalSession{
+		WriteLock: s.writeLock,
+		Clients:   make([]*api.TerminalClient, 0, len(s.clients)),
+	}
+	for _, c := range s.clients {
+		res.Clients = append(res.Clients, &api.TerminalClient{
+			Name: c.Name,
+			Role: c.Role,
+			Size: toTerminalSize(c.Size),
+		})
+	}
+	res.Size = toTerminalSize(s.negotiateSize())
+	return res
+}
+
+func toTerminalSize(size *pty.Winsize) *api.TerminalSize {
+	if size == nil {
+		return nil
+	}
+	return &api.TerminalSize{
+		Cols:     uint32(size.Cols),
+		Rows:     uint32(size.Rows),
+		WidthPx:  uint32(size.X),
+		HeightPx: uint32(size.Y),
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"testing"
+
+	"github.com/creack/pty"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+func TestSessionWriteLock(t *testing.T) {
+	const (
+		alice = "alice"
+		bob   = "bob"
+		carol = "carol"
+	)
+	type op func(s *session) error
+	attach := func(name string, role api.TerminalClientRole) op {
+		return func(s *session) error {
+			_, err := s.Attach(name, role, nil)
+			return err
+		}
+	}
+	detach := func(name string) op {
+		return func(s *session) error {
+			s.Detach(name)
+			return nil
+		}
+	}
+	transfer := func(client, target string, force bool) op {
+		return func(s *session) error {
+			return s.TransferWriteLock(client, target, force)
+		}
+	}
+
+	tests := []struct {
+		Desc              string
+		Ops               []op
+		ExpectedError     error
+		ExpectedWriteLock string
+		CanWrite          map[string]error
+	}{
+		{
+			Desc:     "no clients",
+			CanWrite: map[string]error{"": nil, alice: ErrClientNotAttached},
+		},
+		{
+			Desc:              "first read-write client gets the lock",
+			Ops:               []op{attach(alice, api.TerminalClientRole_read_write), attach(bob, api.TerminalClientRole_read_write)},
+			ExpectedWriteLock: alice,
+			CanWrite:          map[string]error{"": ErrWriteLockHeld, alice: nil, bob: ErrWriteLockHeld},
+		},
+		{
+			Desc:     "read-only clients never get the lock",
+			Ops:      []op{attach(alice, api.TerminalClientRole_read_only)},
+			CanWrite: map[string]error{"": nil, alice: ErrReadOnly},
+		},
+		{
+			Desc:              "duplicate client",
+			Ops:               []op{attach(alice, api.TerminalClientRole_read_write), attach(alice, api.TerminalClientRole_read_only)},
+			ExpectedError:     ErrClientExists,
+			ExpectedWriteLock: alice,
+		},
+		{
+			Desc: "lock passes on when the holder detaches",
+			Ops: []op{
+				attach(alice, api.TerminalClientRole_read_write),
+				attach(bob, api.TerminalClientRole_read_only),
+				attach(carol, api.TerminalClientRole_read_write),
+				detach(alice),
+			},
+			ExpectedWriteLock: carol,
+			CanWrite:          map[string]error{bob: ErrReadOnly, carol: nil},
+		},
+		{
+			Desc:              "handover",
+			Ops:               []op{attach(alice, api.TerminalClientRole_read_write), attach(bob, api.TerminalClientRole_read_write), transfer(alice, bob, false)},
+			ExpectedWriteLock: bob,
+			CanWrite:          map[string]error{alice: ErrWriteLockHeld, bob: nil},
+		},
+		{
+			Desc:              "handover without holding the lock",
+			Ops:               []op{attach(alice, api.TerminalClientRole_read_write), attach(bob, api.TerminalClientRole_read_write), transfer(bob, bob, false)},
+			ExpectedError:     ErrWriteLockHeld,
+			ExpectedWriteLock: alice,
+		},
+		{
+			Desc:              "forced handover",
+			Ops:               []op{attach(alice, api.TerminalClientRole_read_write), attach(bob, api.TerminalClientRole_read_write), transfer(bob, bob, true)},
+			ExpectedWriteLock: bob,
+		},
+		{
+			Desc:              "handover to read-only client",
+			Ops:               []op{attach(alice, api.TerminalClientRole_read_write), attach(bob, api.TerminalClientRole_read_only), transfer(alice, bob, false)},
+			ExpectedError:     ErrReadOnly,
+			ExpectedWriteLock: alice,
+		},
+		{
+			Desc:              "handover to unknown client",
+			Ops:               []op{attach(alice, api.TerminalClientRole_read_write), transfer(alice, bob, false)},
+			ExpectedError:     ErrClientNotAttached,
+			ExpectedWriteLock: alice,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			var (
+				s   session
+				err error
+			)
+			for _, o := range test.Ops {
+				err = o(&s)
+				if err != nil {
+					break
+				}
+			}
+			if err != test.ExpectedError {
+				t.Errorf("unexpected error: %v, expected %v", err, test.ExpectedError)
+			}
+			if act := s.Status().WriteLock; act != test.ExpectedWriteLock {
+				t.Errorf("unexpected write lock holder: %q, expected %q", act, test.ExpectedWriteLock)
+			}
+			for name, expectation := range test.CanWrite {
+				if err := s.CanWrite(name); err != expectation {
+					t.Errorf("unexpected write permission of %q: %v, expected %v", name, err, expectation)
+				}
+			}
+		})
+	}
+}
+
+func TestSessionSize(t *testing.T) {
+	var s session
+	size, err := s.Attach("alice", api.TerminalClientRole_read_write, &pty.Winsize{Cols: 120, Rows: 40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&pty.Winsize{Cols: 120, Rows: 40}, size); diff != "" {
+		t.Errorf("unexpected size (-want +got):\n%s", diff)
+	}
+
+	size, err = s.Attach("bob", api.TerminalClientRole_read_only, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&pty.Winsize{Cols: 120, Rows: 40}, size); diff != "" {
+		t.Errorf("clients without size changed the size (-want +got):\n%s", diff)
+	}
+
+	size, err = s.SetSize("bob", &pty.Winsize{Cols: 80, Rows: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&pty.Winsize{Cols: 80, Rows: 40}, size); diff != "" {
+		t.Errorf("size does not fit all clients (-want +got):\n%s", diff)
+	}
+
+	size = s.Detach("alice")
+	if diff := cmp.Diff(&pty.Winsize{Cols: 80, Rows: 50}, size); diff != "" {
+		t.Errorf("size did not grow after detach (-want +got):\n%s", diff)
+	}
+
+	_, err = s.SetSize("alice", &pty.Winsize{Cols: 10, Rows: 10})
+	if err != ErrClientNotAttached {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
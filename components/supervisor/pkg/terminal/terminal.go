@@ -37,6 +37,11 @@ type Mux struct {
 	aliases []string
 	terms   map[string]*Term
 	mu      sync.RWMutex
+
+	// RecordingLocation is the directory in which the output of each terminal is recorded
+	// as asciicast v2 file named after the terminal's alias. Leave empty to disable recording.
+	// The oldest recordings are deleted once there are too many of them, or they grow too large.
+	RecordingLocation string
 }
 
 // Get returns a terminal for the given alias
@@ -64,8 +69,25 @@ func (m *Mux) Start(cmd *exec.Cmd, options TermOptions) (alias string, err error
 	}
 	alias = uid.String()
 
-	term, err := newTerm(alias, pty, cmd, options)
+	var recording *asciicastRecorder
+	if m.RecordingLocation != "" {
+		err = pruneRecordings(m.RecordingLocation, m.terms)
+		if err != nil {
+			log.WithError(err).Warn("cannot delete old terminal recordings")
+		}
+		recording, err = newAsciicastRecorder(recordingFile(m.RecordingLocation, alias), cmd, options)
+		if err != nil {
+			// not being able to record shouldn't keep the terminal from working
+			log.WithError(err).WithField("alias", alias).Warn("cannot record terminal")
+			recording = nil
+		}
+	}
+
+	term, err := newTerm(alias, pty, cmd, options, recording)
 	if err != nil {
+		if recording != nil {
+			recording.Close()
+		}
 		pty.Close()
 		return "", err
 	}
@@ -190,7 +212,7 @@ func (term *Term) shutdownProcessImmediately() error {
 // For now we assume an average of five terminals per workspace, which makes this consume 1MiB of RAM.
 const terminalBacklogSize = 256 << 10
 
-func newTerm(alias string, pty *os.File, cmd *exec.Cmd, options TermOptions) (*Term, error) {
+func newTerm(alias string, pty *os.File, cmd *exec.Cmd, options TermOptions, recording *asciicastRecorder) (*Term, error) {
 	token, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -212,6 +234,7 @@ func newTerm(alias string, pty *os.File, cmd *exec.Cmd, options TermOptions) (*T
 			timeout:   timeout,
 			listener:  make(map[*multiWriterListener]struct{}),
 			recorder:  recorder,
+			recording: recording,
 			logStdout: options.LogToStdout,
 			logLabel:  alias,
 		},
@@ -274,6 +297,8 @@ type Term struct {
 
 	Stdout *multiWriter
 
+	session session
+
 	waitErr  error
 	waitDone chan struct{}
 
@@ -345,6 +370,16 @@ func (term *Term) resolveForegroundCommand() (string, error) {
 	return string(content), nil
 }
 
+// resize sets the size of the pseudo-terminal
+func (term *Term) resize(size *pty.Winsize) error {
+	err := pty.Setsize(term.PTY, size)
+	if err != nil {
+		return err
+	}
+	term.Stdout.recordResize(size)
+	return nil
+}
+
 // Wait waits for the terminal to exit and returns the resulted process state
 func (term *Term) Wait() (*os.ProcessState, error) {
 	<-term.waitDone
@@ -360,6 +395,8 @@ type multiWriter struct {
 	// ring buffer to record last 256kb of pty output
 	// new listener is initialized with the latest recodring first
 	recorder *RingBuffer
+	// recording records the pty output with timing, nil if recording is disabled
+	recording *asciicastRecorder
 
 	logStdout bool
 	logLabel  string
@@ -475,6 +512,12 @@ func (mw *multiWriter) Write(p []byte) (n int, err error) {
 	defer mw.mu.Unlock()
 
 	mw.recorder.Write(p)
+	if mw.recording != nil {
+		_, err := mw.recording.Write(p)
+		if err != nil {
+			log.WithError(err).WithField("alias", mw.logLabel).Warn("cannot record terminal output")
+		}
+	}
 	if mw.logStdout {
 		log.WithFields(logrus.Fields{
 			"terminalOutput": true,
@@ -515,9 +558,30 @@ func (mw *multiWriter) Close() error {
 			err = cerr
 		}
 	}
+	if mw.recording != nil {
+		cerr := mw.recording.Close()
+		if cerr != nil {
+			err = cerr
+		}
+		mw.recording = nil
+	}
 	return err
 }
 
+// recordResize records a change of the terminal size if the terminal is recorded
+func (mw *multiWriter) recordResize(size *pty.Winsize) {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	if mw.recording == nil {
+		return
+	}
+	err := mw.recording.Resize(size)
+	if err != nil {
+		log.WithError(err).WithField("alias", mw.logLabel).Warn("cannot record terminal resize")
+	}
+}
+
 func (mw *multiWriter) ListenerCount() int {
 	mw.mu.Lock()
 	defer mw.mu.Unlock()
@@ -225,6 +225,8 @@ export namespace DisposeWorkspaceResponse {
 export class BackupWorkspaceRequest extends jspb.Message {
     getId(): string;
     setId(value: string): BackupWorkspaceRequest;
+    getPeriodic(): boolean;
+    setPeriodic(value: boolean): BackupWorkspaceRequest;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): BackupWorkspaceRequest.AsObject;
@@ -239,6 +241,7 @@ export class BackupWorkspaceRequest extends jspb.Message {
 export namespace BackupWorkspaceRequest {
     export type AsObject = {
         id: string,
+        periodic: boolean,
     }
 }
 
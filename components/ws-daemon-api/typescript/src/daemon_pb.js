@@ -1737,7 +1737,8 @@ proto.wsdaemon.BackupWorkspaceRequest.prototype.toObject = function(opt_includeI
  */
 proto.wsdaemon.BackupWorkspaceRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    id: jspb.Message.getFieldWithDefault(msg, 1, "")
+    id: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    periodic: jspb.Message.getBooleanFieldWithDefault(msg, 2, false)
   };
 
   if (includeInstance) {
@@ -1778,6 +1779,10 @@ proto.wsdaemon.BackupWorkspaceRequest.deserializeBinaryFromReader = function(msg
       var value = /** @type {string} */ (reader.readString());
       msg.setId(value);
       break;
+    case 2:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setPeriodic(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1814,6 +1819,13 @@ proto.wsdaemon.BackupWorkspaceRequest.serializeBinaryToWriter = function(message
       f
     );
   }
+  f = message.getPeriodic();
+  if (f) {
+    writer.writeBool(
+      2,
+      f
+    );
+  }
 };
 
 
@@ -1835,6 +1847,24 @@ proto.wsdaemon.BackupWorkspaceRequest.prototype.setId = function(value) {
 };
 
 
+/**
+ * optional bool periodic = 2;
+ * @return {boolean}
+ */
+proto.wsdaemon.BackupWorkspaceRequest.prototype.getPeriodic = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 2, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.wsdaemon.BackupWorkspaceRequest} returns this
+ */
+proto.wsdaemon.BackupWorkspaceRequest.prototype.setPeriodic = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 2, value);
+};
+
+
 
 
 
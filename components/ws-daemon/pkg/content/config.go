@@ -10,6 +10,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/util"
 	cntntcfg "github.com/gitpod-io/gitpod/content-service/api/config"
 	"github.com/gitpod-io/gitpod/ws-daemon/api"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/gitcache"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/quota"
 	"golang.org/x/xerrors"
 )
@@ -46,6 +47,11 @@ type Config struct {
 
 		// Period is the time between regular workspace backups
 		Period util.Duration `json:"period"`
+
+		// Chunked enables incremental backups. Instead of uploading the whole workspace tarball on every backup,
+		// it's split into content-defined chunks of which only the new ones are uploaded.
+		// Does not apply to full workspace backups and snapshots.
+		Chunked bool `json:"chunked,omitempty"`
 	} `json:"backup,omitempty"`
 
 	// UserNamespaces configures the behaviour of the user-namespace support
@@ -61,6 +67,9 @@ type Config struct {
 		// Args are additional arguments to pass to the CI runtime
 		Args []string `json:"args"`
 	} `json:"initializer"`
+
+	// GitCache configures the node-local Git object cache which clones in workspaces borrow objects from
+	GitCache gitcache.Config `json:"gitCache,omitempty"`
 }
 
 type FSShiftMethod api.FSShiftMethod
@@ -0,0 +1,100 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package content
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/git"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/internal/session"
+)
+
+// gitCacheSeedTimeout is the maximum time we spend seeding the Git cache from a workspace
+const gitCacheSeedTimeout = 2 * time.Minute
+
+// acquireGitReferences finds the cached repositories for all Git remotes an initializer clones.
+// The repositories are not evicted from the cache until release is called.
+func (s *WorkspaceService) acquireGitReferences(initializer *csapi.WorkspaceInitializer) (refs map[string]string, release func()) {
+	if s.gitCache == nil {
+		return nil, func() {}
+	}
+
+	var releases []func()
+	for _, gi := range gitInitializers(initializer, true) {
+		if _, exists := refs[gi.RemoteUri]; exists {
+			continue
+		}
+
+		path, rel := s.gitCache.Acquire(gi.RemoteUri)
+		if path == "" {
+			continue
+		}
+		if refs == nil {
+			refs = make(map[string]string)
+		}
+		refs[gi.RemoteUri] = path
+		releases = append(releases, rel)
+	}
+	return refs, func() {
+		for _, rel := range releases {
+			rel()
+		}
+	}
+}
+
+// seedGitCache adds the objects of the clones in a workspace to the Git cache.
+// Shallow and partial clones cannot serve as reference repositories and are ignored.
+// Seeding takes a while, hence callers should not wait for it, e.g. by running it in a goroutine.
+func (s *WorkspaceService) seedGitCache(ctx context.Context, workspace *session.Workspace, initializer *csapi.WorkspaceInitializer) {
+	if s.gitCache == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gitCacheSeedTimeout)
+	defer cancel()
+
+	// prebuild initializer are ignored because their working copy stems from the prebuild, not the remote
+	for _, gi := range gitInitializers(initializer, false) {
+		if gi.CloneDepth > 0 || gi.CloneFilter != csapi.CloneFilter_NO_FILTER {
+			continue
+		}
+		loc := filepath.Join(workspace.Location, gi.CheckoutLocation)
+		if !git.IsWorkingCopy(loc) {
+			// the initializer might have failed on a best-effort basis
+			continue
+		}
+
+		err := s.gitCache.Seed(ctx, gi.RemoteUri, loc)
+		if err != nil {
+			log.WithError(err).WithFields(workspace.OWI()).WithField("location", gi.CheckoutLocation).Warn("cannot seed Git cache")
+		}
+	}
+}
+
+// gitInitializers returns all Git initializer of a workspace initializer, including those which are part of
+// a composite initializer and, if withPrebuild is true, of a prebuild initializer.
+func gitInitializers(initializer *csapi.WorkspaceInitializer, withPrebuild bool) (res []*csapi.GitInitializer) {
+	switch spec := initializer.GetSpec().(type) {
+	case *csapi.WorkspaceInitializer_Git:
+		if spec.Git != nil {
+			res = append(res, spec.Git)
+		}
+	case *csapi.WorkspaceInitializer_Prebuild:
+		if withPrebuild && spec.Prebuild != nil && spec.Prebuild.Git != nil {
+			res = append(res, spec.Prebuild.Git)
+		}
+	case *csapi.WorkspaceInitializer_Composite:
+		if spec.Composite != nil {
+			for _, c := range spec.Composite.Initializer {
+				res = append(res, gitInitializers(c, withPrebuild)...)
+			}
+		}
+	}
+	return res
+}
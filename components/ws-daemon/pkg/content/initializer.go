@@ -9,17 +9,22 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 	"golang.org/x/xerrors"
 	"google.golang.org/protobuf/proto"
 
@@ -44,6 +49,13 @@ type RunInitializerOpts struct {
 	GID uint32
 
 	OWI OWI
+
+	// DataKeys decrypt encrypted remote content. Can be nil if encryption is not configured.
+	DataKeys storage.DataKeys
+
+	// GitReferences maps Git remote URIs to local repositories which clones of that remote borrow objects from.
+	// The repositories are made available read-only to the initializer.
+	GitReferences map[string]string
 }
 
 type OWI struct {
@@ -56,6 +68,9 @@ func (o OWI) Fields() map[string]interface{} {
 	return log.OWI(o.Owner, o.WorkspaceID, o.InstanceID)
 }
 
+// maxParallelChunkSigning is the number of chunk download URLs we sign concurrently
+const maxParallelChunkSigning = 16
+
 // errors to be tested with errors.Is
 var (
 	// cannot find snapshot
@@ -65,13 +80,39 @@ var (
 func collectRemoteContent(ctx context.Context, rs storage.DirectAccess, ps storage.PresignedAccess, workspaceOwner string, initializer *csapi.WorkspaceInitializer) (rc map[string]storage.DownloadInfo, err error) {
 	rc = make(map[string]storage.DownloadInfo)
 
-	backup, err := ps.SignDownload(ctx, rs.Bucket(workspaceOwner), rs.BackupObject(storage.DefaultBackup), &storage.SignedURLOptions{})
+	// a backup selected from the backup history or trail replaces the current backup of the workspace
+	var backupID string
+	if bi := initializer.GetBackup(); bi != nil && (bi.BackupId != "" || bi.Timestamp > 0) {
+		backupID, err = resolveBackup(ctx, rs, bi)
+		if err != nil {
+			return nil, err
+		}
+	}
+	chunkIndex, full, err := storage.BackupObjects(backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chunkIndex != "" {
+		chunks, err := collectChunkedBackup(ctx, rs, ps, workspaceOwner, chunkIndex)
+		if err != nil {
+			return nil, err
+		}
+		for name, info := range chunks {
+			rc[name] = info
+		}
+	}
+
+	backup, err := ps.SignDownload(ctx, rs.Bucket(workspaceOwner), rs.BackupObject(full), &storage.SignedURLOptions{})
 	if err == storage.ErrNotFound {
 		// no backup found - that's fine
 	} else if err != nil {
 		return nil, err
 	} else {
-		rc[storage.DefaultBackup] = *backup
+		rc[full] = *backup
+	}
+	if backupID != "" && len(rc) == 0 {
+		return nil, xerrors.Errorf("backup %s not found", backupID)
 	}
 
 	if si := initializer.GetSnapshot(); si != nil {
@@ -107,6 +148,75 @@ func collectRemoteContent(ctx context.Context, rs storage.DirectAccess, ps stora
 	return rc, nil
 }
 
+// resolveBackup returns the ID of the backup a backup initializer selects from the backup history or trail.
+// The initializer finds the same backup later on because we sign the objects of that backup only.
+func resolveBackup(ctx context.Context, rs storage.DirectAccess, bi *csapi.FromBackupInitializer) (backupID string, err error) {
+	if bi.BackupId != "" {
+		return bi.BackupId, nil
+	}
+
+	backups, err := storage.ListDirectBackups(ctx, rs)
+	if err != nil {
+		return "", err
+	}
+	pointInTime := time.Unix(bi.Timestamp, 0)
+	backup, ok := storage.BackupAt(backups, pointInTime)
+	if !ok {
+		return "", xerrors.Errorf("no backup found before %s", pointInTime.UTC().Format(time.RFC3339))
+	}
+	return backup.ID, nil
+}
+
+// collectChunkedBackup signs the chunk index and all chunks of a chunked backup, if there is one
+func collectChunkedBackup(ctx context.Context, rs storage.DirectAccess, ps storage.PresignedAccess, workspaceOwner, chunkIndex string) (rc map[string]storage.DownloadInfo, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "collectChunkedBackup")
+	span.SetTag("index", chunkIndex)
+	defer tracing.FinishSpan(span, &err)
+
+	names, err := storage.ChunkedBackupObjects(ctx, rs, chunkIndex)
+	if err == storage.ErrNotFound {
+		// no chunked backup found - that's fine
+		return nil, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read chunk index: %w", err)
+	}
+	span.LogKV("objects", len(names))
+
+	var (
+		mu   sync.Mutex
+		sema = make(chan struct{}, maxParallelChunkSigning)
+		eg   errgroup.Group
+	)
+	rc = make(map[string]storage.DownloadInfo, len(names))
+	for _, name := range names {
+		name := name
+		eg.Go(func() error {
+			sema <- struct{}{}
+			defer func() { <-sema }()
+
+			info, err := ps.SignDownload(ctx, rs.Bucket(workspaceOwner), rs.BackupObject(name), &storage.SignedURLOptions{})
+			if err == storage.ErrNotFound {
+				return xerrors.Errorf("chunked backup is incomplete: %s is missing", name)
+			}
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			rc[name] = *info
+			mu.Unlock()
+			return nil
+		})
+	}
+	err = eg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return rc, nil
+}
+
 // RunInitializer runs a content initializer in a user, PID and mount namespace to isolate it from ws-daemon
 func RunInitializer(ctx context.Context, destination string, initializer *csapi.WorkspaceInitializer, remoteContent map[string]storage.DownloadInfo, opts RunInitializerOpts) (err error) {
 	//nolint:ineffassign,staticcheck
@@ -152,12 +262,34 @@ func RunInitializer(ctx context.Context, destination string, initializer *csapi.
 		GID:           int(opts.GID),
 		UID:           int(opts.UID),
 		OWI:           opts.OWI.Fields(),
+
+		DataKeys: opts.DataKeys,
+	}
+	var gitReferenceMounts []specs.Mount
+	if len(opts.GitReferences) > 0 {
+		msg.GitReferences = make(map[string]string, len(opts.GitReferences))
+		for remote, repo := range opts.GitReferences {
+			dst := filepath.Join("/gitcache", filepath.Base(repo))
+			msg.GitReferences[remote] = dst
+			gitReferenceMounts = append(gitReferenceMounts, specs.Mount{
+				Destination: dst,
+				Source:      repo,
+				Type:        "bind",
+				Options:     []string{"bind", "rprivate", "ro"},
+			})
+		}
 	}
 	fc, err := json.MarshalIndent(msg, "", "  ")
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(filepath.Join(tmpdir, "rootfs", "content.json"), fc, 0644)
+	// content.json holds the owner's data key, hence only the initializer may read it
+	contentFN := filepath.Join(tmpdir, "rootfs", "content.json")
+	err = os.WriteFile(contentFN, fc, 0600)
+	if err != nil {
+		return err
+	}
+	err = os.Chown(contentFN, int(opts.UID), int(opts.GID))
 	if err != nil {
 		return err
 	}
@@ -195,6 +327,7 @@ func RunInitializer(ctx context.Context, destination string, initializer *csapi.
 		Type:        "bind",
 		Options:     []string{"bind", "rprivate"},
 	})
+	spec.Mounts = append(spec.Mounts, gitReferenceMounts...)
 
 	spec.Hostname = "content-init"
 	spec.Process.Terminal = false
@@ -307,8 +440,14 @@ func RunInitializerChild() (err error) {
 	}
 
 	rs := &remoteContentStorage{RemoteContent: initmsg.RemoteContent}
+	if len(initmsg.DataKeys) > 0 {
+		rs.Keyring = initmsg.DataKeys
+	}
 
-	initializer, err := wsinit.NewFromRequest(ctx, "/dst", rs, &req, wsinit.NewFromRequestOpts{ForceGitpodUserForGit: false})
+	initializer, err := wsinit.NewFromRequest(ctx, "/dst", rs, &req, wsinit.NewFromRequestOpts{
+		ForceGitpodUserForGit: false,
+		GitReferences:         initmsg.GitReferences,
+	})
 	if err != nil {
 		return err
 	}
@@ -324,7 +463,7 @@ func RunInitializerChild() (err error) {
 	}
 
 	// Place the ready file to make Theia "open its gates"
-	err = wsinit.PlaceWorkspaceReadyFile(ctx, "/dst", initSource, initmsg.UID, initmsg.GID)
+	err = wsinit.PlaceWorkspaceReadyFile(ctx, "/dst", initSource, initializer, initmsg.UID, initmsg.GID)
 	if err != nil {
 		return err
 	}
@@ -336,6 +475,7 @@ var _ storage.DirectAccess = &remoteContentStorage{}
 
 type remoteContentStorage struct {
 	RemoteContent map[string]storage.DownloadInfo
+	Keyring       storage.Keyring
 }
 
 // Init does nothing
@@ -361,7 +501,12 @@ func (rs *remoteContentStorage) Download(ctx context.Context, destination string
 	}
 	defer resp.Body.Close()
 
-	err = archive.ExtractTarbal(ctx, resp.Body, destination, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
+	body, err := storage.Decrypt(resp.Body, rs.Keyring)
+	if err != nil {
+		return true, err
+	}
+
+	err = archive.ExtractTarbal(ctx, body, destination, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
 	if err != nil {
 		return true, xerrors.Errorf("tar %s: %s", destination, err.Error())
 	}
@@ -374,9 +519,39 @@ func (rs *remoteContentStorage) DownloadSnapshot(ctx context.Context, destinatio
 	return rs.Download(ctx, destination, name, mappings)
 }
 
-// ListObjects returns all objects found with the given prefix. Returns an empty list if the bucket does not exuist (yet).
+// DownloadRaw opens an object for reading without extracting it
+func (rs *remoteContentStorage) DownloadRaw(ctx context.Context, name string) (io.ReadCloser, error) {
+	info, exists := rs.RemoteContent[name]
+	if !exists {
+		return nil, storage.ErrNotFound
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, info.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, xerrors.Errorf("cannot download %s: status %d", name, resp.StatusCode)
+	}
+
+	return storage.DecryptReadCloser(resp.Body, rs.Keyring)
+}
+
+// ListObjects returns all remote content whose name has the given prefix
 func (rs *remoteContentStorage) ListObjects(ctx context.Context, prefix string) (objects []string, err error) {
-	return []string{}, nil
+	objects = []string{}
+	for name := range rs.RemoteContent {
+		if strings.HasPrefix(name, prefix) {
+			objects = append(objects, name)
+		}
+	}
+	sort.Strings(objects)
+	return objects, nil
 }
 
 // Qualify just returns the name
@@ -423,4 +598,8 @@ type msgInitContent struct {
 
 	TraceInfo string
 	OWI       map[string]interface{}
+
+	DataKeys storage.DataKeys
+
+	GitReferences map[string]string
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 	"github.com/gitpod-io/gitpod/ws-daemon/api"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/container"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/gitcache"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/internal/session"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/iws"
 )
@@ -46,6 +47,9 @@ type WorkspaceService struct {
 	stopService context.CancelFunc
 	runtime     container.Runtime
 
+	gitCache *gitcache.Cache
+	lfsBytes prometheus.Counter
+
 	api.UnimplementedInWorkspaceServiceServer
 	api.UnimplementedWorkspaceContentServiceServer
 }
@@ -54,7 +58,7 @@ type WorkspaceService struct {
 type WorkspaceExistenceCheck func(instanceID string) bool
 
 // NewWorkspaceService creates a new workspce initialization service, starts housekeeping and the Prometheus integration
-func NewWorkspaceService(ctx context.Context, cfg Config, kubernetesNamespace string, runtime container.Runtime, wec WorkspaceExistenceCheck, uidmapper *iws.Uidmapper, reg prometheus.Registerer) (res *WorkspaceService, err error) {
+func NewWorkspaceService(ctx context.Context, cfg Config, kubernetesNamespace string, runtime container.Runtime, wec WorkspaceExistenceCheck, uidmapper *iws.Uidmapper, gitCache *gitcache.Cache, reg prometheus.Registerer) (res *WorkspaceService, err error) {
 	//nolint:ineffassign
 	span, ctx := opentracing.StartSpanFromContext(ctx, "NewWorkspaceService")
 	defer tracing.FinishSpan(span, &err)
@@ -75,6 +79,14 @@ func NewWorkspaceService(ctx context.Context, cfg Config, kubernetesNamespace st
 	if err := registerWorkingAreaDiskspaceGauge(cfg.WorkingArea, reg); err != nil {
 		log.WithError(err).Warn("cannot register Prometheus gauge for working area diskspace")
 	}
+	lfsBytes := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "content_initializer_lfs_bytes_total",
+		Help: "Amount of Git LFS bytes fetched during workspace initialization",
+	})
+	if err := reg.Register(lfsBytes); err != nil {
+		log.WithError(err).Warn("cannot register Prometheus counter for Git LFS bytes")
+		lfsBytes = nil
+	}
 
 	return &WorkspaceService{
 		config:      cfg,
@@ -82,6 +94,8 @@ func NewWorkspaceService(ctx context.Context, cfg Config, kubernetesNamespace st
 		ctx:         ctx,
 		stopService: stopService,
 		runtime:     runtime,
+		gitCache:    gitCache,
+		lfsBytes:    lfsBytes,
 	}, nil
 }
 
@@ -205,6 +219,12 @@ func (s *WorkspaceService) InitWorkspace(ctx context.Context, req *api.InitWorks
 
 		// Initialize workspace.
 		// FWB workspaces initialize without the help of ws-daemon, but using their supervisor or the registry-facade.
+		keyring, err := storage.NewKeyring(s.config.Storage.Encryption)
+		if err != nil {
+			log.WithError(err).Error("cannot load key-encryption key")
+			return nil, status.Error(codes.Internal, "remote content error")
+		}
+
 		opts := RunInitializerOpts{
 			Command: s.config.Initializer.Command,
 			Args:    s.config.Initializer.Args,
@@ -225,12 +245,37 @@ func (s *WorkspaceService) InitWorkspace(ctx context.Context, req *api.InitWorks
 				InstanceID:  req.Id,
 			},
 		}
+		if keyring != nil {
+			// the initializer runs Git on untrusted repositories, hence it gets the owner's data key only
+			key, _, err := keyring.DataKey(req.Metadata.Owner)
+			if err != nil {
+				log.WithError(err).Error("cannot derive data key")
+				return nil, status.Error(codes.Internal, "remote content error")
+			}
+			opts.DataKeys = storage.DataKeys{req.Metadata.Owner: key}
+		}
 
+		var releaseGitReferences func()
+		opts.GitReferences, releaseGitReferences = s.acquireGitReferences(req.Initializer)
 		err = RunInitializer(ctx, workspace.Location, req.Initializer, remoteContent, opts)
+		releaseGitReferences()
 		if err != nil {
 			log.WithError(err).WithField("workspaceId", req.Id).Error("cannot initialize workspace")
 			return nil, status.Error(codes.Internal, fmt.Sprintf("cannot initialize workspace: %s", err.Error()))
 		}
+
+		ready, err := readWorkspaceReadyMessage(workspace.Location)
+		if err != nil {
+			log.WithError(err).WithFields(workspace.OWI()).Warn("cannot read workspace ready file - not reporting Git LFS bytes")
+		} else {
+			s.reportLFSBytes(workspace, ready)
+			if ready.Source == csapi.WorkspaceInitFromOther {
+				// The workspace content stems from a clone. Seeding must not delay the workspace start and outlives
+				// this request, hence the workspace may be in use and its working copies changed by then. That's fine:
+				// clones borrow objects from the cache only, which Git addresses by their hash.
+				go s.seedGitCache(s.ctx, workspace, req.Initializer)
+			}
+		}
 	}
 
 	// Tell the world we're done
@@ -243,6 +288,33 @@ func (s *WorkspaceService) InitWorkspace(ctx context.Context, req *api.InitWorks
 	return &api.InitWorkspaceResponse{}, nil
 }
 
+// readWorkspaceReadyMessage reads the workspace ready file the initializer placed in a workspace
+func readWorkspaceReadyMessage(location string) (*csapi.WorkspaceReadyMessage, error) {
+	fc, err := os.ReadFile(filepath.Join(location, wsinit.WorkspaceReadyFile))
+	if err != nil {
+		return nil, err
+	}
+	var msg csapi.WorkspaceReadyMessage
+	err = json.Unmarshal(fc, &msg)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot parse workspace ready file: %w", err)
+	}
+	return &msg, nil
+}
+
+// reportLFSBytes records the Git LFS bytes the initializer fetched, as reported in the workspace ready file
+func (s *WorkspaceService) reportLFSBytes(workspace *session.Workspace, msg *csapi.WorkspaceReadyMessage) {
+	if msg.LFSBytes <= 0 {
+		// Counter.Add panics on negative values
+		return
+	}
+
+	log.WithFields(workspace.OWI()).WithField("lfsBytes", msg.LFSBytes).Info("initializer fetched Git LFS objects")
+	if s.lfsBytes != nil {
+		s.lfsBytes.Add(float64(msg.LFSBytes))
+	}
+}
+
 func (s *WorkspaceService) creator(req *api.InitWorkspaceRequest) session.WorkspaceFactory {
 	return func(ctx context.Context, location string) (res *session.Workspace, err error) {
 		return &session.Workspace{
@@ -343,7 +415,10 @@ func (s *WorkspaceService) DisposeWorkspace(ctx context.Context, req *api.Dispos
 			backupName = fmt.Sprintf(storage.FmtFullWorkspaceBackup, time.Now().UnixNano())
 		}
 
+		// we've marked the workspace as disposing already, hence no other backup can start after this one
+		unlock := sess.LockBackup()
 		err = s.uploadWorkspaceContent(ctx, sess, backupName, mfName)
+		unlock()
 		if err != nil {
 			log.WithError(err).WithFields(sess.OWI()).Error("final backup failed")
 			return nil, status.Error(codes.DataLoss, "final backup failed")
@@ -409,9 +484,17 @@ func (s *WorkspaceService) uploadWorkspaceContent(ctx context.Context, sess *ses
 		log.WithError(err).WithFields(sess.OWI()).Warn("cannot remove workspace ready file")
 	}
 
-	if s.config.Storage.BackupTrail.Enabled && !sess.FullWorkspaceBackup {
+	if s.config.Storage.BackupTrail.Enabled && !sess.FullWorkspaceBackup && !s.chunkedBackup(sess, backupName) {
+		// chunk indexes cannot be trailed - the trail holds tarballs only
 		opts = append(opts, storage.WithBackupTrail("trail", s.config.Storage.BackupTrail.MaxLength))
 	}
+	if s.config.Storage.BackupRetention.Enabled && !sess.FullWorkspaceBackup && backupName == storage.DefaultBackup {
+		opts = append(opts, storage.WithBackupHistory())
+	}
+	if s.config.Storage.Encryption.Enabled && !sess.FullWorkspaceBackup {
+		// FWB layers are served by registry-facade as they are, hence we cannot encrypt them
+		opts = append(opts, storage.WithEncryption())
+	}
 
 	rs, ok := sess.NonPersistentAttrs[session.AttrRemoteStorage].(storage.DirectAccess)
 	if rs == nil || !ok {
@@ -479,6 +562,22 @@ func (s *WorkspaceService) uploadWorkspaceContent(ctx context.Context, sess *ses
 		}
 	}()
 
+	if s.chunkedBackup(sess, backupName) {
+		err = retryIfErr(ctx, s.config.Backup.Attempts, log.WithFields(sess.OWI()).WithField("op", "upload chunks"), func(ctx context.Context) (err error) {
+			_, _, stats, err := storage.UploadChunked(ctx, rs, tmpf.Name(), storage.DefaultBackupChunkIndex, s.config.TmpDir, opts...)
+			if err != nil {
+				return
+			}
+			log.WithFields(sess.OWI()).WithField("stats", stats).Debug("uploaded chunked backup")
+			return
+		})
+		if err != nil {
+			return xerrors.Errorf("cannot upload workspace content: %w", err)
+		}
+		s.deleteUnreferencedChunks(ctx, sess, rs)
+		return nil
+	}
+
 	var (
 		layerBucket string
 		layerObject string
@@ -507,6 +606,18 @@ func (s *WorkspaceService) uploadWorkspaceContent(ctx context.Context, sess *ses
 		return xerrors.Errorf("cannot upload workspace content: %w", err)
 	}
 
+	if backupName == storage.DefaultBackup && !sess.FullWorkspaceBackup {
+		// A chunk index left over from when chunked backups were enabled would take precedence over
+		// the tarball we've just uploaded during the next restore. We must not let that happen.
+		err = retryIfErr(ctx, s.config.Backup.Attempts, log.WithFields(sess.OWI()).WithField("op", "delete chunk index"), func(ctx context.Context) error {
+			return s.deleteChunkIndex(ctx, sess, rs)
+		})
+		if err != nil {
+			return xerrors.Errorf("cannot delete stale chunk index: %w", err)
+		}
+		s.deleteUnreferencedChunks(ctx, sess, rs)
+	}
+
 	err = retryIfErr(ctx, s.config.Backup.Attempts, log.WithFields(sess.OWI()).WithField("op", "upload manifest"), func(ctx context.Context) (err error) {
 		if !sess.FullWorkspaceBackup {
 			return
@@ -562,6 +673,42 @@ func (s *WorkspaceService) uploadWorkspaceContent(ctx context.Context, sess *ses
 	return nil
 }
 
+// deleteChunkIndex removes the chunk index of the default backup if there is one
+func (s *WorkspaceService) deleteChunkIndex(ctx context.Context, sess *session.Workspace, rs storage.DirectAccess) error {
+	ps, err := storage.NewPresignedAccess(&s.config.Storage)
+	if err != nil {
+		return err
+	}
+	err = ps.DeleteObject(ctx, rs.Bucket(sess.Owner), &storage.DeleteObjectQuery{Name: rs.BackupObject(storage.DefaultBackupChunkIndex)})
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// deleteUnreferencedChunks removes the chunks no chunk index refers to anymore. Failing to do so only costs storage,
+// hence we do not fail the backup because of it.
+func (s *WorkspaceService) deleteUnreferencedChunks(ctx context.Context, sess *session.Workspace, rs storage.DirectAccess) {
+	ps, err := storage.NewPresignedAccess(&s.config.Storage)
+	if err != nil {
+		log.WithError(err).WithFields(sess.OWI()).Warn("cannot delete unreferenced backup chunks")
+		return
+	}
+	deleted, err := storage.DeleteUnreferencedChunks(ctx, rs, ps, sess.Owner)
+	if err != nil {
+		log.WithError(err).WithFields(sess.OWI()).Warn("cannot delete unreferenced backup chunks")
+		return
+	}
+	if deleted > 0 {
+		log.WithFields(sess.OWI()).WithField("deleted", deleted).Debug("deleted unreferenced backup chunks")
+	}
+}
+
+// chunkedBackup determines if a backup is uploaded as content-defined chunks rather than a single tarball
+func (s *WorkspaceService) chunkedBackup(sess *session.Workspace, backupName string) bool {
+	return s.config.Backup.Chunked && !sess.FullWorkspaceBackup && backupName == storage.DefaultBackup
+}
+
 func (s *WorkspaceService) uploadWorkspaceLogs(ctx context.Context, sess *session.Workspace) (err error) {
 	rs, ok := sess.NonPersistentAttrs[session.AttrRemoteStorage].(storage.DirectAccess)
 	if rs == nil || !ok {
@@ -732,23 +879,32 @@ func (s *WorkspaceService) BackupWorkspace(ctx context.Context, req *api.BackupW
 		return nil, status.Error(codes.Internal, "workspace has no remote storage")
 	}
 
+	unlock := sess.LockBackup()
+	defer unlock()
+	if req.Periodic && !sess.IsReady() {
+		// The workspace is being disposed of. Its final backup is more recent than ours would be.
+		return nil, status.Error(codes.FailedPrecondition, "workspace content is not ready")
+	}
+
 	backupName := storage.DefaultBackup
 	var mfName = storage.DefaultBackupManifest
 	// TODO: do we want this always in the worse case?
 	if sess.FullWorkspaceBackup {
 		backupName = fmt.Sprintf(storage.FmtFullWorkspaceBackup, time.Now().UnixNano())
 	}
-	log.WithField("workspaceId", sess.WorkspaceID).WithField("instanceID", sess.InstanceID).WithField("backupName", backupName).Info("backing up")
+	log.WithField("workspaceId", sess.WorkspaceID).WithField("instanceID", sess.InstanceID).WithField("backupName", backupName).WithField("periodic", req.Periodic).Info("backing up")
 
 	err = s.uploadWorkspaceContent(ctx, sess, backupName, mfName)
 	if err != nil {
-		log.WithError(err).WithFields(sess.OWI()).Error("final backup failed")
-		return nil, status.Error(codes.DataLoss, "final backup failed")
+		log.WithError(err).WithFields(sess.OWI()).Error("backup failed")
+		return nil, status.Error(codes.DataLoss, "backup failed")
 	}
 
 	var qualifiedName string
 	if sess.FullWorkspaceBackup {
 		qualifiedName = rs.Qualify(mfName)
+	} else if s.chunkedBackup(sess, backupName) {
+		qualifiedName = rs.Qualify(storage.DefaultBackupChunkIndex)
 	} else {
 		qualifiedName = rs.Qualify(backupName)
 	}
@@ -23,6 +23,7 @@ import (
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/content"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/diskguard"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/dispatch"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/gitcache"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/hosts"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/iws"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/resources"
@@ -61,6 +62,11 @@ func NewDaemon(config Config, reg prometheus.Registerer) (*Daemon, error) {
 		return nil, err
 	}
 
+	gitCache, err := gitcache.New(config.Content.GitCache, reg)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create Git cache: %w", err)
+	}
+
 	contentService, err := content.NewWorkspaceService(
 		context.Background(),
 		config.Content,
@@ -68,13 +74,18 @@ func NewDaemon(config Config, reg prometheus.Registerer) (*Daemon, error) {
 		containerRuntime,
 		dsptch.WorkspaceExistsOnNode,
 		&iws.Uidmapper{Config: config.Uidmapper, Runtime: containerRuntime},
+		gitCache,
 		reg,
 	)
 	if err != nil {
 		return nil, xerrors.Errorf("cannot create content service: %w", err)
 	}
 
-	dsk := diskguard.FromConfig(config.DiskSpaceGuard, clientset, nodename)
+	var reclaimers []diskguard.Reclaimer
+	if gitCache != nil {
+		reclaimers = append(reclaimers, gitCache)
+	}
+	dsk := diskguard.FromConfig(config.DiskSpaceGuard, clientset, nodename, reclaimers...)
 
 	hsts, err := hosts.FromConfig(config.Hosts, clientset, config.Runtime.KubernetesNamespace)
 	if err != nil {
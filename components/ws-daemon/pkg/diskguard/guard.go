@@ -34,20 +34,37 @@ type Config struct {
 	} `json:"locations"`
 }
 
-// FromConfig produces a set of disk space guards from the configuration
-func FromConfig(cfg Config, clientset kubernetes.Interface, nodeName string) []*Guard {
+// Reclaimer can free disk space on demand, e.g. by evicting cache entries
+type Reclaimer interface {
+	// Location is the path whose disk the reclaimer frees space on
+	Location() string
+
+	// Reclaim tries to free the given number of bytes and returns the number of bytes freed
+	Reclaim(bytes int64) (freed int64)
+}
+
+// FromConfig produces a set of disk space guards from the configuration. Guards ask the reclaimers
+// which free space on their disk to do so before they label the node.
+func FromConfig(cfg Config, clientset kubernetes.Interface, nodeName string, reclaimers ...Reclaimer) []*Guard {
 	if !cfg.Enabled {
 		return nil
 	}
 
 	res := make([]*Guard, len(cfg.Locations))
 	for i, loc := range cfg.Locations {
+		var rs []Reclaimer
+		for _, r := range reclaimers {
+			if onSameDevice(loc.Path, r.Location()) {
+				rs = append(rs, r)
+			}
+		}
 		res[i] = &Guard{
 			Path:          loc.Path,
 			MinBytesAvail: loc.MinBytesAvail,
 			Interval:      time.Duration(cfg.Interval),
 			Clientset:     clientset,
 			Nodename:      nodeName,
+			Reclaimers:    rs,
 		}
 	}
 
@@ -64,6 +81,9 @@ type Guard struct {
 	Interval      time.Duration
 	Clientset     kubernetes.Interface
 	Nodename      string
+
+	// Reclaimers free space on the guarded disk before the node is labeled
+	Reclaimers []Reclaimer
 }
 
 // Start starts the disk guard
@@ -78,6 +98,10 @@ func (g *Guard) Start() {
 		log.WithField("bvail", bvail).WithField("minBytesAvail", g.MinBytesAvail).Debug("checked for available disk space")
 
 		addLabel := bvail <= g.MinBytesAvail
+		if addLabel && len(g.Reclaimers) > 0 {
+			bvail = g.reclaim(bvail)
+			addLabel = bvail <= g.MinBytesAvail
+		}
 		err = g.setLabel(LabelDiskPressure, addLabel)
 		if err != nil {
 			log.WithError(err).Error("cannot update node label")
@@ -87,6 +111,30 @@ func (g *Guard) Start() {
 	}
 }
 
+// reclaim asks the reclaimers to free enough space to get above the minimum available bytes
+// and returns the bytes available afterwards
+func (g *Guard) reclaim(bvail uint64) uint64 {
+	var freed int64
+	for _, r := range g.Reclaimers {
+		need := int64(g.MinBytesAvail-bvail) + 1 - freed
+		if need <= 0 {
+			break
+		}
+		freed += r.Reclaim(need)
+	}
+	if freed == 0 {
+		return bvail
+	}
+	log.WithField("path", g.Path).WithField("freed", freed).Info("reclaimed disk space")
+
+	res, err := getAvailableBytes(g.Path)
+	if err != nil {
+		log.WithError(err).WithField("path", g.Path).Error("cannot check how much space is available")
+		return bvail
+	}
+	return res
+}
+
 // setLabel adds or removes the label from the node
 func (g *Guard) setLabel(label string, add bool) error {
 	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
@@ -118,6 +166,15 @@ func (g *Guard) setLabel(label string, add bool) error {
 	})
 }
 
+// onSameDevice returns true if both paths reside on the same device
+func onSameDevice(a, b string) bool {
+	var sa, sb syscall.Stat_t
+	if syscall.Stat(a, &sa) != nil || syscall.Stat(b, &sb) != nil {
+		return false
+	}
+	return sa.Dev == sb.Dev
+}
+
 func getAvailableBytes(path string) (bvail uint64, err error) {
 	var stat syscall.Statfs_t
 	err = syscall.Statfs(path, &stat)
@@ -0,0 +1,357 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package gitcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/quota"
+)
+
+// Config configures the node-local Git object cache
+type Config struct {
+	Enabled bool `json:"enabled"`
+
+	// Location is the directory in which the cache keeps a bare repository per remote
+	Location string `json:"location"`
+
+	// MaxSize is the eviction budget of the cache. Once the cache grows beyond it,
+	// the least recently used repositories are removed.
+	MaxSize quota.Size `json:"maxSize"`
+
+	// MinClones is the number of clones of a remote on this node after which the remote is cached.
+	// Remotes are cached on their first clone if this is zero.
+	MinClones int `json:"minClones,omitempty"`
+}
+
+// Cache keeps bare repositories of frequently cloned remotes on the node, which clones of these remotes
+// borrow objects from instead of fetching everything from the remote again (see git clone --reference).
+//
+// The cache is populated from fresh clones in workspaces, hence never needs to authenticate against a remote.
+type Cache struct {
+	Config Config
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	clones  map[string]int
+
+	lookups *prometheus.CounterVec
+}
+
+type entry struct {
+	Path     string
+	Size     int64
+	LastUsed time.Time
+
+	// ready is true once the repository was seeded at least once
+	ready bool
+	// seeding is true while the repository is being seeded
+	seeding bool
+	// leases is the number of clones currently borrowing objects from the repository
+	leases int
+}
+
+// New creates a new cache and restores the repositories a previous instance left in the cache location.
+// Returns nil if the cache is disabled.
+func New(cfg Config, reg prometheus.Registerer) (*Cache, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if cfg.Location == "" {
+		return nil, xerrors.Errorf("Git cache location is missing")
+	}
+
+	err := os.MkdirAll(cfg.Location, 0755)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create Git cache location: %w", err)
+	}
+	fs, err := os.ReadDir(cfg.Location)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read Git cache location: %w", err)
+	}
+
+	c := &Cache{
+		Config:  cfg,
+		entries: make(map[string]*entry),
+		clones:  make(map[string]int),
+		lookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gitcache_lookups_total",
+			Help: "Number of Git cache lookups by result",
+		}, []string{"result"}),
+	}
+	for _, f := range fs {
+		if !f.IsDir() || !strings.HasSuffix(f.Name(), ".git") {
+			continue
+		}
+
+		path := filepath.Join(cfg.Location, f.Name())
+		stat, err := f.Info()
+		if err != nil {
+			continue
+		}
+		size, err := dirSize(path)
+		if err != nil {
+			log.WithError(err).WithField("path", path).Warn("cannot determine size of cached Git repository - removing it")
+			_ = os.RemoveAll(path)
+			continue
+		}
+		c.entries[strings.TrimSuffix(f.Name(), ".git")] = &entry{
+			Path:     path,
+			Size:     size,
+			LastUsed: stat.ModTime(),
+			ready:    true,
+		}
+	}
+	c.mu.Lock()
+	c.evict(int64(cfg.MaxSize))
+	c.mu.Unlock()
+
+	if reg != nil {
+		err = reg.Register(c.lookups)
+		if err != nil {
+			log.WithError(err).Warn("cannot register Prometheus counter for Git cache lookups")
+		}
+		err = reg.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "gitcache_size_bytes",
+			Help: "Size of the Git cache",
+		}, func() float64 {
+			return float64(c.Size())
+		}))
+		if err != nil {
+			log.WithError(err).Warn("cannot register Prometheus gauge for Git cache size")
+		}
+	}
+
+	return c, nil
+}
+
+// Acquire returns the cached repository of a remote, if there is one. The repository is not evicted until release is called.
+// If the remote is not cached, path is empty.
+func (c *Cache) Acquire(remoteURI string) (path string, release func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key(remoteURI)]
+	if !ok || !e.ready {
+		c.lookups.WithLabelValues("miss").Inc()
+		return "", func() {}
+	}
+	c.lookups.WithLabelValues("hit").Inc()
+
+	e.leases++
+	e.LastUsed = time.Now()
+	// the modification time persists the LRU order across restarts
+	_ = os.Chtimes(e.Path, e.LastUsed, e.LastUsed)
+
+	var once sync.Once
+	return e.Path, func() {
+		once.Do(func() {
+			c.mu.Lock()
+			e.leases--
+			c.mu.Unlock()
+		})
+	}
+}
+
+// Seed adds the objects of a fresh clone of a remote to the cache. Remotes are cached once they were cloned
+// often enough (see Config.MinClones). Remotes which are cached already are refreshed with the objects of the clone,
+// so that the cache keeps up with the remote. The working copy must neither be shallow nor a partial clone.
+func (c *Cache) Seed(ctx context.Context, remoteURI, workingCopy string) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "gitcache.Seed")
+	defer tracing.FinishSpan(span, &err)
+
+	k := key(remoteURI)
+	c.mu.Lock()
+	e, ok := c.entries[k]
+	if !ok {
+		c.clones[k]++
+		if c.clones[k] < c.Config.MinClones {
+			c.mu.Unlock()
+			return nil
+		}
+		delete(c.clones, k)
+
+		e = &entry{Path: filepath.Join(c.Config.Location, k+".git")}
+		c.entries[k] = e
+	}
+	if e.seeding {
+		// someone else is seeding this repository right now - that's good enough
+		c.mu.Unlock()
+		return nil
+	}
+	e.seeding = true
+	c.mu.Unlock()
+
+	err = seed(ctx, e.Path, workingCopy)
+	var size int64
+	if err == nil {
+		size, err = dirSize(e.Path)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e.seeding = false
+	if err != nil {
+		if !e.ready {
+			delete(c.entries, k)
+			_ = os.RemoveAll(e.Path)
+		}
+		return xerrors.Errorf("cannot seed Git cache: %w", err)
+	}
+	e.ready = true
+	e.Size = size
+	e.LastUsed = time.Now()
+	span.SetTag("size", size)
+
+	c.evict(int64(c.Config.MaxSize))
+	return nil
+}
+
+// Reclaim evicts least recently used repositories until at least the given number of bytes
+// were freed, or no repository can be evicted anymore. Returns the number of bytes freed.
+func (c *Cache) Reclaim(bytes int64) (freed int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.evict(c.size() - bytes)
+}
+
+// Location returns the directory the cache lives in
+func (c *Cache) Location() string {
+	return c.Config.Location
+}
+
+// Size returns the total size of all cached repositories
+func (c *Cache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.size()
+}
+
+func (c *Cache) size() (total int64) {
+	for _, e := range c.entries {
+		total += e.Size
+	}
+	return
+}
+
+// evict removes least recently used repositories which are not in use until the cache is no larger than budget.
+// Callers must hold c.mu.
+func (c *Cache) evict(budget int64) (freed int64) {
+	if budget < 0 {
+		budget = 0
+	}
+
+	total := c.size()
+	if total <= budget {
+		return 0
+	}
+
+	keys := make([]string, 0, len(c.entries))
+	for k := range c.entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return c.entries[keys[i]].LastUsed.Before(c.entries[keys[j]].LastUsed)
+	})
+	for _, k := range keys {
+		if total <= budget {
+			break
+		}
+
+		e := c.entries[k]
+		if !e.ready || e.seeding || e.leases > 0 {
+			continue
+		}
+		err := os.RemoveAll(e.Path)
+		if err != nil {
+			log.WithError(err).WithField("path", e.Path).Warn("cannot evict cached Git repository")
+			continue
+		}
+		delete(c.entries, k)
+		total -= e.Size
+		freed += e.Size
+		log.WithField("path", e.Path).WithField("size", e.Size).Debug("evicted cached Git repository")
+	}
+	return freed
+}
+
+// seed fetches all remote branches and tags of a working copy into a bare repository. Fetching only ever adds objects,
+// hence the repository can be refreshed while clones borrow objects from it.
+func seed(ctx context.Context, repo, workingCopy string) error {
+	if _, err := os.Stat(repo); os.IsNotExist(err) {
+		err = git(ctx, "", "init", "--bare", "--quiet", repo)
+		if err != nil {
+			return err
+		}
+	}
+
+	return git(ctx, repo,
+		// the working copy belongs to the workspace user, not us
+		"-c", "safe.directory="+workingCopy,
+		"fetch", "--quiet", "--no-auto-gc", workingCopy,
+		"+refs/remotes/origin/*:refs/heads/*",
+		"+refs/tags/*:refs/tags/*",
+	)
+}
+
+func git(ctx context.Context, gitDir string, args ...string) error {
+	if gitDir != "" {
+		args = append([]string{"--git-dir", gitDir}, args...)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_LFS_SKIP_SMUDGE=1",
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return xerrors.Errorf("git %s: %s: %w", strings.Join(args, " "), string(out), err)
+	}
+	return nil
+}
+
+// key returns the name of the cached repository of a remote. Credentials in the remote URI are ignored.
+func key(remoteURI string) string {
+	if u, err := url.Parse(remoteURI); err == nil && u.User != nil {
+		u.User = nil
+		remoteURI = u.String()
+	}
+	h := sha256.Sum256([]byte(remoteURI))
+	return hex.EncodeToString(h[:])
+}
+
+// dirSize returns the total size of all regular files in a directory
+func dirSize(dir string) (size int64, err error) {
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return
+}
@@ -75,6 +75,7 @@ type Workspace struct {
 	state              WorkspaceState
 	stateLock          sync.RWMutex
 	operatingCondition *sync.Cond
+	backupLock         sync.Mutex
 }
 
 // OWI produces the owner, workspace, instance log metadata from the information
@@ -223,6 +224,13 @@ func (s *Workspace) Dispose(ctx context.Context) (err error) {
 	return nil
 }
 
+// LockBackup serialises backups of this workspace so that a slow backup never overwrites a more recent one.
+// Call the returned function to release the lock.
+func (s *Workspace) LockBackup() (unlock func()) {
+	s.backupLock.Lock()
+	return s.backupLock.Unlock
+}
+
 // IsReady returns true if the workspace is in the ready state
 func (s *Workspace) IsReady() bool {
 	s.stateLock.RLock()
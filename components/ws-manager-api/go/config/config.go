@@ -107,6 +107,15 @@ type Configuration struct {
 	RegistryFacadeHost string `json:"registryFacadeHost"`
 	// Cluster host under which workspaces are served, e.g. ws-eu11.gitpod.io
 	WorkspaceClusterHost string `json:"workspaceClusterHost"`
+	// PeriodicBackup configures regular backups of running workspaces
+	PeriodicBackup PeriodicBackupConfiguration `json:"periodicBackup,omitempty"`
+}
+
+// PeriodicBackupConfiguration configures regular backups of running workspaces, so that a workspace
+// whose node fails loses at most the changes made since its last backup.
+type PeriodicBackupConfiguration struct {
+	// Interval is the time between two backups of a running workspace. Zero disables periodic backups.
+	Interval util.Duration `json:"interval,omitempty"`
 }
 
 // AllContainerConfiguration contains the configuration for all container in a workspace pod
@@ -198,6 +207,9 @@ func (c *Configuration) Validate() error {
 	if c.Timeouts.Stopping < c.Timeouts.ContentFinalization {
 		return xerrors.Errorf("stopping timeout must be greater than content finalization timeout")
 	}
+	if c.PeriodicBackup.Interval < 0 {
+		return xerrors.Errorf("periodicBackup: interval must not be negative")
+	}
 
 	err = validation.ValidateStruct(&c.WorkspacePodTemplate,
 		validation.Field(&c.WorkspacePodTemplate.DefaultPath, validPodTemplate),
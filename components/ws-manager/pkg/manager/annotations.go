@@ -71,6 +71,12 @@ const (
 	// ownerTokenAnnotation contains the owner token of the workspace
 	ownerTokenAnnotation = "gitpod/ownerToken"
 
+	// portShareSecretAnnotation contains the secret which signs the share tokens of the workspace's ports
+	portShareSecretAnnotation = "gitpod/portShareSecret"
+
+	// sshGatewayTokenAnnotation contains the token the SSH gateway of ws-proxy logs into the workspace with
+	sshGatewayTokenAnnotation = "gitpod/sshGatewayToken"
+
 	// workspaceAdmissionAnnotation determines the user admission to a workspace, i.e. if it can be accessed by everyone without token
 	workspaceAdmissionAnnotation = "gitpod/admission"
 
@@ -283,6 +283,8 @@ func (m *Manager) createDefiniteWorkspacePod(startContext *startWorkspaceContext
 		workspaceAdmissionAnnotation:         admissionLevel,
 		workspaceImageSpecAnnotation:         imageSpec,
 		ownerTokenAnnotation:                 startContext.OwnerToken,
+		portShareSecretAnnotation:            startContext.PortShareSecret,
+		sshGatewayTokenAnnotation:            startContext.SSHGatewayToken,
 		wsk8s.TraceIDAnnotation:              startContext.TraceID,
 		wsk8s.RequiredNodeServicesAnnotation: "ws-daemon,registry-facade",
 		// TODO(cw): post Kubernetes 1.19 use GA form for settings those profiles
@@ -523,6 +525,8 @@ func (m *Manager) createWorkspaceEnvironment(startContext *startWorkspaceContext
 	result = append(result, corev1.EnvVar{Name: "GITPOD_WORKSPACE_URL", Value: startContext.WorkspaceURL})
 	result = append(result, corev1.EnvVar{Name: "GITPOD_WORKSPACE_CLUSTER_HOST", Value: m.Config.WorkspaceClusterHost})
 	result = append(result, corev1.EnvVar{Name: "THEIA_SUPERVISOR_ENDPOINT", Value: fmt.Sprintf(":%d", startContext.SupervisorPort)})
+	result = append(result, corev1.EnvVar{Name: "THEIA_SUPERVISOR_PORT_SHARE_SECRET", Value: startContext.PortShareSecret})
+	result = append(result, corev1.EnvVar{Name: "THEIA_SUPERVISOR_SSH_GATEWAY_TOKEN", Value: startContext.SSHGatewayToken})
 	// TODO(ak) remove THEIA_WEBVIEW_EXTERNAL_ENDPOINT and THEIA_MINI_BROWSER_HOST_PATTERN when Theia is removed
 	result = append(result, corev1.EnvVar{Name: "THEIA_WEBVIEW_EXTERNAL_ENDPOINT", Value: "webview-{{hostname}}"})
 	result = append(result, corev1.EnvVar{Name: "THEIA_MINI_BROWSER_HOST_PATTERN", Value: "browser-{{hostname}}"})
@@ -707,6 +711,16 @@ func (m *Manager) newStartWorkspaceContext(ctx context.Context, req *api.StartWo
 		return nil, xerrors.Errorf("cannot create owner token: %w", err)
 	}
 
+	portShareSecret, err := getRandomString(32)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create port share secret: %w", err)
+	}
+
+	sshGatewayToken, err := getRandomString(32)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create SSH gateway token: %w", err)
+	}
+
 	workspaceSpan := opentracing.StartSpan("workspace", opentracing.FollowsFrom(opentracing.SpanFromContext(ctx).Context()))
 	traceID := tracing.GetTraceID(workspaceSpan)
 
@@ -721,14 +735,17 @@ func (m *Manager) newStartWorkspaceContext(ctx context.Context, req *api.StartWo
 			headlessLabel:          fmt.Sprintf("%v", headless),
 			markerLabel:            "true",
 		},
-		CLIAPIKey:      cliAPIKey,
-		OwnerToken:     ownerToken,
-		Request:        req,
-		IDEPort:        23000,
-		SupervisorPort: 22999,
-		WorkspaceURL:   workspaceURL,
-		TraceID:        traceID,
-		Headless:       headless,
+		CLIAPIKey:       cliAPIKey,
+		OwnerToken:      ownerToken,
+		PortShareSecret: portShareSecret,
+		SSHGatewayToken: sshGatewayToken,
+		Request:         req,
+		IDEPort:         23000,
+		SupervisorPort:  22999,
+		SSHPort:         23001,
+		WorkspaceURL:    workspaceURL,
+		TraceID:         traceID,
+		Headless:        headless,
 	}, nil
 }
 
@@ -128,6 +128,8 @@ func TestCreateDefiniteWorkspacePod(t *testing.T) {
 				// tie down values that would otherwise change for each test
 				ctx.CLIAPIKey = "Ab=5=rRA*9:C'T{;RRB\u003e]vK2p6`fFfrS"
 				ctx.OwnerToken = "%7J'[Of/8NDiWE+9F,I6^Jcj_1\u0026}-F8p"
+				ctx.PortShareSecret = "Q1r-VQ3w_3TuPsd.7Q3yXi0uPb_Hj2Lh"
+				ctx.SSHGatewayToken = "e3Rz-Wn9_KxVq.8Lm2Pb0Yc_Tj4Hs7Ud"
 
 				fixture.Context = ctx
 			}
@@ -73,15 +73,18 @@ type Manager struct {
 }
 
 type startWorkspaceContext struct {
-	Request        *api.StartWorkspaceRequest `json:"request"`
-	Labels         map[string]string          `json:"labels"`
-	CLIAPIKey      string                     `json:"cliApiKey"`
-	OwnerToken     string                     `json:"ownerToken"`
-	IDEPort        int32                      `json:"idePort"`
-	SupervisorPort int32                      `json:"supervisorPort"`
-	WorkspaceURL   string                     `json:"workspaceURL"`
-	TraceID        string                     `json:"traceID"`
-	Headless       bool                       `json:"headless"`
+	Request         *api.StartWorkspaceRequest `json:"request"`
+	Labels          map[string]string          `json:"labels"`
+	CLIAPIKey       string                     `json:"cliApiKey"`
+	OwnerToken      string                     `json:"ownerToken"`
+	PortShareSecret string                     `json:"portShareSecret"`
+	SSHGatewayToken string                     `json:"sshGatewayToken"`
+	IDEPort         int32                      `json:"idePort"`
+	SupervisorPort  int32                      `json:"supervisorPort"`
+	SSHPort         int32                      `json:"sshPort"`
+	WorkspaceURL    string                     `json:"workspaceURL"`
+	TraceID         string                     `json:"traceID"`
+	Headless        bool                       `json:"headless"`
 }
 
 const (
@@ -226,6 +229,10 @@ func (m *Manager) StartWorkspace(ctx context.Context, req *api.StartWorkspaceReq
 					Name: "supervisor",
 					Port: startContext.SupervisorPort,
 				},
+				{
+					Name: "ssh",
+					Port: startContext.SSHPort,
+				},
 			},
 			Selector: startContext.Labels,
 		},
@@ -490,6 +497,7 @@ func (m *Manager) findWorkspacePod(ctx context.Context, workspaceID string) (*co
 }
 
 // getPodID computes the pod ID from a workpace ID
+//
 //nolint:unused,deadcode
 func getPodID(workspaceType, workspaceID string) string {
 	return fmt.Sprintf("%s-%s", strings.TrimSpace(strings.ToLower(workspaceType)), strings.TrimSpace(workspaceID))
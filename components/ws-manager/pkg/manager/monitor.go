@@ -32,6 +32,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/common-go/tracing"
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 	wsdaemon "github.com/gitpod-io/gitpod/ws-daemon/api"
 	"github.com/gitpod-io/gitpod/ws-manager/api"
 	"github.com/gitpod-io/gitpod/ws-manager/pkg/manager/internal/workpool"
@@ -74,6 +75,9 @@ type Monitor struct {
 	finalizerMap     map[string]context.CancelFunc
 	finalizerMapLock sync.Mutex
 
+	periodicBackupMap     map[string]*periodicBackup
+	periodicBackupMapLock sync.Mutex
+
 	act actingManager
 
 	OnError func(error)
@@ -94,6 +98,8 @@ func (m *Manager) CreateMonitor() (*Monitor, error) {
 		initializerMap: make(map[string]struct{}),
 		finalizerMap:   make(map[string]context.CancelFunc),
 
+		periodicBackupMap: make(map[string]*periodicBackup),
+
 		OnError: func(err error) {
 			log.WithError(err).Error("workspace monitor error")
 		},
@@ -409,6 +415,13 @@ func (m *Monitor) doHousekeeping(ctx context.Context) {
 	if err != nil {
 		m.OnError(err)
 	}
+
+	if m.manager.Config.PeriodicBackup.Interval > 0 {
+		err = m.backupRunningWorkspaces(ctx)
+		if err != nil {
+			m.OnError(err)
+		}
+	}
 }
 
 // writeEventTraceLog writes an event trace log if one is configured. This function is written in
@@ -943,6 +956,10 @@ func (m *Monitor) finalizeWorkspaceContent(ctx context.Context, wso *workspaceOb
 		break
 	}
 
+	if doBackup && backupError == nil {
+		m.enforceBackupRetention(ctx, wso)
+	}
+
 	disposalStatus = &workspaceDisposalStatus{
 		BackupComplete: true,
 		GitStatus:      gitStatus,
@@ -959,6 +976,143 @@ func (m *Monitor) finalizeWorkspaceContent(ctx context.Context, wso *workspaceOb
 	}
 }
 
+// periodicBackup tracks the periodic backups of a single running workspace
+type periodicBackup struct {
+	Last    time.Time
+	Running bool
+}
+
+// backupRunningWorkspaces starts a backup of every running workspace whose last backup is older than the periodic backup interval
+func (m *Monitor) backupRunningWorkspaces(ctx context.Context) (err error) {
+	span, ctx := tracing.FromContext(ctx, "backupRunningWorkspaces")
+	defer tracing.FinishSpan(span, &err)
+
+	var pods corev1.PodList
+	err = m.manager.Clientset.List(ctx, &pods, workspaceObjectListOptions(m.manager.Config.Namespace))
+	if err != nil {
+		return xerrors.Errorf("backupRunningWorkspaces: %w", err)
+	}
+
+	var (
+		interval = time.Duration(m.manager.Config.PeriodicBackup.Interval)
+		now      = time.Now()
+		running  = make(map[string]struct{}, len(pods.Items))
+	)
+	m.periodicBackupMapLock.Lock()
+	defer m.periodicBackupMapLock.Unlock()
+	for i := range pods.Items {
+		wso := &workspaceObjects{Pod: &pods.Items[i]}
+		workspaceID, ok := wso.WorkspaceID()
+		if !ok || !m.needsPeriodicBackup(wso) {
+			continue
+		}
+		running[workspaceID] = struct{}{}
+
+		bkp, exists := m.periodicBackupMap[workspaceID]
+		if !exists {
+			// We start counting once we first see the workspace running, e.g. after it started or after we restarted.
+			m.periodicBackupMap[workspaceID] = &periodicBackup{Last: now}
+			continue
+		}
+		if bkp.Running || now.Sub(bkp.Last) < interval {
+			continue
+		}
+
+		bkp.Last = now
+		bkp.Running = true
+		go func() {
+			m.backupWorkspaceContent(context.Background(), wso, workspaceID)
+
+			m.periodicBackupMapLock.Lock()
+			bkp.Running = false
+			m.periodicBackupMapLock.Unlock()
+		}()
+	}
+	for workspaceID := range m.periodicBackupMap {
+		if _, ok := running[workspaceID]; !ok {
+			delete(m.periodicBackupMap, workspaceID)
+		}
+	}
+
+	return nil
+}
+
+// needsPeriodicBackup returns true if the workspace is a running regular workspace. Workspaces using a full workspace
+// backup are excluded: each of their backups adds a layer to the workspace's manifest which nothing prunes.
+func (m *Monitor) needsPeriodicBackup(wso *workspaceObjects) bool {
+	if wso.IsWorkspaceHeadless() || !wso.WasEverReady() {
+		return false
+	}
+	if wso.Pod != nil {
+		if _, fullWorkspaceBackup := wso.Pod.Labels[fullWorkspaceBackupAnnotation]; fullWorkspaceBackup {
+			return false
+		}
+	}
+	tpe, err := wso.WorkspaceType()
+	if err != nil || tpe != api.WorkspaceType_REGULAR {
+		return false
+	}
+	status, err := m.manager.getWorkspaceStatus(*wso)
+	if err != nil {
+		log.WithError(err).WithFields(wso.GetOWI()).Warn("cannot get workspace status - not backing up")
+		return false
+	}
+	return status.Phase == api.WorkspacePhase_RUNNING
+}
+
+// backupWorkspaceContent takes a periodic backup of a running workspace and enforces the backup retention policy afterwards
+func (m *Monitor) backupWorkspaceContent(ctx context.Context, wso *workspaceObjects, workspaceID string) {
+	span, ctx := tracing.FromContext(ctx, "backupWorkspaceContent")
+	tracing.ApplyOWI(span, wso.GetOWI())
+	var err error
+	defer tracing.FinishSpan(span, &err)
+	log := log.WithFields(wso.GetOWI())
+
+	snc, err := m.manager.connectToWorkspaceDaemon(ctx, *wso)
+	if err != nil {
+		log.WithError(err).Warn("cannot connect to ws-daemon for periodic backup")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(m.manager.Config.Timeouts.ContentFinalization))
+	defer cancel()
+	_, err = snc.BackupWorkspace(ctx, &wsdaemon.BackupWorkspaceRequest{Id: workspaceID, Periodic: true})
+	if grpc_status.Code(err) == codes.FailedPrecondition {
+		// the workspace is stopping already - its final backup supersedes this one
+		span.LogKV("event", "workspace is stopping")
+		err = nil
+		return
+	}
+	if err != nil {
+		log.WithError(err).Warn("periodic backup failed")
+		return
+	}
+	log.Debug("periodic backup done")
+
+	m.enforceBackupRetention(ctx, wso)
+}
+
+// enforceBackupRetention deletes all backups from the workspace's backup history which the retention policy does not keep.
+// Failing to do so is not critical, we'll try again after the next backup.
+func (m *Monitor) enforceBackupRetention(ctx context.Context, wso *workspaceObjects) {
+	if m.manager.Content == nil || !m.manager.Content.BackupRetention.Enabled {
+		return
+	}
+
+	var (
+		owner       = wso.Pod.Labels[wsk8s.OwnerLabel]
+		workspaceID = wso.Pod.Labels[wsk8s.MetaIDLabel]
+	)
+	deleted, err := storage.EnforceBackupRetention(ctx, m.manager.Content.Storage, owner, workspaceID, m.manager.Content.BackupRetention)
+	if err != nil {
+		log.WithError(err).WithFields(wso.GetOWI()).Warn("cannot enforce backup retention")
+		return
+	}
+	if deleted > 0 {
+		log.WithFields(wso.GetOWI()).WithField("deleted", deleted).Debug("deleted expired backups")
+	}
+}
+
 // deleteDanglingServices removes services for which there is no corresponding workspace pod anymore
 func (m *Monitor) deleteDanglingServices(ctx context.Context) error {
 	var services corev1.ServiceList
@@ -15,8 +15,18 @@ import (
 	ctesting "github.com/gitpod-io/gitpod/common-go/testing"
 	"github.com/gitpod-io/gitpod/ws-manager/pkg/clock"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+func TestNeedsPeriodicBackupSkipsFullWorkspaceBackup(t *testing.T) {
+	wso := &workspaceObjects{Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Labels: map[string]string{fullWorkspaceBackupAnnotation: "true"},
+	}}}
+	if (&Monitor{}).needsPeriodicBackup(wso) {
+		t.Error("expected workspaces using a full workspace backup not to be backed up periodically")
+	}
+}
+
 func TestActOnPodEvent(t *testing.T) {
 	type actOnPodEventResult struct {
 		Actions []actRecord `json:"actions"`
@@ -257,6 +257,10 @@ func (m *Manager) getWorkspaceStatus(wso workspaceObjects) (*api.WorkspaceStatus
 	if !ok {
 		log.WithFields(wso.GetOWI()).Warn("pod has no owner token. is this a legacy pod?")
 	}
+	// workspaces started before port sharing was introduced have no port share secret, hence their ports cannot be shared
+	portShareSecret := wso.Pod.Annotations[portShareSecretAnnotation]
+	// likewise, the SSH gateway cannot log into workspaces started without an SSH gateway token
+	sshGatewayToken := wso.Pod.Annotations[sshGatewayTokenAnnotation]
 	admission := api.AdmissionLevel_ADMIT_OWNER_ONLY
 	if av, ok := api.AdmissionLevel_value[strings.ToUpper(wso.Pod.Annotations[workspaceAdmissionAnnotation])]; ok {
 		admission = api.AdmissionLevel(av)
@@ -283,8 +287,10 @@ func (m *Manager) getWorkspaceStatus(wso workspaceObjects) (*api.WorkspaceStatus
 			NodeIp:   wso.Pod.Status.HostIP,
 		},
 		Auth: &api.WorkspaceAuthentication{
-			Admission:  admission,
-			OwnerToken: ownerToken,
+			Admission:       admission,
+			OwnerToken:      ownerToken,
+			PortShareSecret: portShareSecret,
+			SshGatewayToken: sshGatewayToken,
 		},
 	}
 
@@ -57,6 +57,7 @@ type Config struct {
 	Ingress                     proxy.HostBasedIngressConfig      `json:"ingress"`
 	Proxy                       proxy.Config                      `json:"proxy"`
 	WorkspaceInfoProviderConfig proxy.WorkspaceInfoProviderConfig `json:"workspaceInfoProviderConfig"`
+	SSHGateway                  *proxy.SSHGatewayConfig           `json:"sshGateway,omitempty"`
 	PProfAddr                   string                            `json:"pprofAddr"`
 	PrometheusAddr              string                            `json:"prometheusAddr"`
 	ReadinessProbeAddr          string                            `json:"readinessProbeAddr"`
@@ -73,6 +74,14 @@ func (c *Config) Validate() error {
 	if err := c.WorkspaceInfoProviderConfig.Validate(); err != nil {
 		return err
 	}
+	if c.SSHGateway != nil {
+		if err := c.SSHGateway.Validate(); err != nil {
+			return err
+		}
+		if c.Proxy.WorkspacePodConfig.SSHPort == 0 {
+			return xerrors.Errorf("the SSH gateway requires workspacePodConfig.sshPort")
+		}
+	}
 
 	return nil
 }
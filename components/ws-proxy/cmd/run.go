@@ -5,6 +5,7 @@
 package cmd
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"os/signal"
@@ -37,8 +38,10 @@ var runCmd = &cobra.Command{
 		}
 
 		common_grpc.SetupLogging()
+		var metrics prometheus.Registerer
 		if cfg.PrometheusAddr != "" {
 			reg := prometheus.NewRegistry()
+			metrics = reg
 			reg.MustRegister(
 				collectors.NewGoCollector(),
 				collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
@@ -79,9 +82,35 @@ var runCmd = &cobra.Command{
 		}
 		log.Infof("workspace info provider started")
 
-		go proxy.NewWorkspaceProxy(cfg.Ingress, cfg.Proxy, proxy.HostBasedRouter(cfg.Ingress.Header, cfg.Proxy.GitpodInstallation.WorkspaceHostSuffix, cfg.Proxy.GitpodInstallation.WorkspaceHostSuffixRegex), workspaceInfoProvider).MustServe()
+		router := proxy.HostBasedRouter(cfg.Ingress.Header, cfg.Proxy.GitpodInstallation.WorkspaceHostSuffix, cfg.Proxy.GitpodInstallation.WorkspaceHostSuffixRegex)
+		if pathPrefix := cfg.Proxy.GitpodInstallation.WorkspacePathPrefix; pathPrefix != "" {
+			router = proxy.PathBasedRouter(cfg.Ingress.Header, cfg.Proxy.GitpodInstallation.WorkspacePathHostName, pathPrefix, cfg.Proxy.GitpodInstallation.WorkspaceHostSuffix)
+		}
+		var rateLimits proxy.RateLimitConfig
+		if cfg.Proxy.RateLimits != nil {
+			rateLimits = *cfg.Proxy.RateLimits
+		}
+		trafficLimiter, err := proxy.NewTrafficLimiter(rateLimits, workspaceInfoProvider, metrics)
+		if err != nil {
+			log.WithError(err).Fatal("cannot create traffic limiter")
+		}
+		go trafficLimiter.Run(context.Background())
+
+		workspaceProxy := proxy.NewWorkspaceProxy(cfg.Ingress, cfg.Proxy, router, workspaceInfoProvider)
+		workspaceProxy.TrafficLimiter = trafficLimiter
+		go workspaceProxy.PortInspector.Run(context.Background())
+		go workspaceProxy.MustServe()
 		log.Infof("started proxying on %s", cfg.Ingress.HttpAddress)
 
+		if cfg.SSHGateway != nil {
+			sshGateway, err := proxy.NewSSHGateway(*cfg.SSHGateway, cfg.Proxy.WorkspacePodConfig, workspaceInfoProvider)
+			if err != nil {
+				log.WithError(err).Fatal("cannot create SSH gateway")
+			}
+			go sshGateway.MustServe()
+			log.Infof("started SSH gateway on %s", cfg.SSHGateway.Address)
+		}
+
 		if cfg.ReadinessProbeAddr != "" {
 			go func() {
 				err = http.ListenAndServe(cfg.ReadinessProbeAddr, http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
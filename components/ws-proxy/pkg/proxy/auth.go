@@ -10,9 +10,11 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
+	"github.com/gitpod-io/gitpod/common-go/portshare"
 	"github.com/gitpod-io/gitpod/ws-manager/api"
 )
 
@@ -61,12 +63,7 @@ func WorkspaceAuthHandler(domain string, info WorkspaceInfoProvider) mux.Middlew
 				if err != nil {
 					log.WithField("port", port).WithError(err).Error("cannot convert port to int")
 				} else {
-					for _, p := range ws.Ports {
-						if p.Port == uint32(prt) {
-							isPublic = p.Visibility == api.PortVisibility_PORT_VISIBILITY_PUBLIC
-							break
-						}
-					}
+					isPublic = ws.isPublicPort(uint32(prt))
 				}
 
 				if isPublic {
@@ -75,6 +72,20 @@ func WorkspaceAuthHandler(domain string, info WorkspaceInfoProvider) mux.Middlew
 					return
 				}
 
+				if err == nil {
+					cn := fmt.Sprintf("%s%s_%d_share_", cookiePrefix, ws.InstanceID, prt)
+					token, shared := portShareToken(resp, req, ws, uint32(prt), cn)
+					if shared && token.Allows(uint32(prt), req.Method) {
+						// shared ports are free for everyone with the link - no owner tokens or cookies matter
+						h.ServeHTTP(resp, req)
+						return
+					}
+					if shared {
+						// the owner may still be allowed to, e.g. after opening a read-only share link themselves
+						log.WithField("port", port).WithField("method", req.Method).Debug("share token does not allow request")
+					}
+				}
+
 				// port seems to be private - subject it to the same access policy as the workspace itself
 			}
 
@@ -107,3 +118,72 @@ func WorkspaceAuthHandler(domain string, info WorkspaceInfoProvider) mux.Middlew
 		})
 	}
 }
+
+// portShareToken returns the valid share token of a port request, if any. Share tokens come with the query of
+// share links, in which case we remember them in a cookie which expires together with the token, such that the
+// requests of the page which was shared are granted access, too.
+func portShareToken(resp http.ResponseWriter, req *http.Request, ws *WorkspaceInfo, port uint32, cookieName string) (token *portshare.Token, ok bool) {
+	if ws.Auth == nil || ws.Auth.PortShareSecret == "" {
+		return nil, false
+	}
+	log := getLog(req.Context())
+
+	if value := req.URL.Query().Get(portshare.QueryParam); value != "" {
+		// the workspace port must never see the share token
+		req.URL.RawQuery = removeQueryParam(req.URL.RawQuery, portshare.QueryParam)
+
+		token, err := portshare.Verify(ws.Auth.PortShareSecret, value, time.Now())
+		if err != nil {
+			log.WithError(err).Debug("invalid share token in query")
+		} else if token.Port != port {
+			log.WithField("port", port).WithField("tokenPort", token.Port).Debug("share token was issued for another port")
+		} else {
+			http.SetCookie(resp, &http.Cookie{
+				Name:     cookieName,
+				Value:    value,
+				Path:     cookiePath(req),
+				Expires:  token.Expires,
+				Secure:   req.TLS != nil,
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+			return token, true
+		}
+	}
+
+	c, err := req.Cookie(cookieName)
+	if err != nil {
+		return nil, false
+	}
+	token, err = portshare.Verify(ws.Auth.PortShareSecret, c.Value, time.Now())
+	if err != nil {
+		log.WithError(err).Debug("invalid share token in cookie")
+		return nil, false
+	}
+	return token, true
+}
+
+// removeQueryParam removes all pairs with the given key from a raw query, leaving the rest of the query as it was
+func removeQueryParam(rawQuery, key string) string {
+	pairs := strings.Split(rawQuery, "&")
+	res := pairs[:0]
+	for _, pair := range pairs {
+		k := pair
+		if i := strings.Index(pair, "="); i >= 0 {
+			k = pair[:i]
+		}
+		if uk, err := url.QueryUnescape(k); err == nil && uk == key {
+			continue
+		}
+		res = append(res, pair)
+	}
+	return strings.Join(res, "&")
+}
+
+// cookiePath returns the path of cookies set by ws-proxy, which is the workspace's path prefix behind the PathBasedRouter
+func cookiePath(req *http.Request) string {
+	if prefix := mux.Vars(req)[workspacePathPrefixIdentifier]; prefix != "" {
+		return prefix
+	}
+	return "/"
+}
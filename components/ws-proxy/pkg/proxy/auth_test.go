@@ -10,20 +10,23 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/portshare"
 	"github.com/gitpod-io/gitpod/ws-manager/api"
 )
 
 func TestWorkspaceAuthHandler(t *testing.T) {
 	log.Log.Logger.SetLevel(logrus.PanicLevel)
 	type testResult struct {
-		HandlerCalled bool
-		StatusCode    int
+		HandlerCalled  bool
+		StatusCode     int
+		ShareCookieSet bool
 	}
 
 	const (
@@ -32,15 +35,22 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 		instanceID  = "instance-fce1-4ff6-9364-cf6dff0c4ecf"
 		ownerToken  = "owner-token"
 		testPort    = 8080
+		shareSecret = "share-secret"
 	)
 	var (
+		shareToken = func(port uint32, expires time.Duration, readOnly bool) string {
+			return portshare.Sign(shareSecret, portshare.Token{Port: port, Expires: time.Now().Add(expires), ReadOnly: readOnly})
+		}
+		shareCookieName = "_test_domain_com_ws_" + instanceID + "_" + strconv.Itoa(testPort) + "_share_"
+
 		ownerOnlyInfos = map[string]*WorkspaceInfo{
 			workspaceID: {
 				WorkspaceID: workspaceID,
 				InstanceID:  instanceID,
 				Auth: &api.WorkspaceAuthentication{
-					Admission:  api.AdmissionLevel_ADMIT_OWNER_ONLY,
-					OwnerToken: ownerToken,
+					Admission:       api.AdmissionLevel_ADMIT_OWNER_ONLY,
+					OwnerToken:      ownerToken,
+					PortShareSecret: shareSecret,
 				},
 				Ports: []PortInfo{{PortSpec: api.PortSpec{Port: testPort, Visibility: api.PortVisibility_PORT_VISIBILITY_PRIVATE}}},
 			},
@@ -68,6 +78,9 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 		Name        string
 		Infos       map[string]*WorkspaceInfo
 		OwnerCookie string
+		ShareQuery  string
+		ShareCookie string
+		Method      string
 		WorkspaceID string
 		Port        string
 		Expected    testResult
@@ -197,6 +210,87 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 				StatusCode:    http.StatusOK,
 			},
 		},
+		{
+			Name:        "private port with share link",
+			Infos:       ownerOnlyInfos,
+			WorkspaceID: workspaceID,
+			Port:        strconv.Itoa(testPort),
+			ShareQuery:  shareToken(testPort, time.Hour, false),
+			Expected: testResult{
+				HandlerCalled:  true,
+				StatusCode:     http.StatusOK,
+				ShareCookieSet: true,
+			},
+		},
+		{
+			Name:        "private port with share cookie",
+			Infos:       ownerOnlyInfos,
+			WorkspaceID: workspaceID,
+			Port:        strconv.Itoa(testPort),
+			ShareCookie: shareToken(testPort, time.Hour, false),
+			Method:      http.MethodPost,
+			Expected: testResult{
+				HandlerCalled: true,
+				StatusCode:    http.StatusOK,
+			},
+		},
+		{
+			Name:        "private port with expired share link",
+			Infos:       ownerOnlyInfos,
+			WorkspaceID: workspaceID,
+			Port:        strconv.Itoa(testPort),
+			ShareQuery:  shareToken(testPort, -time.Hour, false),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusUnauthorized,
+			},
+		},
+		{
+			Name:        "private port with share link of other port",
+			Infos:       ownerOnlyInfos,
+			WorkspaceID: workspaceID,
+			Port:        strconv.Itoa(testPort),
+			ShareQuery:  shareToken(testPort+1, time.Hour, false),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusUnauthorized,
+			},
+		},
+		{
+			Name:        "private port with read-only share link",
+			Infos:       ownerOnlyInfos,
+			WorkspaceID: workspaceID,
+			Port:        strconv.Itoa(testPort),
+			ShareCookie: shareToken(testPort, time.Hour, true),
+			Method:      http.MethodPost,
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusUnauthorized,
+			},
+		},
+		{
+			Name:        "private port with read-only share link and owner cookie",
+			Infos:       ownerOnlyInfos,
+			WorkspaceID: workspaceID,
+			Port:        strconv.Itoa(testPort),
+			ShareCookie: shareToken(testPort, time.Hour, true),
+			OwnerCookie: ownerToken,
+			Method:      http.MethodPost,
+			Expected: testResult{
+				HandlerCalled: true,
+				StatusCode:    http.StatusOK,
+			},
+		},
+		{
+			Name:        "workspace with share link",
+			Infos:       ownerOnlyInfos,
+			WorkspaceID: workspaceID,
+			ShareQuery:  shareToken(testPort, time.Hour, false),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusUnauthorized,
+			},
+		},
 		{
 			Name:        "broken port",
 			Infos:       publicPortInfos,
@@ -224,15 +318,29 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 		t.Run(test.Name, func(t *testing.T) {
 			var res testResult
 			handler := WorkspaceAuthHandler(domain, &fixedInfoProvider{Infos: test.Infos})(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+				if req.URL.Query().Get(portshare.QueryParam) != "" {
+					t.Error("share token was forwarded to the workspace")
+				}
 				res.HandlerCalled = true
 				resp.WriteHeader(http.StatusOK)
 			}))
 
+			method := test.Method
+			if method == "" {
+				method = http.MethodGet
+			}
+			target := fmt.Sprintf("http://%s/", domain)
+			if test.ShareQuery != "" {
+				target += "?" + portshare.QueryParam + "=" + test.ShareQuery
+			}
 			rr := httptest.NewRecorder()
-			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://%s/", domain), nil)
+			req := httptest.NewRequest(method, target, nil)
 			if test.OwnerCookie != "" {
 				setOwnerTokenCookie(req, instanceID, test.OwnerCookie)
 			}
+			if test.ShareCookie != "" {
+				req.AddCookie(&http.Cookie{Name: shareCookieName, Value: test.ShareCookie})
+			}
 			vars := map[string]string{
 				workspaceIDIdentifier: test.WorkspaceID,
 			}
@@ -243,6 +351,11 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 
 			handler.ServeHTTP(rr, req)
 			res.StatusCode = rr.Code
+			for _, c := range rr.Result().Cookies() {
+				if c.Name == shareCookieName {
+					res.ShareCookieSet = true
+				}
+			}
 
 			if diff := cmp.Diff(test.Expected, res); diff != "" {
 				t.Errorf("unexpected response (-want +got):\n%s", diff)
@@ -255,3 +368,24 @@ func setOwnerTokenCookie(r *http.Request, instanceID, token string) *http.Reques
 	r.AddCookie(&http.Cookie{Name: "_test_domain_com_ws_" + instanceID + "_owner_", Value: token})
 	return r
 }
+
+func TestRemoveQueryParam(t *testing.T) {
+	tests := []struct {
+		Name     string
+		RawQuery string
+		Expected string
+	}{
+		{Name: "only param", RawQuery: "gitpod_share=abc", Expected: ""},
+		{Name: "keeps order and escaping", RawQuery: "z=1&gitpod_share=abc&a=%2f+b&a=2", Expected: "z=1&a=%2f+b&a=2"},
+		{Name: "escaped key", RawQuery: "gitpod%5Fshare=abc&b", Expected: "b"},
+		{Name: "similar key", RawQuery: "gitpod_share_x=1&x=gitpod_share", Expected: "gitpod_share_x=1&x=gitpod_share"},
+		{Name: "empty", RawQuery: "", Expected: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if act := removeQueryParam(test.RawQuery, portshare.QueryParam); act != test.Expected {
+				t.Errorf("unexpected query: %q, expected %q", act, test.Expected)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ package proxy
 import (
 	"os"
 	"path/filepath"
+	"regexp"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	"golang.org/x/xerrors"
@@ -27,6 +28,7 @@ type Config struct {
 	WorkspacePodConfig *WorkspacePodConfig `json:"workspacePodConfig"`
 
 	BuiltinPages BuiltinPagesConfig `json:"builtinPages"`
+	RateLimits   *RateLimitConfig   `json:"rateLimits,omitempty"`
 }
 
 // Validate validates the configuration to catch issues during startup and not at runtime
@@ -39,6 +41,7 @@ func (c *Config) Validate() error {
 		c.BlobServer,
 		c.GitpodInstallation,
 		c.WorkspacePodConfig,
+		c.RateLimits,
 	} {
 		err := v.Validate()
 		if err != nil {
@@ -75,6 +78,8 @@ type WorkspacePodConfig struct {
 	TheiaPort           uint16 `json:"theiaPort"`
 	SupervisorPort      uint16 `json:"supervisorPort"`
 	SupervisorImage     string `json:"supervisorImage"`
+	// SSHPort is the port of supervisor's SSH server, only required by the SSH gateway
+	SSHPort uint16 `json:"sshPort"`
 }
 
 // Validate validates the configuration to catch issues during startup and not at runtime
@@ -99,6 +104,14 @@ type GitpodInstallation struct {
 	HostName                 string `json:"hostName"`
 	WorkspaceHostSuffix      string `json:"workspaceHostSuffix"`
 	WorkspaceHostSuffixRegex string `json:"workspaceHostSuffixRegex"`
+	// WorkspacePathPrefix enables path-based routing, i.e. workspaces are served below
+	// <workspacePathHostName><workspacePathPrefix>/<workspaceID>/ instead of on their own host,
+	// which requires neither wildcard DNS nor wildcard certificates. Exposed ports are still served on their own host
+	// below WorkspaceHostSuffix, so that they do not share the origin of the IDEs, and not at all if it is empty.
+	WorkspacePathPrefix string `json:"workspacePathPrefix,omitempty"`
+	// WorkspacePathHostName is the host path-based routing serves all workspaces on. All workspaces share the origin
+	// of that host, which is why it must differ from HostName.
+	WorkspacePathHostName string `json:"workspacePathHostName,omitempty"`
 }
 
 // Validate validates the configuration to catch issues during startup and not at runtime
@@ -107,13 +120,23 @@ func (c *GitpodInstallation) Validate() error {
 		return xerrors.Errorf("GitpodInstallation not configured")
 	}
 
-	return validation.ValidateStruct(c,
+	fields := []*validation.FieldRules{
 		validation.Field(&c.Scheme, validation.Required),
 		validation.Field(&c.HostName, validation.Required), // TODO IP ONLY: Check if there is any dependency. If yes, remove it.
-		validation.Field(&c.WorkspaceHostSuffix, validation.Required),
-	)
+	}
+	if c.WorkspacePathPrefix == "" {
+		fields = append(fields, validation.Field(&c.WorkspaceHostSuffix, validation.Required))
+	} else {
+		fields = append(fields,
+			validation.Field(&c.WorkspacePathPrefix, validation.Match(workspacePathPrefixRegex).Error("must start but not end with a slash")),
+			validation.Field(&c.WorkspacePathHostName, validation.Required, validation.NotIn(c.HostName).Error("must differ from hostName")),
+		)
+	}
+	return validation.ValidateStruct(c, fields...)
 }
 
+var workspacePathPrefixRegex = regexp.MustCompile(`^(/[^/]+)+$`)
+
 // BlobServerConfig configures where to serve the IDE from
 type BlobServerConfig struct {
 	Scheme string `json:"scheme"`
@@ -158,6 +181,41 @@ func (c *TransportConfig) Validate() error {
 	)
 }
 
+// RateLimitConfig configures the token buckets limiting the traffic to workspaces. Zero values disable a limit.
+type RateLimitConfig struct {
+	// Workspace limits the traffic of a workspace's IDE and private ports, which public ports do not draw from
+	Workspace RateLimit `json:"workspace"`
+	// PublicPort limits the traffic of each public port of a workspace
+	PublicPort RateLimit `json:"publicPort"`
+}
+
+// Validate validates the configuration to catch issues during startup and not at runtime
+func (c *RateLimitConfig) Validate() error {
+	if c == nil {
+		// rate limits are optional
+		return nil
+	}
+	for _, l := range []*RateLimit{&c.Workspace, &c.PublicPort} {
+		err := validation.ValidateStruct(l,
+			validation.Field(&l.RequestsPerSecond, validation.Min(0.0)),
+			validation.Field(&l.RequestBurst, validation.Min(0)),
+			validation.Field(&l.BytesPerSecond, validation.Min(0)),
+		)
+		if err != nil {
+			return xerrors.Errorf("invalid rate limit config: %w", err)
+		}
+	}
+	return nil
+}
+
+// RateLimit configures a token bucket for requests and one for bytes
+type RateLimit struct {
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	RequestBurst      int     `json:"requestBurst"`
+	// BytesPerSecond limits the bytes in both directions, allowing for bursts of one second
+	BytesPerSecond int `json:"bytesPerSecond"`
+}
+
 // BuiltinPagesConfig configures pages served directly by ws-proxy
 type BuiltinPagesConfig struct {
 	Location string `json:"location"`
@@ -174,6 +232,7 @@ func (c *BuiltinPagesConfig) Validate() error {
 			validation.Required,
 			validation.By(validateFileExists("")),
 			validation.By(validateFileExists(builtinPagePortNotFound)),
+			validation.By(validateFileExists(builtinPageWorkspaceStopped)),
 		),
 	)
 }
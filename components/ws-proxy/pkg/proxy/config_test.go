@@ -0,0 +1,52 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package proxy
+
+import (
+	"testing"
+)
+
+func TestGitpodInstallationValidate(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Config        GitpodInstallation
+		ExpectedError bool
+	}{
+		{
+			Name:   "host-based routing",
+			Config: GitpodInstallation{Scheme: "https", HostName: "gitpod.dev", WorkspaceHostSuffix: ".ws.gitpod.dev"},
+		},
+		{
+			Name:   "path-based routing",
+			Config: GitpodInstallation{Scheme: "https", HostName: "gitpod.dev", WorkspacePathPrefix: "/ws", WorkspacePathHostName: "ws.gitpod.dev"},
+		},
+		{
+			Name:          "path-based routing without host",
+			Config:        GitpodInstallation{Scheme: "https", HostName: "gitpod.dev", WorkspacePathPrefix: "/ws"},
+			ExpectedError: true,
+		},
+		{
+			Name:          "path-based routing on the dashboard host",
+			Config:        GitpodInstallation{Scheme: "https", HostName: "gitpod.dev", WorkspacePathPrefix: "/ws", WorkspacePathHostName: "gitpod.dev"},
+			ExpectedError: true,
+		},
+		{
+			Name:          "path prefix with trailing slash",
+			Config:        GitpodInstallation{Scheme: "https", HostName: "gitpod.dev", WorkspacePathPrefix: "/ws/", WorkspacePathHostName: "ws.gitpod.dev"},
+			ExpectedError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := test.Config.Validate()
+			if test.ExpectedError && err == nil {
+				t.Error("expected an error")
+			}
+			if !test.ExpectedError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
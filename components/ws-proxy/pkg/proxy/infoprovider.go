@@ -92,6 +92,17 @@ type PortInfo struct {
 	PublicPort string
 }
 
+// isPublicPort returns true if the workspace exposes the port publicly. Ports which are not exposed are private.
+func (info *WorkspaceInfo) isPublicPort(port uint32) bool {
+	// PortInfo must not be copied as it contains a protobuf message
+	for i := range info.Ports {
+		if info.Ports[i].Port == port {
+			return info.Ports[i].Visibility == wsapi.PortVisibility_PORT_VISIBILITY_PUBLIC
+		}
+	}
+	return false
+}
+
 // RemoteWorkspaceInfoProvider provides (cached) infos about running workspaces that it queries from ws-manager
 type RemoteWorkspaceInfoProvider struct {
 	Config WorkspaceInfoProviderConfig
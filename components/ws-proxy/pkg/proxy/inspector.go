@@ -0,0 +1,579 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/ws-manager/api"
+)
+
+const (
+	// inspectorPathPrefix is the path below the workspace URL which serves the port inspector API
+	inspectorPathPrefix = "/_wsproxy/v1/inspector"
+
+	// Used as key for storing the inspected port in the requests mux.Vars() map. This must not be workspacePortIdentifier,
+	// which would subject the inspector API to the access policy of the port instead of the workspace.
+	inspectedPortIdentifier = "inspectedPort"
+	exchangeIDIdentifier    = "exchangeID"
+
+	// inspectorMaxExchanges is the number of exchanges kept per port
+	inspectorMaxExchanges = 50
+	// inspectorMaxBodySize is the number of bytes of request and response bodies kept per exchange
+	inspectorMaxBodySize = 64 * 1024
+	// inspectorMaxWorkspacePorts is the number of ports of a workspace inspection can be enabled for at once
+	inspectorMaxWorkspacePorts = 8
+	// inspectorMaxWorkspaceSize is the number of bytes kept across the ports of a workspace, beyond which its oldest exchanges are forgotten
+	inspectorMaxWorkspaceSize = 2 * 1024 * 1024
+	// inspectorMaxPorts and inspectorMaxTotalSize bound the ports and bytes across all workspaces. They are a backstop only,
+	// i.e. workspaces do not reach them unless many of them use the inspector at once.
+	inspectorMaxPorts     = 128
+	inspectorMaxTotalSize = 16 * 1024 * 1024
+	// inspectorCleanupInterval is the interval in which the ports of workspaces which stopped running are forgotten
+	inspectorCleanupInterval = 1 * time.Minute
+)
+
+var (
+	errTooManyInspectedPorts = xerrors.Errorf("inspection is enabled for too many ports of the workspace")
+	errInspectorFull         = xerrors.Errorf("inspection is enabled for too many ports, try again later")
+)
+
+// PortInspector captures the HTTP requests to workspace ports the owner enabled inspection for, and keeps
+// the latest of them in memory.
+//
+// That state is local to each ws-proxy replica: inspection is enabled on, and the exchanges are kept by,
+// the replica which happened to serve the respective request. Neither survives a restart.
+type PortInspector struct {
+	InfoProvider WorkspaceInfoProvider
+
+	mu         sync.Mutex
+	ports      map[inspectedPort]*inspectedExchanges
+	workspaces map[string]*inspectedWorkspace
+	size       int
+}
+
+// inspectedWorkspace accounts for the ports of a workspace inspection is enabled for
+type inspectedWorkspace struct {
+	Ports int
+	Size  int
+}
+
+type inspectedPort struct {
+	WorkspaceID string
+	Port        string
+}
+
+type inspectedExchanges struct {
+	Exchanges []*CapturedExchange
+	NextID    uint64
+	Size      int
+}
+
+// dropOldest forgets the oldest exchange and returns its size
+func (e *inspectedExchanges) dropOldest() int {
+	size := e.Exchanges[0].size()
+	e.Exchanges[0] = nil
+	e.Exchanges = e.Exchanges[1:]
+	e.Size -= size
+	return size
+}
+
+// CapturedExchange is a request to a workspace port and its response
+type CapturedExchange struct {
+	ID       uint64            `json:"id"`
+	Time     time.Time         `json:"time"`
+	Duration string            `json:"duration"`
+	Replay   bool              `json:"replay,omitempty"`
+	Request  CapturedRequest   `json:"request"`
+	Response *CapturedResponse `json:"response,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// CapturedRequest is a captured request to a workspace port
+type CapturedRequest struct {
+	Method        string      `json:"method"`
+	Host          string      `json:"host"`
+	URI           string      `json:"uri"`
+	Header        http.Header `json:"header"`
+	Body          []byte      `json:"body,omitempty"`
+	BodyTruncated bool        `json:"bodyTruncated,omitempty"`
+}
+
+// CapturedResponse is a captured response of a workspace port
+type CapturedResponse struct {
+	Status        int         `json:"status"`
+	Header        http.Header `json:"header"`
+	Body          []byte      `json:"body,omitempty"`
+	BodyTruncated bool        `json:"bodyTruncated,omitempty"`
+}
+
+// size approximates the memory an exchange occupies
+func (e *CapturedExchange) size() int {
+	res := len(e.Request.Method) + len(e.Request.Host) + len(e.Request.URI) + headerSize(e.Request.Header) + len(e.Request.Body) + len(e.Error)
+	if e.Response != nil {
+		res += headerSize(e.Response.Header) + len(e.Response.Body)
+	}
+	return res
+}
+
+func headerSize(h http.Header) int {
+	var res int
+	for k, vs := range h {
+		for _, v := range vs {
+			res += len(k) + len(v)
+		}
+	}
+	return res
+}
+
+// NewPortInspector creates a new port inspector with inspection disabled for all ports
+func NewPortInspector(infoProvider WorkspaceInfoProvider) *PortInspector {
+	return &PortInspector{
+		InfoProvider: infoProvider,
+		ports:        make(map[inspectedPort]*inspectedExchanges),
+		workspaces:   make(map[string]*inspectedWorkspace),
+	}
+}
+
+// Enable starts capturing the requests to a workspace port. It fails if inspection is enabled for too many ports
+// of the workspace, or of all workspaces, already.
+func (p *PortInspector) Enable(workspaceID, port string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := inspectedPort{workspaceID, port}
+	if _, enabled := p.ports[key]; enabled {
+		return nil
+	}
+	ws, exists := p.workspaces[workspaceID]
+	if exists && ws.Ports >= inspectorMaxWorkspacePorts {
+		return errTooManyInspectedPorts
+	}
+	if len(p.ports) >= inspectorMaxPorts {
+		return errInspectorFull
+	}
+	if !exists {
+		ws = &inspectedWorkspace{}
+		p.workspaces[workspaceID] = ws
+	}
+	ws.Ports++
+	p.ports[key] = &inspectedExchanges{NextID: 1}
+	return nil
+}
+
+// Disable stops capturing the requests to a workspace port and forgets the captured ones
+func (p *PortInspector) Disable(workspaceID, port string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.disable(inspectedPort{workspaceID, port})
+}
+
+func (p *PortInspector) disable(key inspectedPort) {
+	e, enabled := p.ports[key]
+	if !enabled {
+		return
+	}
+	p.size -= e.Size
+	delete(p.ports, key)
+
+	ws := p.workspaces[key.WorkspaceID]
+	ws.Size -= e.Size
+	ws.Ports--
+	if ws.Ports == 0 {
+		delete(p.workspaces, key.WorkspaceID)
+	}
+}
+
+// Exchanges returns the captured exchanges of a workspace port, oldest first
+func (p *PortInspector) Exchanges(workspaceID, port string) (exchanges []*CapturedExchange, enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e, enabled := p.ports[inspectedPort{workspaceID, port}]
+	if !enabled {
+		return nil, false
+	}
+	return append([]*CapturedExchange(nil), e.Exchanges...), true
+}
+
+// Exchange returns a captured exchange of a workspace port, or nil if it is not known (anymore)
+func (p *PortInspector) Exchange(workspaceID, port string, id uint64) *CapturedExchange {
+	exchanges, _ := p.Exchanges(workspaceID, port)
+	for _, e := range exchanges {
+		if e.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+func (p *PortInspector) isEnabled(key inspectedPort) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, enabled := p.ports[key]
+	return enabled
+}
+
+func (p *PortInspector) add(key inspectedPort, exchange *CapturedExchange) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e, enabled := p.ports[key]
+	if !enabled {
+		// inspection was disabled while the request was in flight
+		return
+	}
+	exchange.ID = e.NextID
+	e.NextID++
+	e.Exchanges = append(e.Exchanges, exchange)
+	size := exchange.size()
+	e.Size += size
+	ws := p.workspaces[key.WorkspaceID]
+	ws.Size += size
+	p.size += size
+	if len(e.Exchanges) > inspectorMaxExchanges {
+		p.dropOldest(key)
+	}
+	for ws.Size > inspectorMaxWorkspaceSize {
+		p.dropOldest(p.oldest(key.WorkspaceID))
+	}
+	for p.size > inspectorMaxTotalSize {
+		p.dropOldest(p.oldest(""))
+	}
+}
+
+// dropOldest forgets the oldest exchange of a port
+func (p *PortInspector) dropOldest(key inspectedPort) {
+	size := p.ports[key].dropOldest()
+	p.workspaces[key.WorkspaceID].Size -= size
+	p.size -= size
+}
+
+// oldest returns the port of a workspace, or of all workspaces if workspaceID is empty, whose first exchange was captured first
+func (p *PortInspector) oldest(workspaceID string) inspectedPort {
+	var (
+		res    inspectedPort
+		oldest *CapturedExchange
+	)
+	for key, e := range p.ports {
+		if len(e.Exchanges) == 0 || (workspaceID != "" && key.WorkspaceID != workspaceID) {
+			continue
+		}
+		if oldest == nil || e.Exchanges[0].Time.Before(oldest.Time) {
+			res, oldest = key, e.Exchanges[0]
+		}
+	}
+	return res
+}
+
+// Run forgets the ports of workspaces which are no longer running until ctx is canceled
+func (p *PortInspector) Run(ctx context.Context) {
+	ticker := time.NewTicker(inspectorCleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.forgetStopped(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *PortInspector) forgetStopped(ctx context.Context) {
+	p.mu.Lock()
+	workspaces := make(map[string]struct{})
+	for key := range p.ports {
+		workspaces[key.WorkspaceID] = struct{}{}
+	}
+	p.mu.Unlock()
+
+	for workspaceID := range workspaces {
+		info := p.InfoProvider.WorkspaceInfo(ctx, workspaceID)
+		if info != nil && info.Phase != api.WorkspacePhase_STOPPING && info.Phase != api.WorkspacePhase_STOPPED {
+			continue
+		}
+
+		p.mu.Lock()
+		for key := range p.ports {
+			if key.WorkspaceID == workspaceID {
+				p.disable(key)
+			}
+		}
+		p.mu.Unlock()
+	}
+}
+
+// inspectingTransport captures the requests to workspace ports with inspection enabled
+type inspectingTransport struct {
+	transport http.RoundTripper
+	inspector *PortInspector
+	replay    bool
+	// onCaptured is called once the exchange is complete
+	onCaptured func(*CapturedExchange)
+}
+
+func (t *inspectingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	coords := getWorkspaceCoords(req)
+	key := inspectedPort{coords.ID, coords.Port}
+	if !t.inspector.isEnabled(key) {
+		return t.transport.RoundTrip(req)
+	}
+
+	var (
+		started  = time.Now()
+		exchange = &CapturedExchange{
+			Time:   started,
+			Replay: t.replay,
+			Request: CapturedRequest{
+				Method: req.Method,
+				Host:   req.Host,
+				URI:    req.URL.RequestURI(),
+				Header: req.Header.Clone(),
+			},
+		}
+		reqBody = &boundedBuffer{Limit: inspectorMaxBodySize}
+	)
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = &capturingReadCloser{ReadCloser: req.Body, buf: reqBody}
+	}
+	var once sync.Once
+	done := func(respBody *boundedBuffer) {
+		once.Do(func() {
+			exchange.Duration = time.Since(started).String()
+			exchange.Request.Body, exchange.Request.BodyTruncated = reqBody.Captured()
+			if respBody != nil {
+				exchange.Response.Body, exchange.Response.BodyTruncated = respBody.Captured()
+			}
+			t.inspector.add(key, exchange)
+			if t.onCaptured != nil {
+				t.onCaptured(exchange)
+			}
+		})
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		exchange.Error = err.Error()
+		done(nil)
+		return nil, err
+	}
+	exchange.Response = &CapturedResponse{
+		Status: resp.StatusCode,
+		Header: resp.Header.Clone(),
+	}
+	if resp.StatusCode == http.StatusSwitchingProtocols {
+		// the body of upgraded connections must stay an io.ReadWriteCloser, and is no HTTP body anyway
+		done(nil)
+		return resp, nil
+	}
+	respBody := &boundedBuffer{Limit: inspectorMaxBodySize}
+	resp.Body = &capturingReadCloser{ReadCloser: resp.Body, buf: respBody, onClose: func() { done(respBody) }}
+	return resp, nil
+}
+
+func withPortInspector(inspector *PortInspector) proxyPassOpt {
+	return func(h *proxyPassConfig) {
+		h.Transport = &inspectingTransport{transport: h.Transport, inspector: inspector}
+	}
+}
+
+// boundedBuffer keeps the first Limit bytes written to it
+type boundedBuffer struct {
+	Limit int
+
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	truncated bool
+}
+
+func (b *boundedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := len(p)
+	if rem := b.Limit - b.buf.Len(); n > rem {
+		p = p[:rem]
+		b.truncated = true
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+// Captured returns a copy of the bytes written so far and whether some were dropped
+func (b *boundedBuffer) Captured() ([]byte, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.buf.Len() == 0 {
+		return nil, b.truncated
+	}
+	return append([]byte(nil), b.buf.Bytes()...), b.truncated
+}
+
+// capturingReadCloser writes what is read to buf and calls onClose when it's closed
+type capturingReadCloser struct {
+	io.ReadCloser
+	buf     *boundedBuffer
+	onClose func()
+}
+
+func (c *capturingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	if n > 0 {
+		_, _ = c.buf.Write(p[:n])
+	}
+	return n, err
+}
+
+func (c *capturingReadCloser) Close() error {
+	err := c.ReadCloser.Close()
+	if c.onClose != nil {
+		c.onClose()
+	}
+	return err
+}
+
+// installInspectorRoutes serves the port inspector API:
+//
+//	GET    <inspectorPathPrefix>/ports/<port>                        lists the captured exchanges
+//	PUT    <inspectorPathPrefix>/ports/<port>                        enables inspection
+//	DELETE <inspectorPathPrefix>/ports/<port>                        disables inspection and forgets the captured exchanges
+//	POST   <inspectorPathPrefix>/ports/<port>/exchanges/<id>/replay  replays a captured request
+func installInspectorRoutes(r *mux.Router, config *RouteHandlerConfig) {
+	inspector := config.PortInspector
+	portCoords := func(req *http.Request) (workspaceID, port string) {
+		vars := mux.Vars(req)
+		return vars[workspaceIDIdentifier], vars[inspectedPortIdentifier]
+	}
+	writeJSON := func(resp http.ResponseWriter, v interface{}) {
+		resp.Header().Set("Content-Type", "application/json")
+		resp.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(resp).Encode(v)
+	}
+
+	// browsers send the Origin header with all requests below, hence we can reject those of other sites
+	r.Use(func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("Origin") != "" && !isSameOrigin(req) {
+				resp.WriteHeader(http.StatusForbidden)
+				return
+			}
+			h.ServeHTTP(resp, req)
+		})
+	})
+
+	portPath := "/ports/{" + inspectedPortIdentifier + ":[0-9]+}"
+	r.Path(portPath).Methods(http.MethodGet).HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		exchanges, enabled := inspector.Exchanges(portCoords(req))
+		if exchanges == nil {
+			exchanges = []*CapturedExchange{}
+		}
+		writeJSON(resp, struct {
+			Enabled   bool                `json:"enabled"`
+			Exchanges []*CapturedExchange `json:"exchanges"`
+		}{enabled, exchanges})
+	})
+	r.Path(portPath).Methods(http.MethodPut).HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		err := inspector.Enable(portCoords(req))
+		if err != nil {
+			http.Error(resp, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		resp.WriteHeader(http.StatusNoContent)
+	})
+	r.Path(portPath).Methods(http.MethodDelete).HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		inspector.Disable(portCoords(req))
+		resp.WriteHeader(http.StatusNoContent)
+	})
+	r.Path(portPath + "/exchanges/{" + exchangeIDIdentifier + ":[0-9]+}/replay").Methods(http.MethodPost).HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		workspaceID, port := portCoords(req)
+		id, _ := strconv.ParseUint(mux.Vars(req)[exchangeIDIdentifier], 10, 64)
+		captured := inspector.Exchange(workspaceID, port, id)
+		if captured == nil {
+			http.Error(resp, "exchange not found", http.StatusNotFound)
+			return
+		}
+		if captured.Request.BodyTruncated {
+			http.Error(resp, "the request body was too large to be captured completely", http.StatusUnprocessableEntity)
+			return
+		}
+
+		exchange, err := replayExchange(req, config, workspaceID, port, captured)
+		if err != nil {
+			http.Error(resp, err.Error(), http.StatusBadGateway)
+			return
+		}
+		writeJSON(resp, exchange)
+	})
+}
+
+// replayExchange sends a captured request to the workspace port again, and returns the exchange it was captured as
+func replayExchange(req *http.Request, config *RouteHandlerConfig, workspaceID, port string, captured *CapturedExchange) (*CapturedExchange, error) {
+	replayReq, err := http.NewRequestWithContext(req.Context(), captured.Request.Method, "", bytes.NewReader(captured.Request.Body))
+	if err != nil {
+		return nil, err
+	}
+	replayReq = mux.SetURLVars(replayReq, map[string]string{
+		workspaceIDIdentifier:   workspaceID,
+		workspacePortIdentifier: port,
+	})
+	target, err := workspacePodPortResolver(config.Config, replayReq)
+	if err != nil {
+		return nil, err
+	}
+	uri, err := url.Parse(captured.Request.URI)
+	if err != nil {
+		return nil, err
+	}
+	replayReq.URL = target.ResolveReference(uri)
+	replayReq.Host = captured.Request.Host
+	replayReq.Header = captured.Request.Header.Clone()
+
+	var (
+		exchange  *CapturedExchange
+		transport = &inspectingTransport{
+			transport:  &workspaceTransport{config.DefaultTransport},
+			inspector:  config.PortInspector,
+			replay:     true,
+			onCaptured: func(e *CapturedExchange) { exchange = e },
+		}
+	)
+	resp, err := transport.RoundTrip(replayReq)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if exchange == nil {
+		// inspection was disabled in the meantime
+		return nil, fmt.Errorf("inspection is not enabled for port %s", port)
+	}
+	return exchange, nil
+}
+
+// isSameOrigin returns true if the request was sent by a page of the same origin, which browsers tell with the Origin header
+func isSameOrigin(req *http.Request) bool {
+	origin, err := url.Parse(req.Header.Get("Origin"))
+	if err != nil || origin.Host == "" {
+		return false
+	}
+	return origin.Host == req.Host || origin.Host == req.Header.Get(forwardedHostnameHeader)
+}
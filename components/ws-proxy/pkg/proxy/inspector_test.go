@@ -0,0 +1,279 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/gitpod-io/gitpod/ws-manager/api"
+)
+
+func TestPortInspector(t *testing.T) {
+	port := startTestTarget(t, portServeHost, "port")
+	defer port.Close()
+	port.Target.Handler = func(w http.ResponseWriter, r *http.Request, requestCount uint8) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, "%d: %s", requestCount, body)
+	}
+
+	ingress := HostBasedIngressConfig{HttpAddress: "8080", HttpsAddress: "9090"}
+	proxy := NewWorkspaceProxy(ingress, config, HostBasedRouter(hostBasedHeader, wsHostSuffix, wsHostNameRegex), &fakeWsInfoProvider{infos: workspaces})
+	handler, err := proxy.Handler()
+	if err != nil {
+		t.Fatalf("cannot create proxy handler: %q", err)
+	}
+	serve := func(req *http.Request) *http.Response {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+	inspectorRequest := func(method, path string, mod ...requestModifier) *http.Request {
+		url := strings.TrimSuffix(workspaces[0].URL, "/") + inspectorPathPrefix + "/ports/28080" + path
+		return modifyRequest(httptest.NewRequest(method, url, nil), append([]requestModifier{addHostHeader}, mod...)...)
+	}
+	owner := addOwnerToken(workspaces[0].InstanceID, workspaces[0].Auth.OwnerToken)
+	type listing struct {
+		Enabled   bool                `json:"enabled"`
+		Exchanges []*CapturedExchange `json:"exchanges"`
+	}
+	list := func() (res listing) {
+		resp := serve(inspectorRequest("GET", "", owner))
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("cannot list exchanges: %d", resp.StatusCode)
+		}
+		err := json.NewDecoder(resp.Body).Decode(&res)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	sendToPort := func(body string) {
+		req := modifyRequest(httptest.NewRequest("POST", workspaces[0].Ports[0].Url+"webhook?event=push", strings.NewReader(body)), addHostHeader)
+		resp := serve(req)
+		_, _ = io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("unexpected port response: %d", resp.StatusCode)
+		}
+	}
+	ignoreVolatile := cmp.Options{
+		cmpopts.IgnoreFields(CapturedExchange{}, "Time", "Duration"),
+		cmpopts.IgnoreFields(CapturedRequest{}, "Header"),
+		cmpopts.IgnoreFields(CapturedResponse{}, "Header"),
+	}
+
+	// requests to ports are not captured unless the owner enables inspection
+	sendToPort("not captured")
+	if resp := serve(inspectorRequest("GET", "")); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected the inspector to require authentication, got %d", resp.StatusCode)
+	}
+	if resp := serve(inspectorRequest("PUT", "", owner, addHeader("Origin", "https://evil.com"))); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected the inspector to reject foreign origins, got %d", resp.StatusCode)
+	}
+	if diff := cmp.Diff(listing{Exchanges: []*CapturedExchange{}}, list()); diff != "" {
+		t.Errorf("unexpected exchanges before enabling (-want +got):\n%s", diff)
+	}
+
+	if resp := serve(inspectorRequest("PUT", "", owner)); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("cannot enable inspection: %d", resp.StatusCode)
+	}
+	sendToPort("hello")
+
+	captured := &CapturedExchange{
+		ID:       1,
+		Request:  CapturedRequest{Method: "POST", Host: "28080-amaranth-smelt-9ba20cc1.test-domain.com", URI: "/webhook?event=push", Body: []byte("hello")},
+		Response: &CapturedResponse{Status: http.StatusAccepted, Body: []byte("1: hello")},
+	}
+	if diff := cmp.Diff(listing{Enabled: true, Exchanges: []*CapturedExchange{captured}}, list(), ignoreVolatile); diff != "" {
+		t.Errorf("unexpected exchanges (-want +got):\n%s", diff)
+	}
+
+	resp := serve(inspectorRequest("POST", "/exchanges/1/replay", owner))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("cannot replay exchange: %d", resp.StatusCode)
+	}
+	var replayed CapturedExchange
+	err = json.NewDecoder(resp.Body).Decode(&replayed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay := &CapturedExchange{
+		ID:       2,
+		Replay:   true,
+		Request:  captured.Request,
+		Response: &CapturedResponse{Status: http.StatusAccepted, Body: []byte("2: hello")},
+	}
+	if diff := cmp.Diff(replay, &replayed, ignoreVolatile); diff != "" {
+		t.Errorf("unexpected replay (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(listing{Enabled: true, Exchanges: []*CapturedExchange{captured, replay}}, list(), ignoreVolatile); diff != "" {
+		t.Errorf("unexpected exchanges after replay (-want +got):\n%s", diff)
+	}
+	if resp := serve(inspectorRequest("POST", "/exchanges/42/replay", owner)); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected unknown exchanges not to be replayed, got %d", resp.StatusCode)
+	}
+
+	if resp := serve(inspectorRequest("DELETE", "", owner)); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("cannot disable inspection: %d", resp.StatusCode)
+	}
+	sendToPort("not captured either")
+	if diff := cmp.Diff(listing{Exchanges: []*CapturedExchange{}}, list()); diff != "" {
+		t.Errorf("unexpected exchanges after disabling (-want +got):\n%s", diff)
+	}
+}
+
+func TestPortInspectorLimits(t *testing.T) {
+	inspector := NewPortInspector(&fakeWsInfoProvider{infos: []WorkspaceInfo{
+		{WorkspaceID: "running", Phase: api.WorkspacePhase_RUNNING},
+		{WorkspaceID: "neighbour", Phase: api.WorkspacePhase_RUNNING},
+		{WorkspaceID: "stopping", Phase: api.WorkspacePhase_STOPPING},
+	}})
+	for i := 0; i < inspectorMaxWorkspacePorts; i++ {
+		err := inspector.Enable("running", fmt.Sprint(i))
+		if err != nil {
+			t.Fatalf("cannot enable port %d: %v", i, err)
+		}
+	}
+	if err := inspector.Enable("running", fmt.Sprint(inspectorMaxWorkspacePorts)); err != errTooManyInspectedPorts {
+		t.Errorf("expected enabling more than %d ports to fail, got %v", inspectorMaxWorkspacePorts, err)
+	}
+	if err := inspector.Enable("running", "0"); err != nil {
+		t.Errorf("expected enabling an enabled port to succeed, got %v", err)
+	}
+	if err := inspector.Enable("neighbour", "0"); err != nil {
+		t.Errorf("expected the ports of other workspaces to be unaffected, got %v", err)
+	}
+
+	// fill all ports with exchanges of the maximum body size, which exceeds the size of a workspace
+	body := make([]byte, inspectorMaxBodySize)
+	start := time.Now()
+	inspector.add(inspectedPort{"neighbour", "0"}, &CapturedExchange{Time: start.Add(-time.Second), Request: CapturedRequest{Body: body}})
+	for i := 0; i < inspectorMaxExchanges; i++ {
+		for port := 0; port < inspectorMaxWorkspacePorts; port++ {
+			inspector.add(inspectedPort{"running", fmt.Sprint(port)}, &CapturedExchange{
+				Time:    start.Add(time.Duration(i*inspectorMaxWorkspacePorts+port) * time.Millisecond),
+				Request: CapturedRequest{Body: body},
+			})
+		}
+	}
+	if size := inspector.workspaces["running"].Size; size > inspectorMaxWorkspaceSize {
+		t.Errorf("inspector keeps %d bytes of the workspace, more than %d", size, inspectorMaxWorkspaceSize)
+	}
+	first, _ := inspector.Exchanges("running", "0")
+	last, _ := inspector.Exchanges("running", fmt.Sprint(inspectorMaxWorkspacePorts-1))
+	if len(first) == 0 || len(first) != len(last) || first[len(first)-1].ID != inspectorMaxExchanges {
+		t.Errorf("expected the oldest exchanges of all ports to be forgotten, got %d and %d exchanges", len(first), len(last))
+	}
+	if neighbour, _ := inspector.Exchanges("neighbour", "0"); len(neighbour) != 1 {
+		t.Errorf("expected the exchanges of other workspaces to be kept, got %d exchanges", len(neighbour))
+	}
+
+	inspector.Disable("running", "0")
+	inspector.Disable("running", "1")
+	for _, key := range []inspectedPort{{"stopping", "0"}, {"stopped", "0"}} {
+		err := inspector.Enable(key.WorkspaceID, key.Port)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inspector.add(key, &CapturedExchange{Time: time.Now(), Request: CapturedRequest{Body: body}})
+	}
+	inspector.forgetStopped(context.Background())
+	for _, key := range []inspectedPort{{"stopping", "0"}, {"stopped", "0"}} {
+		if inspector.isEnabled(key) {
+			t.Errorf("expected %s to be forgotten", key.WorkspaceID)
+		}
+		if _, exists := inspector.workspaces[key.WorkspaceID]; exists {
+			t.Errorf("expected the accounting of %s to be forgotten", key.WorkspaceID)
+		}
+	}
+	if !inspector.isEnabled(inspectedPort{"running", "2"}) {
+		t.Error("expected the running workspace to be kept")
+	}
+	assertInspectorAccounting(t, inspector)
+}
+
+func TestPortInspectorBackstop(t *testing.T) {
+	inspector := NewPortInspector(&fakeWsInfoProvider{})
+	var workspaces []string
+	for i := 0; i < inspectorMaxPorts/inspectorMaxWorkspacePorts; i++ {
+		workspaceID := fmt.Sprintf("workspace-%d", i)
+		workspaces = append(workspaces, workspaceID)
+		for port := 0; port < inspectorMaxWorkspacePorts; port++ {
+			err := inspector.Enable(workspaceID, fmt.Sprint(port))
+			if err != nil {
+				t.Fatalf("cannot enable port %d of %s: %v", port, workspaceID, err)
+			}
+		}
+	}
+	if err := inspector.Enable("another-workspace", "0"); err != errInspectorFull {
+		t.Errorf("expected enabling more than %d ports in total to fail, got %v", inspectorMaxPorts, err)
+	}
+
+	body := make([]byte, inspectorMaxBodySize)
+	start := time.Now()
+	for i := 0; i < inspectorMaxWorkspaceSize/inspectorMaxBodySize; i++ {
+		for _, workspaceID := range workspaces {
+			inspector.add(inspectedPort{workspaceID, fmt.Sprint(i % inspectorMaxWorkspacePorts)}, &CapturedExchange{
+				Time:    start.Add(time.Duration(i) * time.Millisecond),
+				Request: CapturedRequest{Body: body},
+			})
+		}
+	}
+	if inspector.size > inspectorMaxTotalSize {
+		t.Errorf("inspector keeps %d bytes, more than %d", inspector.size, inspectorMaxTotalSize)
+	}
+	assertInspectorAccounting(t, inspector)
+}
+
+func assertInspectorAccounting(t *testing.T, inspector *PortInspector) {
+	var (
+		size       int
+		workspaces = make(map[string]inspectedWorkspace)
+	)
+	for key, e := range inspector.ports {
+		size += e.Size
+		ws := workspaces[key.WorkspaceID]
+		ws.Ports++
+		ws.Size += e.Size
+		workspaces[key.WorkspaceID] = ws
+	}
+	if size != inspector.size {
+		t.Errorf("inspector accounts for %d bytes, but keeps %d", inspector.size, size)
+	}
+	for workspaceID, ws := range inspector.workspaces {
+		if *ws != workspaces[workspaceID] {
+			t.Errorf("inspector accounts for %v of %s, but keeps %v", *ws, workspaceID, workspaces[workspaceID])
+		}
+	}
+	if len(workspaces) != len(inspector.workspaces) {
+		t.Errorf("inspector accounts for %d workspaces, but keeps %d", len(inspector.workspaces), len(workspaces))
+	}
+}
+
+func TestBoundedBuffer(t *testing.T) {
+	buf := &boundedBuffer{Limit: 4}
+	for _, p := range []string{"ab", "cd", "ef"} {
+		n, err := buf.Write([]byte(p))
+		if err != nil || n != len(p) {
+			t.Fatalf("unexpected write result: %d, %v", n, err)
+		}
+	}
+	body, truncated := buf.Captured()
+	if string(body) != "abcd" || !truncated {
+		t.Errorf("unexpected capture: %q, truncated: %v", body, truncated)
+	}
+}
@@ -64,6 +64,14 @@ func proxyPass(config *RouteHandlerConfig, resolver targetResolver, opts ...prox
 	}
 
 	return func(w http.ResponseWriter, req *http.Request) {
+		if config.TrafficLimiter != nil {
+			var allowed bool
+			w, req, allowed = config.TrafficLimiter.Limit(w, req)
+			if !allowed {
+				return
+			}
+		}
+
 		targetURL, err := h.TargetResolver(config.Config, req)
 		if err != nil {
 			if h.ErrorHandler != nil {
@@ -98,6 +106,10 @@ func proxyPass(config *RouteHandlerConfig, resolver targetResolver, opts ...prox
 				}
 			}
 
+			if vars := mux.Vars(req); vars[workspacePathPrefixIdentifier] != "" {
+				rewriteResponseForPathPrefix(resp, req, vars[pathPrefixIdentifier], vars[workspacePathPrefixIdentifier])
+			}
+
 			return nil
 		}
 
@@ -214,3 +226,58 @@ func withWorkspaceTransport() proxyPassOpt {
 		h.Transport = &workspaceTransport{h.Transport}
 	}
 }
+
+// rewriteResponseForPathPrefix restores the prefix stripped by the PathBasedRouter in the redirects and cookies of a response,
+// i.e. a redirect to /login by the workspace becomes a redirect to <workspacePathPrefix>/login.
+func rewriteResponseForPathPrefix(resp *http.Response, req *http.Request, pathPrefix, workspacePathPrefix string) {
+	for _, name := range []string{"Location", "Content-Location"} {
+		if location := resp.Header.Get(name); location != "" {
+			resp.Header.Set(name, addPathPrefix(location, req.Host, pathPrefix, workspacePathPrefix))
+		}
+	}
+	if refresh := resp.Header.Get("Refresh"); refresh != "" {
+		// e.g. Refresh: 5; url=/login
+		if idx := strings.Index(strings.ToLower(refresh), "url="); idx >= 0 {
+			idx += len("url=")
+			resp.Header.Set("Refresh", refresh[:idx]+addPathPrefix(refresh[idx:], req.Host, pathPrefix, workspacePathPrefix))
+		}
+	}
+
+	cookies := resp.Cookies()
+	if len(cookies) == 0 {
+		return
+	}
+	resp.Header.Del("Set-Cookie")
+	for _, c := range cookies {
+		// workspaces share the host, hence their cookies must be scoped by path
+		c.Domain = ""
+		c.Path = strings.TrimSuffix(workspacePathPrefix+c.Path, "/")
+		if v := c.String(); v != "" {
+			resp.Header.Add("Set-Cookie", v)
+		}
+	}
+}
+
+// addPathPrefix prefixes absolute paths, and URLs on host, with workspacePathPrefix. URLs which already point below
+// pathPrefix are left alone, e.g. those produced by apps configured with their public base path.
+func addPathPrefix(location, host, pathPrefix, workspacePathPrefix string) string {
+	u, err := url.Parse(location)
+	if err != nil {
+		return location
+	}
+	if u.Host != "" && u.Host != host {
+		return location
+	}
+	if u.Host == "" && !strings.HasPrefix(u.Path, "/") {
+		// relative to the current path
+		return location
+	}
+	if u.Path == pathPrefix || strings.HasPrefix(u.Path, pathPrefix+"/") {
+		return location
+	}
+	u.Path = workspacePathPrefix + u.Path
+	if u.RawPath != "" {
+		u.RawPath = workspacePathPrefix + u.RawPath
+	}
+	return u.String()
+}
@@ -20,6 +20,10 @@ type WorkspaceProxy struct {
 	Config                Config
 	WorkspaceRouter       WorkspaceRouter
 	WorkspaceInfoProvider WorkspaceInfoProvider
+	// PortInspector captures the requests to workspace ports - its Run method must be started to forget stopped workspaces
+	PortInspector *PortInspector
+	// TrafficLimiter limits and accounts for the traffic to workspaces if set
+	TrafficLimiter *TrafficLimiter
 }
 
 // NewWorkspaceProxy creates a new workspace proxy
@@ -29,6 +33,7 @@ func NewWorkspaceProxy(ingress HostBasedIngressConfig, config Config, workspaceR
 		Config:                config,
 		WorkspaceRouter:       workspaceRouter,
 		WorkspaceInfoProvider: workspaceInfoProvider,
+		PortInspector:         NewPortInspector(workspaceInfoProvider),
 	}
 }
 
@@ -77,13 +82,20 @@ func (p *WorkspaceProxy) Handler() (http.Handler, error) {
 	r := mux.NewRouter()
 
 	// install routes
-	handlerConfig, err := NewRouteHandlerConfig(&p.Config, WithDefaultAuth(p.WorkspaceInfoProvider))
+	opts := []RouteHandlerConfigOpt{WithDefaultAuth(p.WorkspaceInfoProvider), WithPortInspector(p.PortInspector)}
+	if p.TrafficLimiter != nil {
+		opts = append(opts, WithTrafficLimiter(p.TrafficLimiter))
+	}
+	handlerConfig, err := NewRouteHandlerConfig(&p.Config, opts...)
 	if err != nil {
 		return nil, err
 	}
 	ideRouter, portRouter, blobserveRouter := p.WorkspaceRouter(r, p.WorkspaceInfoProvider)
-	installWorkspaceRoutes(ideRouter, handlerConfig, p.WorkspaceInfoProvider)
-	err = installWorkspacePortRoutes(portRouter, handlerConfig)
+	err = installWorkspaceRoutes(ideRouter, handlerConfig, p.WorkspaceInfoProvider)
+	if err != nil {
+		return nil, err
+	}
+	err = installWorkspacePortRoutes(portRouter, handlerConfig, p.WorkspaceInfoProvider)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,292 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// trafficIdleTimeout is the time after which the buckets and metrics of a workspace (port) without traffic are forgotten
+	trafficIdleTimeout = 1 * time.Hour
+)
+
+// TrafficLimiter applies the rate limits to the traffic of workspaces and their public ports, and accounts for the bytes transferred.
+// Each public port has buckets of its own, such that its visitors cannot exhaust those of the owner's IDE and private ports.
+type TrafficLimiter struct {
+	Limits       RateLimitConfig
+	InfoProvider WorkspaceInfoProvider
+
+	bytes   *prometheus.CounterVec
+	limited *prometheus.CounterVec
+
+	mu      sync.Mutex
+	buckets map[trafficKey]*trafficBuckets
+	series  map[trafficKey]time.Time
+}
+
+// trafficKey identifies the traffic of a workspace port, or of the IDE if Port is empty
+type trafficKey struct {
+	WorkspaceID string
+	Port        string
+}
+
+type trafficBuckets struct {
+	Requests *rate.Limiter
+	Bytes    *rate.Limiter
+	LastUsed time.Time
+}
+
+// NewTrafficLimiter creates a new traffic limiter. reg can be nil
+func NewTrafficLimiter(limits RateLimitConfig, infoProvider WorkspaceInfoProvider, reg prometheus.Registerer) (*TrafficLimiter, error) {
+	bytes := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitpod",
+		Subsystem: "ws_proxy",
+		Name:      "workspace_bytes_total",
+		Help:      "bytes proxied to (in) and from (out) workspaces - port is empty for the IDE",
+	}, []string{"workspace", "port", "direction"})
+	limited := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitpod",
+		Subsystem: "ws_proxy",
+		Name:      "workspace_requests_limited_total",
+		Help:      "requests to workspaces rejected because they exceeded the request rate limit",
+	}, []string{"workspace", "port"})
+	if reg != nil {
+		for _, c := range []prometheus.Collector{bytes, limited} {
+			err := reg.Register(c)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &TrafficLimiter{
+		Limits:       limits,
+		InfoProvider: infoProvider,
+		bytes:        bytes,
+		limited:      limited,
+		buckets:      make(map[trafficKey]*trafficBuckets),
+		series:       make(map[trafficKey]time.Time),
+	}, nil
+}
+
+// Limit rejects the request if it exceeds the request rate of its public port, or of its workspace's IDE and private ports.
+// Otherwise it returns the response writer and request to proxy, which account for and limit the bytes transferred.
+func (l *TrafficLimiter) Limit(resp http.ResponseWriter, req *http.Request) (http.ResponseWriter, *http.Request, bool) {
+	coords := getWorkspaceCoords(req)
+	if coords.ID == "" {
+		// blobserve requests do not belong to a workspace
+		return resp, req, true
+	}
+	key := trafficKey{coords.ID, coords.Port}
+
+	now := time.Now()
+	var buckets *trafficBuckets
+	if coords.Port != "" && l.isPublicPort(req.Context(), coords) {
+		buckets = l.getBuckets(key, l.Limits.PublicPort, now)
+	} else {
+		buckets = l.getBuckets(trafficKey{WorkspaceID: coords.ID}, l.Limits.Workspace, now)
+	}
+	if buckets.Requests != nil && !buckets.Requests.AllowN(now, 1) {
+		l.limited.WithLabelValues(key.WorkspaceID, key.Port).Inc()
+		resp.Header().Set("Retry-After", "1")
+		http.Error(resp, "too many requests", http.StatusTooManyRequests)
+		return resp, req, false
+	}
+
+	meter := &trafficMeter{
+		ctx:     req.Context(),
+		limiter: buckets.Bytes,
+		in:      l.bytes.WithLabelValues(key.WorkspaceID, key.Port, "in"),
+		out:     l.bytes.WithLabelValues(key.WorkspaceID, key.Port, "out"),
+	}
+	l.mu.Lock()
+	l.series[key] = now
+	l.mu.Unlock()
+
+	if req.Body != nil && req.Body != http.NoBody {
+		req = req.Clone(req.Context())
+		req.Body = &meteredReadCloser{ReadCloser: req.Body, meter: meter}
+	}
+	return &meteredResponseWriter{ResponseWriter: resp, meter: meter}, req, true
+}
+
+func (l *TrafficLimiter) getBuckets(key trafficKey, limit RateLimit, now time.Time) *trafficBuckets {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &trafficBuckets{}
+		if limit.RequestsPerSecond > 0 {
+			burst := limit.RequestBurst
+			if burst < 1 {
+				burst = 1
+			}
+			b.Requests = rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), burst)
+		}
+		if limit.BytesPerSecond > 0 {
+			b.Bytes = rate.NewLimiter(rate.Limit(limit.BytesPerSecond), limit.BytesPerSecond)
+		}
+		l.buckets[key] = b
+	}
+	b.LastUsed = now
+	return b
+}
+
+func (l *TrafficLimiter) isPublicPort(ctx context.Context, coords WorkspaceCoords) bool {
+	prt, err := strconv.ParseUint(coords.Port, 10, 16)
+	if err != nil {
+		return false
+	}
+	ws := l.InfoProvider.WorkspaceInfo(ctx, coords.ID)
+	if ws == nil {
+		return false
+	}
+	return ws.isPublicPort(uint32(prt))
+}
+
+// Run forgets the buckets and metrics of workspaces (ports) without traffic until ctx is canceled
+func (l *TrafficLimiter) Run(ctx context.Context) {
+	ticker := time.NewTicker(trafficIdleTimeout / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			l.forgetIdle(now.Add(-trafficIdleTimeout))
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (l *TrafficLimiter) forgetIdle(before time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, b := range l.buckets {
+		if b.LastUsed.Before(before) {
+			delete(l.buckets, key)
+		}
+	}
+	for key, lastUsed := range l.series {
+		if !lastUsed.Before(before) {
+			continue
+		}
+		l.bytes.DeleteLabelValues(key.WorkspaceID, key.Port, "in")
+		l.bytes.DeleteLabelValues(key.WorkspaceID, key.Port, "out")
+		l.limited.DeleteLabelValues(key.WorkspaceID, key.Port)
+		delete(l.series, key)
+	}
+}
+
+// trafficMeter counts the bytes of a request and waits for the byte rate limit, if any, to allow them
+type trafficMeter struct {
+	ctx     context.Context
+	limiter *rate.Limiter
+	in, out prometheus.Counter
+}
+
+func (m *trafficMeter) transfer(n int, count prometheus.Counter) error {
+	if n <= 0 {
+		return nil
+	}
+	count.Add(float64(n))
+	if m.limiter == nil {
+		return nil
+	}
+	// WaitN must not be asked for more than the burst at once
+	for rem := n; rem > 0; rem -= m.limiter.Burst() {
+		chunk := rem
+		if chunk > m.limiter.Burst() {
+			chunk = m.limiter.Burst()
+		}
+		err := m.limiter.WaitN(m.ctx, chunk)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type meteredReadCloser struct {
+	io.ReadCloser
+	meter *trafficMeter
+}
+
+func (r *meteredReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if werr := r.meter.transfer(n, r.meter.in); werr != nil && err == nil {
+		err = werr
+	}
+	return n, err
+}
+
+// meteredResponseWriter meters the response, and the traffic of upgraded connections in both directions
+type meteredResponseWriter struct {
+	http.ResponseWriter
+	meter *trafficMeter
+}
+
+func (w *meteredResponseWriter) Write(p []byte) (int, error) {
+	err := w.meter.transfer(len(p), w.meter.out)
+	if err != nil {
+		return 0, err
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *meteredResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *meteredResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, brw, err := hj.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	// the upgraded connection outlives the request context
+	meter := *w.meter
+	meter.ctx = context.Background()
+	mc := &meteredConn{Conn: conn, meter: &meter}
+	return mc, bufio.NewReadWriter(brw.Reader, bufio.NewWriter(mc)), nil
+}
+
+type meteredConn struct {
+	net.Conn
+	meter *trafficMeter
+}
+
+func (c *meteredConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if werr := c.meter.transfer(n, c.meter.in); werr != nil && err == nil {
+		err = werr
+	}
+	return n, err
+}
+
+func (c *meteredConn) Write(p []byte) (int, error) {
+	err := c.meter.transfer(len(p), c.meter.out)
+	if err != nil {
+		return 0, err
+	}
+	return c.Conn.Write(p)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestTrafficLimiter(t *testing.T) {
+	var (
+		workspaceID = workspaces[0].WorkspaceID
+		publicPort  = "28080"
+		privatePort = "3000"
+	)
+	type Expectation struct {
+		Status   []int
+		BytesIn  float64
+		BytesOut float64
+		Limited  float64
+	}
+	tests := []struct {
+		Desc        string
+		Limits      RateLimitConfig
+		Port        string
+		Requests    int
+		Expectation Expectation
+	}{
+		{
+			Desc:        "unlimited",
+			Port:        publicPort,
+			Requests:    3,
+			Expectation: Expectation{Status: []int{200, 200, 200}, BytesIn: 15, BytesOut: 30},
+		},
+		{
+			Desc:        "public port",
+			Limits:      RateLimitConfig{PublicPort: RateLimit{RequestsPerSecond: 0.001, RequestBurst: 2}},
+			Port:        publicPort,
+			Requests:    3,
+			Expectation: Expectation{Status: []int{200, 200, 429}, BytesIn: 10, BytesOut: 20, Limited: 1},
+		},
+		{
+			Desc:        "private port",
+			Limits:      RateLimitConfig{PublicPort: RateLimit{RequestsPerSecond: 0.001, RequestBurst: 2}},
+			Port:        privatePort,
+			Requests:    3,
+			Expectation: Expectation{Status: []int{200, 200, 200}, BytesIn: 15, BytesOut: 30},
+		},
+		{
+			Desc:        "workspace",
+			Limits:      RateLimitConfig{Workspace: RateLimit{RequestsPerSecond: 0.001}},
+			Requests:    2,
+			Expectation: Expectation{Status: []int{200, 429}, BytesIn: 5, BytesOut: 10, Limited: 1},
+		},
+		{
+			Desc:        "workspace private port",
+			Limits:      RateLimitConfig{Workspace: RateLimit{RequestsPerSecond: 0.001}},
+			Port:        privatePort,
+			Requests:    2,
+			Expectation: Expectation{Status: []int{200, 429}, BytesIn: 5, BytesOut: 10, Limited: 1},
+		},
+		{
+			Desc:        "workspace public port",
+			Limits:      RateLimitConfig{Workspace: RateLimit{RequestsPerSecond: 0.001}},
+			Port:        publicPort,
+			Requests:    2,
+			Expectation: Expectation{Status: []int{200, 200}, BytesIn: 10, BytesOut: 20},
+		},
+		{
+			Desc:        "bytes",
+			Limits:      RateLimitConfig{PublicPort: RateLimit{BytesPerSecond: 1 << 20}},
+			Port:        publicPort,
+			Requests:    2,
+			Expectation: Expectation{Status: []int{200, 200}, BytesIn: 10, BytesOut: 20},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			limiter, err := NewTrafficLimiter(test.Limits, &fakeWsInfoProvider{infos: workspaces}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			echo := func(resp http.ResponseWriter, req *http.Request) {
+				body, _ := io.ReadAll(req.Body)
+				_, _ = resp.Write(append(body, body...))
+			}
+
+			var act Expectation
+			for i := 0; i < test.Requests; i++ {
+				req := httptest.NewRequest("POST", "https://example.com/", strings.NewReader("hello"))
+				req = mux.SetURLVars(req, map[string]string{workspaceIDIdentifier: workspaceID, workspacePortIdentifier: test.Port})
+				rec := httptest.NewRecorder()
+				if w, req, allowed := limiter.Limit(rec, req); allowed {
+					echo(w, req)
+				}
+				act.Status = append(act.Status, rec.Code)
+			}
+			act.BytesIn = testutil.ToFloat64(limiter.bytes.WithLabelValues(workspaceID, test.Port, "in"))
+			act.BytesOut = testutil.ToFloat64(limiter.bytes.WithLabelValues(workspaceID, test.Port, "out"))
+			act.Limited = testutil.ToFloat64(limiter.limited.WithLabelValues(workspaceID, test.Port))
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+
+			if test.Port == publicPort {
+				// the visitors of public ports must not exhaust the buckets of the owner's IDE
+				req := httptest.NewRequest("GET", "https://example.com/", nil)
+				req = mux.SetURLVars(req, map[string]string{workspaceIDIdentifier: workspaceID})
+				if _, _, allowed := limiter.Limit(httptest.NewRecorder(), req); !allowed {
+					t.Errorf("public port traffic exhausted the IDE's buckets")
+				}
+			}
+
+			limiter.forgetIdle(time.Now().Add(time.Minute))
+			if len(limiter.buckets) != 0 || len(limiter.series) != 0 {
+				t.Errorf("idle traffic was not forgotten")
+			}
+		})
+	}
+}
@@ -34,6 +34,8 @@ type RouteHandlerConfig struct {
 	DefaultTransport     http.RoundTripper
 	CorsHandler          mux.MiddlewareFunc
 	WorkspaceAuthHandler mux.MiddlewareFunc
+	PortInspector        *PortInspector
+	TrafficLimiter       *TrafficLimiter
 }
 
 // RouteHandlerConfigOpt modifies the router handler config
@@ -46,6 +48,20 @@ func WithDefaultAuth(infoprov WorkspaceInfoProvider) RouteHandlerConfigOpt {
 	}
 }
 
+// WithPortInspector captures the requests to the ports the owner enabled inspection for
+func WithPortInspector(inspector *PortInspector) RouteHandlerConfigOpt {
+	return func(config *Config, c *RouteHandlerConfig) {
+		c.PortInspector = inspector
+	}
+}
+
+// WithTrafficLimiter enables rate limiting and accounting of the traffic to workspaces
+func WithTrafficLimiter(limiter *TrafficLimiter) RouteHandlerConfigOpt {
+	return func(config *Config, c *RouteHandlerConfig) {
+		c.TrafficLimiter = limiter
+	}
+}
+
 // NewRouteHandlerConfig creates a new instance
 func NewRouteHandlerConfig(config *Config, opts ...RouteHandlerConfigOpt) (*RouteHandlerConfig, error) {
 	corsHandler, err := corsHandler(config.GitpodInstallation.Scheme, config.GitpodInstallation.HostName)
@@ -69,12 +85,15 @@ func NewRouteHandlerConfig(config *Config, opts ...RouteHandlerConfigOpt) (*Rout
 type RouteHandler = func(r *mux.Router, config *RouteHandlerConfig)
 
 // installWorkspaceRoutes configures routing of workspace and IDE requests
-func installWorkspaceRoutes(r *mux.Router, config *RouteHandlerConfig, ip WorkspaceInfoProvider) {
+func installWorkspaceRoutes(r *mux.Router, config *RouteHandlerConfig, ip WorkspaceInfoProvider) error {
 	r.Use(logHandler)
 
 	// Note: the order of routes defines their priority.
 	//       Routes registered first have priority over those that come afterwards.
-	routes := newIDERoutes(config, ip)
+	routes, err := newIDERoutes(config, ip)
+	if err != nil {
+		return err
+	}
 
 	// The favicon warants special handling, because we pull that from the supervisor frontend
 	// rather than the IDE.
@@ -104,12 +123,15 @@ func installWorkspaceRoutes(r *mux.Router, config *RouteHandlerConfig, ip Worksp
 	routes.HandleDirectSupervisorRoute(r.PathPrefix("/_supervisor/v1"), true)
 	routes.HandleDirectSupervisorRoute(r.PathPrefix("/_supervisor"), true)
 
+	routes.HandleInspectorRoute(r.PathPrefix(inspectorPathPrefix))
+
 	routes.HandleDirectIDERoute(enableCompression(r).MatcherFunc(func(req *http.Request, m *mux.RouteMatch) bool {
 		// this handles all foreign (none-IDE) content
 		return m.Vars != nil && m.Vars[foreignOriginIdentifier] != ""
 	}))
 
 	routes.HandleRoot(enableCompression(r).NewRoute())
+	return nil
 }
 
 func enableCompression(r *mux.Router) *mux.Router {
@@ -118,12 +140,16 @@ func enableCompression(r *mux.Router) *mux.Router {
 	return res
 }
 
-func newIDERoutes(config *RouteHandlerConfig, ip WorkspaceInfoProvider) *ideRoutes {
+func newIDERoutes(config *RouteHandlerConfig, ip WorkspaceInfoProvider) (*ideRoutes, error) {
+	mustExist, err := workspaceMustExistHandler(config.Config, ip)
+	if err != nil {
+		return nil, err
+	}
 	return &ideRoutes{
 		Config:                    config,
 		InfoProvider:              ip,
-		workspaceMustExistHandler: workspaceMustExistHandler(config.Config, ip),
-	}
+		workspaceMustExistHandler: mustExist,
+	}, nil
 }
 
 type ideRoutes struct {
@@ -155,6 +181,16 @@ func (ir *ideRoutes) HandleDirectSupervisorRoute(route *mux.Route, authenticated
 	r.NewRoute().HandlerFunc(proxyPass(ir.Config, workspacePodSupervisorResolver))
 }
 
+// HandleInspectorRoute serves the API of the port inspector to the workspace owner
+func (ir *ideRoutes) HandleInspectorRoute(route *mux.Route) {
+	r := route.Subrouter()
+	r.Use(logRouteHandlerHandler("HandleInspectorRoute"))
+	r.Use(ir.workspaceMustExistHandler)
+	r.Use(ir.Config.WorkspaceAuthHandler)
+
+	installInspectorRoutes(r, ir.Config)
+}
+
 func (ir *ideRoutes) HandleSupervisorFrontendRoute(route *mux.Route) {
 	if ir.Config.Config.BlobServer == nil {
 		// if we don't have blobserve, we serve the supervisor frontend from supervisor directly
@@ -253,8 +289,8 @@ func (ir *ideRoutes) HandleRoot(route *mux.Route) {
 				}
 
 				inlineVars := &BlobserveInlineVars{
-					IDE:             t.asBlobserveURL(image, ""),
-					SupervisorImage: t.asBlobserveURL(t.Config.WorkspacePodConfig.SupervisorImage, ""),
+					IDE:             t.asBlobserveURL(req, image, ""),
+					SupervisorImage: t.asBlobserveURL(req, t.Config.WorkspacePodConfig.SupervisorImage, ""),
 				}
 				inlinveVarsValue, err := json.Marshal(inlineVars)
 				if err != nil {
@@ -302,13 +338,18 @@ func installBlobserveRoutes(r *mux.Router, config *RouteHandlerConfig) {
 }
 
 // installWorkspacePortRoutes configures routing for exposed ports
-func installWorkspacePortRoutes(r *mux.Router, config *RouteHandlerConfig) error {
+func installWorkspacePortRoutes(r *mux.Router, config *RouteHandlerConfig, ip WorkspaceInfoProvider) error {
 	showPortNotFoundPage, err := servePortNotFoundPage(config.Config)
 	if err != nil {
 		return err
 	}
+	portMustExist, err := workspacePortMustExistHandler(config.Config, ip)
+	if err != nil {
+		return err
+	}
 
 	r.Use(logHandler)
+	r.Use(portMustExist)
 	r.Use(config.WorkspaceAuthHandler)
 	// filter all session cookies
 	r.Use(sensitiveCookieHandler(config.Config.GitpodInstallation.HostName))
@@ -332,6 +373,7 @@ func installWorkspacePortRoutes(r *mux.Router, config *RouteHandlerConfig) error
 				withHTTPErrorHandler(showPortNotFoundPage),
 				withXFrameOptionsFilter(),
 				withWorkspaceTransport(),
+				withPortInspector(config.PortInspector),
 			)(rw, r)
 		},
 	)
@@ -521,12 +563,22 @@ func sensitiveCookieHandler(domain string) func(h http.Handler) http.Handler {
 	}
 }
 
-// workspaceMustExistHandler redirects if we don't know about a workspace yet.
-func workspaceMustExistHandler(config *Config, infoProvider WorkspaceInfoProvider) mux.MiddlewareFunc {
+// workspaceMustExistHandler serves browsers navigating to a workspace we don't know about yet the interstitial page which starts it,
+// and redirects all other requests to the start page of the dashboard.
+func workspaceMustExistHandler(config *Config, infoProvider WorkspaceInfoProvider) (mux.MiddlewareFunc, error) {
+	showStoppedPage, err := serveWorkspaceStoppedPage(config)
+	if err != nil {
+		return nil, err
+	}
 	return func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
 			coords := getWorkspaceCoords(req)
 			info := infoProvider.WorkspaceInfo(req.Context(), coords.ID)
+			if info == nil && isNavigation(req) {
+				log.WithFields(log.OWI("", coords.ID, "")).Info("no workspace info found - serving interstitial page")
+				showStoppedPage.ServeHTTP(resp, req)
+				return
+			}
 			if info == nil {
 				log.WithFields(log.OWI("", coords.ID, "")).Info("no workspace info found - redirecting to start")
 				redirectURL := fmt.Sprintf("%s://%s/start/#%s", config.GitpodInstallation.Scheme, config.GitpodInstallation.HostName, coords.ID)
@@ -536,7 +588,39 @@ func workspaceMustExistHandler(config *Config, infoProvider WorkspaceInfoProvide
 
 			h.ServeHTTP(resp, req.WithContext(context.WithValue(req.Context(), infoContextValueKey, info)))
 		})
+	}, nil
+}
+
+// workspacePortMustExistHandler serves browsers navigating to a port the interstitial page which starts its workspace, if we don't know
+// about the workspace yet. All other requests, e.g. webhooks, are left to the WorkspaceAuthHandler which does not find the workspace either.
+func workspacePortMustExistHandler(config *Config, infoProvider WorkspaceInfoProvider) (mux.MiddlewareFunc, error) {
+	showStoppedPage, err := serveWorkspaceStoppedPage(config)
+	if err != nil {
+		return nil, err
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			coords := getWorkspaceCoords(req)
+			if isNavigation(req) && infoProvider.WorkspaceInfo(req.Context(), coords.ID) == nil {
+				log.WithFields(log.OWI("", coords.ID, "")).Info("no workspace info found - serving interstitial page")
+				showStoppedPage.ServeHTTP(resp, req)
+				return
+			}
+
+			h.ServeHTTP(resp, req)
+		})
+	}, nil
+}
+
+// isNavigation returns true if a browser requests a document to show, as opposed to e.g. fetching data or a webhook
+func isNavigation(req *http.Request) bool {
+	if req.Method != http.MethodGet {
+		return false
 	}
+	mode := req.Header.Get("Sec-Fetch-Mode")
+	return mode == "navigate" || mode == "nested-navigate" ||
+		// fallback for user agents not supporting fetch metadata
+		(mode == "" && strings.Contains(req.Header.Get("Accept"), "text/html"))
 }
 
 // getWorkspaceInfoFromContext retrieves workspace information put there by the workspaceMustExistHandler
@@ -572,6 +656,10 @@ func removeSensitiveCookies(cookies []*http.Cookie, domain string) []*http.Cooki
 			// skip owner token
 			continue
 		}
+		if strings.HasPrefix(c.Name, hostnamePrefix) && strings.HasSuffix(c.Name, "_share_") {
+			// skip port share token
+			continue
+		}
 		log.WithField("hostnamePrefix", hostnamePrefix).WithField("name", c.Name).Debug("keeping cookie")
 		cookies[n] = c
 		n++
@@ -661,7 +749,7 @@ func (t *blobserveTransport) RoundTrip(req *http.Request) (resp *http.Response,
 
 func (t *blobserveTransport) redirect(image string, req *http.Request) (*http.Response, error) {
 	path := strings.TrimPrefix(req.URL.Path, "/"+image)
-	location := t.asBlobserveURL(image, path)
+	location := t.asBlobserveURL(req, image, path)
 
 	header := make(http.Header, 2)
 	header.Set("Location", location)
@@ -683,7 +771,11 @@ func (t *blobserveTransport) redirect(image string, req *http.Request) (*http.Re
 	}, nil
 }
 
-func (t *blobserveTransport) asBlobserveURL(image string, path string) string {
+func (t *blobserveTransport) asBlobserveURL(req *http.Request, image string, path string) string {
+	if pathPrefix := mux.Vars(req)[pathPrefixIdentifier]; pathPrefix != "" {
+		// behind the PathBasedRouter blobserve shares the origin of the workspaces
+		return fmt.Sprintf("%s/blobserve/%s%s%s", pathPrefix, image, imagePathSeparator, path)
+	}
 	return fmt.Sprintf("%s://%s%s/%s%s%s",
 		t.Config.GitpodInstallation.Scheme,
 		"blobserve",
@@ -697,7 +789,8 @@ func (t *blobserveTransport) asBlobserveURL(image string, path string) string {
 // endregion
 
 const (
-	builtinPagePortNotFound = "port-not-found.html"
+	builtinPagePortNotFound     = "port-not-found.html"
+	builtinPageWorkspaceStopped = "workspace-stopped.html"
 )
 
 func servePortNotFoundPage(config *Config) (http.Handler, error) {
@@ -716,3 +809,28 @@ func servePortNotFoundPage(config *Config) (http.Handler, error) {
 		w.Write(page)
 	}), nil
 }
+
+// serveWorkspaceStoppedPage serves the interstitial page of workspaces which are not running. The page asks the server to start
+// the workspace on behalf of the signed in user, follows its start and returns to the requested URL once it is running.
+// ws-proxy never sees the user's session: the browser talks to the server directly. Should the server refuse the page's origin,
+// as it does for the shared host of the PathBasedRouter, the page links to the start page of the dashboard instead.
+func serveWorkspaceStoppedPage(config *Config) (http.Handler, error) {
+	fn := filepath.Join(config.BuiltinPages.Location, builtinPageWorkspaceStopped)
+	if tp := os.Getenv("TELEPRESENCE_ROOT"); tp != "" {
+		fn = filepath.Join(tp, fn)
+	}
+	page, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	page = bytes.ReplaceAll(page, []byte("https://gitpod.io"), []byte(fmt.Sprintf("%s://%s", config.GitpodInstallation.Scheme, config.GitpodInstallation.HostName)))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the workspace ID was matched by workspaceIDRegex, hence needs no escaping
+		res := bytes.Replace(page, []byte(`data-workspace-id=""`), []byte(fmt.Sprintf(`data-workspace-id="%s"`, getWorkspaceCoords(r).ID)), 1)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write(res)
+	}), nil
+}
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
@@ -435,6 +436,46 @@ func TestRoutes(t *testing.T) {
 				Body: ("<a href=\"https://test-domain.com/start/#blabla-smelt-9ba20cc1\">Found</a>.\n\n"),
 			},
 		},
+		{
+			Desc: "non-existent navigation to /",
+			Request: modifyRequest(httptest.NewRequest("GET", strings.ReplaceAll(workspaces[0].URL, "amaranth", "blabla")+"?folder=%2Fworkspace", nil),
+				addHostHeader,
+				addHeader("Sec-Fetch-Mode", "navigate"),
+			),
+			Expectation: Expectation{
+				Status: http.StatusServiceUnavailable,
+				Header: http.Header{
+					"Cache-Control": {"no-store"},
+					"Content-Type":  {"text/html; charset=utf-8"},
+					"Vary":          {"Accept-Encoding"},
+				},
+			},
+			IgnoreBody: true,
+		},
+		{
+			Desc: "non-existent port navigation",
+			Request: modifyRequest(httptest.NewRequest("GET", strings.ReplaceAll(workspaces[0].Ports[0].Url, "amaranth", "blabla"), nil),
+				addHostHeader,
+				addHeader("Sec-Fetch-Mode", "navigate"),
+			),
+			Expectation: Expectation{
+				Status: http.StatusServiceUnavailable,
+				Header: http.Header{
+					"Cache-Control": {"no-store"},
+					"Content-Type":  {"text/html; charset=utf-8"},
+				},
+			},
+			IgnoreBody: true,
+		},
+		{
+			Desc: "non-existent port fetch",
+			Request: modifyRequest(httptest.NewRequest("POST", strings.ReplaceAll(workspaces[0].Ports[0].Url, "amaranth", "blabla"), nil),
+				addHostHeader,
+			),
+			Expectation: Expectation{
+				Status: http.StatusNotFound,
+			},
+		},
 		{
 			Desc:   "blobserve supervisor frontend /worker-proxy.js",
 			Config: &config,
@@ -735,6 +776,7 @@ func TestRemoveSensitiveCookies(t *testing.T) {
 		sessionCookie     = &http.Cookie{Domain: domain, Name: "_test_domain_com_", Value: "fobar"}
 		portAuthCookie    = &http.Cookie{Domain: domain, Name: "_test_domain_com_ws_77f6b236_3456_4b88_8284_81ca543a9d65_port_auth_", Value: "some-token"}
 		ownerCookie       = &http.Cookie{Domain: domain, Name: "_test_domain_com_ws_77f6b236_3456_4b88_8284_81ca543a9d65_owner_", Value: "some-other-token"}
+		shareCookie       = &http.Cookie{Domain: domain, Name: "_test_domain_com_ws_77f6b236_3456_4b88_8284_81ca543a9d65_8080_share_", Value: "a-share-token"}
 		miscCookie        = &http.Cookie{Domain: domain, Name: "some-other-cookie", Value: "I like cookies"}
 		invalidCookieName = &http.Cookie{Domain: domain, Name: "foobar[0]", Value: "violates RFC6266"}
 	)
@@ -748,6 +790,7 @@ func TestRemoveSensitiveCookies(t *testing.T) {
 		{"session cookie", []*http.Cookie{sessionCookie, miscCookie}, []*http.Cookie{miscCookie}},
 		{"portAuth cookie", []*http.Cookie{portAuthCookie, miscCookie}, []*http.Cookie{miscCookie}},
 		{"owner cookie", []*http.Cookie{ownerCookie, miscCookie}, []*http.Cookie{miscCookie}},
+		{"share cookie", []*http.Cookie{shareCookie, miscCookie}, []*http.Cookie{miscCookie}},
 		{"misc cookie", []*http.Cookie{miscCookie}, []*http.Cookie{miscCookie}},
 		{"invalid cookie name", []*http.Cookie{invalidCookieName}, []*http.Cookie{invalidCookieName}},
 	}
@@ -796,3 +839,26 @@ func TestSensitiveCookieHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestServeWorkspaceStoppedPage(t *testing.T) {
+	handler, err := serveWorkspaceStoppedPage(&config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := mux.SetURLVars(httptest.NewRequest("GET", "https://blabla-smelt-9ba20cc1.test-domain.com/", nil), map[string]string{
+		workspaceIDIdentifier: "blabla-smelt-9ba20cc1",
+	})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	for _, expected := range []string{`data-workspace-id="blabla-smelt-9ba20cc1"`, `new URL('https://test-domain.com')`} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("page does not contain %s", expected)
+		}
+	}
+	if strings.Contains(body, "gitpod.io") {
+		t.Errorf("page refers to gitpod.io instead of the installation")
+	}
+}
@@ -0,0 +1,444 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package proxy
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	wsapi "github.com/gitpod-io/gitpod/ws-manager/api"
+)
+
+// SSHGatewayConfig configures the SSH gateway
+type SSHGatewayConfig struct {
+	Address  string   `json:"address"`
+	HostKeys []string `json:"hostKeys"`
+}
+
+// Validate validates the configuration to catch issues during startup and not at runtime
+func (c *SSHGatewayConfig) Validate() error {
+	if c == nil {
+		return xerrors.Errorf("SSHGatewayConfig not configured")
+	}
+
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Address, validation.Required),
+		validation.Field(&c.HostKeys, validation.Required),
+	)
+}
+
+const (
+	// sshWorkspaceUser is the user the SSH server of supervisor runs sessions as
+	sshWorkspaceUser = "gitpod"
+	// sshAuthorizedKeyRequest asks the SSH server of supervisor whether a public key may log in
+	sshAuthorizedKeyRequest = "authorized-key@gitpod.io"
+	// sshWorkspaceIDExtension carries the workspace a connection was authenticated for
+	sshWorkspaceIDExtension = "workspace-id"
+
+	sshDialTimeout = 10 * time.Second
+	// sshHandshakeTimeout bounds the time clients have to authenticate
+	sshHandshakeTimeout = 30 * time.Second
+
+	// sshMaxFailedConns is the number of connections per source address which may fail to authenticate within sshFailedAuthWindow.
+	// Once exceeded, we reject all connections of the source until the window ends. We count connections rather than rejected
+	// keys, because clients offer all of their keys in turn and only one of them is expected to be authorized.
+	sshMaxFailedConns   = 10
+	sshFailedAuthWindow = 1 * time.Minute
+)
+
+// SSHGateway accepts SSH connections for all workspaces on a single address and relays them
+// to the SSH server of the workspace's supervisor.
+//
+// Clients select the workspace by their user name: either <workspaceID>#<ownerToken>, or
+// <workspaceID> if they log in with a key listed in the workspace's authorized keys.
+// The gateway logs into the workspace using the workspace's SSH gateway token.
+type SSHGateway struct {
+	Config                SSHGatewayConfig
+	WorkspacePodConfig    *WorkspacePodConfig
+	WorkspaceInfoProvider WorkspaceInfoProvider
+
+	hostKeys []ssh.Signer
+	failures sshAuthFailures
+}
+
+// NewSSHGateway creates a new SSH gateway and loads its host keys
+func NewSSHGateway(config SSHGatewayConfig, workspacePodConfig *WorkspacePodConfig, workspaceInfoProvider WorkspaceInfoProvider) (*SSHGateway, error) {
+	g := &SSHGateway{
+		Config:                config,
+		WorkspacePodConfig:    workspacePodConfig,
+		WorkspaceInfoProvider: workspaceInfoProvider,
+	}
+	tproot := os.Getenv("TELEPRESENCE_ROOT")
+	for _, fn := range config.HostKeys {
+		if tproot != "" {
+			fn = filepath.Join(tproot, fn)
+		}
+		content, err := os.ReadFile(fn)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot read host key: %w", err)
+		}
+		hostKey, err := ssh.ParsePrivateKey(content)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot parse host key %s: %w", fn, err)
+		}
+		g.hostKeys = append(g.hostKeys, hostKey)
+	}
+	return g, nil
+}
+
+// MustServe starts the SSH gateway and ends the process if doing so fails
+func (g *SSHGateway) MustServe() {
+	l, err := net.Listen("tcp", g.Config.Address)
+	if err != nil {
+		log.WithError(err).Fatal("cannot start SSH gateway")
+		return
+	}
+	err = g.Serve(l)
+	if err != nil {
+		log.WithError(err).Fatal("SSH gateway stopped")
+	}
+}
+
+// Serve accepts SSH connections on l until it is closed
+func (g *SSHGateway) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go g.handleConn(conn)
+	}
+}
+
+// sshUpstream is a connection to the SSH server of a workspace
+type sshUpstream struct {
+	WorkspaceID string
+	Conn        ssh.Conn
+	Chans       <-chan ssh.NewChannel
+	Reqs        <-chan *ssh.Request
+}
+
+// serverConfig produces the config of a client connection. While authorizing keys, it keeps the upstream connection
+// in upstream, s.t. we log into the workspace once per client connection rather than once per offered key.
+func (g *SSHGateway) serverConfig(upstream **sshUpstream) *ssh.ServerConfig {
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			return g.authorizeKey(meta, key, upstream)
+		},
+		KeyboardInteractiveCallback: func(meta ssh.ConnMetadata, _ ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+			return g.authorizeOwnerToken(meta)
+		},
+	}
+	for _, hostKey := range g.hostKeys {
+		config.AddHostKey(hostKey)
+	}
+	return config
+}
+
+// sshSource returns the address failed connections are counted against
+func sshSource(addr net.Addr) string {
+	source := addr.String()
+	if host, _, err := net.SplitHostPort(source); err == nil {
+		source = host
+	}
+	return source
+}
+
+// sshAuthFailures counts the connections per source address which failed to authenticate
+type sshAuthFailures struct {
+	mu      sync.Mutex
+	sources map[string]*sshSourceFailures
+}
+
+type sshSourceFailures struct {
+	Count int
+	Since time.Time
+}
+
+// Exceeded returns true if the source failed sshMaxFailedConns times within the current window
+func (f *sshAuthFailures) Exceeded(source string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	failures, exists := f.sources[source]
+	if !exists || time.Since(failures.Since) > sshFailedAuthWindow {
+		return false
+	}
+	return failures.Count >= sshMaxFailedConns
+}
+
+// Add counts a connection of the source which failed to authenticate
+func (f *sshAuthFailures) Add(source string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.sources == nil {
+		f.sources = make(map[string]*sshSourceFailures)
+	}
+	// forget about the sources whose window has ended, s.t. the map does not grow indefinitely
+	for src, failures := range f.sources {
+		if time.Since(failures.Since) > sshFailedAuthWindow {
+			delete(f.sources, src)
+		}
+	}
+	failures, exists := f.sources[source]
+	if !exists {
+		failures = &sshSourceFailures{Since: time.Now()}
+		f.sources[source] = failures
+	}
+	failures.Count++
+}
+
+// parseSSHUser splits a user name into the workspace ID and the owner token, if any
+func parseSSHUser(user string) (workspaceID string, ownerToken string) {
+	segs := strings.SplitN(user, "#", 2)
+	if len(segs) == 1 {
+		return segs[0], ""
+	}
+	return segs[0], segs[1]
+}
+
+// authorizeOwnerToken logs in users of the form <workspaceID>#<ownerToken> without asking any questions
+func (g *SSHGateway) authorizeOwnerToken(meta ssh.ConnMetadata) (*ssh.Permissions, error) {
+	workspaceID, ownerToken := parseSSHUser(meta.User())
+	if ownerToken == "" {
+		return nil, xerrors.Errorf("no owner token for workspace %s", workspaceID)
+	}
+	info, err := g.workspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(ownerToken), []byte(info.Auth.OwnerToken)) != 1 {
+		return nil, xerrors.Errorf("invalid owner token for workspace %s", workspaceID)
+	}
+	return sshPermissions(workspaceID), nil
+}
+
+// authorizeKey logs in users with an owner token regardless of their key, or asks the workspace whether their key is authorized.
+// To do so, it reuses the upstream connection of the client connection, or stores the one it opens in upstream.
+func (g *SSHGateway) authorizeKey(meta ssh.ConnMetadata, key ssh.PublicKey, upstream **sshUpstream) (*ssh.Permissions, error) {
+	workspaceID, ownerToken := parseSSHUser(meta.User())
+	if ownerToken != "" {
+		return g.authorizeOwnerToken(meta)
+	}
+
+	if *upstream != nil && (*upstream).WorkspaceID != workspaceID {
+		// the client changed its user name between attempts
+		(*upstream).Conn.Close()
+		*upstream = nil
+	}
+	if *upstream == nil {
+		info, err := g.workspace(workspaceID)
+		if err != nil {
+			return nil, err
+		}
+		conn, chans, reqs, err := g.dialWorkspace(info)
+		if err != nil {
+			return nil, err
+		}
+		*upstream = &sshUpstream{WorkspaceID: workspaceID, Conn: conn, Chans: chans, Reqs: reqs}
+	}
+
+	ok, _, err := (*upstream).Conn.SendRequest(sshAuthorizedKeyRequest, true, key.Marshal())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, xerrors.Errorf("unknown public key for workspace %s", workspaceID)
+	}
+	return sshPermissions(workspaceID), nil
+}
+
+func sshPermissions(workspaceID string) *ssh.Permissions {
+	return &ssh.Permissions{
+		Extensions: map[string]string{
+			sshWorkspaceIDExtension: workspaceID,
+		},
+	}
+}
+
+// workspace returns the info of a running workspace which accepts SSH connections
+func (g *SSHGateway) workspace(workspaceID string) (*WorkspaceInfo, error) {
+	info := g.WorkspaceInfoProvider.WorkspaceInfo(context.Background(), workspaceID)
+	if info == nil {
+		return nil, xerrors.Errorf("workspace %s not found", workspaceID)
+	}
+	if info.Phase != wsapi.WorkspacePhase_RUNNING {
+		return nil, xerrors.Errorf("workspace %s is not running", workspaceID)
+	}
+	if info.Auth == nil || info.Auth.OwnerToken == "" {
+		return nil, xerrors.Errorf("workspace %s has no owner token", workspaceID)
+	}
+	if info.Auth.SshGatewayToken == "" {
+		return nil, xerrors.Errorf("workspace %s does not accept connections of the SSH gateway", workspaceID)
+	}
+	return info, nil
+}
+
+// dialWorkspace logs into the SSH server of the workspace's supervisor using the SSH gateway token
+func (g *SSHGateway) dialWorkspace(info *WorkspaceInfo) (ssh.Conn, <-chan ssh.NewChannel, <-chan *ssh.Request, error) {
+	dst, err := buildWorkspacePodURL(g.WorkspacePodConfig.ServiceTemplate, info.WorkspaceID, fmt.Sprint(g.WorkspacePodConfig.SSHPort))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	conn, err := net.DialTimeout("tcp", dst.Host, sshDialTimeout)
+	if err != nil {
+		return nil, nil, nil, xerrors.Errorf("cannot connect to workspace %s: %w", info.WorkspaceID, err)
+	}
+	c, chans, reqs, err := ssh.NewClientConn(conn, dst.Host, &ssh.ClientConfig{
+		User: sshWorkspaceUser,
+		Auth: []ssh.AuthMethod{ssh.Password(info.Auth.SshGatewayToken)},
+		// the host keys of workspaces are not known to ws-proxy - we rely on the cluster network instead
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         sshDialTimeout,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, xerrors.Errorf("cannot log into workspace %s: %w", info.WorkspaceID, err)
+	}
+	return c, chans, reqs, nil
+}
+
+func (g *SSHGateway) handleConn(conn net.Conn) {
+	// the upstream connection opened while authorizing the client's keys, if any
+	var authUpstream *sshUpstream
+	defer func() {
+		if authUpstream != nil {
+			authUpstream.Conn.Close()
+		}
+	}()
+
+	source := sshSource(conn.RemoteAddr())
+	if g.failures.Exceeded(source) {
+		log.WithField("remote", conn.RemoteAddr().String()).Debug("ssh gateway: too many failed connections")
+		conn.Close()
+		return
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(sshHandshakeTimeout))
+	clientConn, clientChans, clientReqs, err := ssh.NewServerConn(conn, g.serverConfig(&authUpstream))
+	if err != nil {
+		log.WithError(err).WithField("remote", conn.RemoteAddr().String()).Debug("ssh gateway: handshake failed")
+		g.failures.Add(source)
+		conn.Close()
+		return
+	}
+	defer clientConn.Close()
+	_ = conn.SetDeadline(time.Time{})
+
+	workspaceID := clientConn.Permissions.Extensions[sshWorkspaceIDExtension]
+	log := log.WithFields(log.OWI("", workspaceID, "")).WithField("remote", clientConn.RemoteAddr().String())
+	upstream := authUpstream
+	if upstream == nil || upstream.WorkspaceID != workspaceID {
+		info, err := g.workspace(workspaceID)
+		if err != nil {
+			log.WithError(err).Warn("ssh gateway: workspace is gone")
+			return
+		}
+		upstreamConn, chans, reqs, err := g.dialWorkspace(info)
+		if err != nil {
+			log.WithError(err).Warn("ssh gateway: cannot connect to workspace")
+			return
+		}
+		defer upstreamConn.Close()
+		upstream = &sshUpstream{WorkspaceID: workspaceID, Conn: upstreamConn, Chans: chans, Reqs: reqs}
+	}
+	log.Debug("ssh gateway: relaying connection")
+	defer log.Debug("ssh gateway: connection closed")
+
+	go relaySSHGlobalRequests(upstream.Conn, clientReqs)
+	go relaySSHGlobalRequests(clientConn, upstream.Reqs)
+	go relaySSHChannels(upstream.Conn, clientChans)
+	go relaySSHChannels(clientConn, upstream.Chans)
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_ = clientConn.Wait()
+		done <- struct{}{}
+	}()
+	go func() {
+		_ = upstream.Conn.Wait()
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// relaySSHGlobalRequests sends global requests to dst and relays the replies
+func relaySSHGlobalRequests(dst ssh.Conn, reqs <-chan *ssh.Request) {
+	for req := range reqs {
+		ok, payload, err := dst.SendRequest(req.Type, req.WantReply, req.Payload)
+		if req.WantReply {
+			_ = req.Reply(ok && err == nil, payload)
+		}
+	}
+}
+
+// relaySSHChannels opens the channels on dst and relays them
+func relaySSHChannels(dst ssh.Conn, chans <-chan ssh.NewChannel) {
+	for newCh := range chans {
+		go func(newCh ssh.NewChannel) {
+			dstCh, dstReqs, err := dst.OpenChannel(newCh.ChannelType(), newCh.ExtraData())
+			if openErr, ok := err.(*ssh.OpenChannelError); ok {
+				_ = newCh.Reject(openErr.Reason, openErr.Message)
+				return
+			}
+			if err != nil {
+				_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+				return
+			}
+			srcCh, srcReqs, err := newCh.Accept()
+			if err != nil {
+				dstCh.Close()
+				return
+			}
+			go relaySSHChannel(dstCh, srcCh, srcReqs)
+			relaySSHChannel(srcCh, dstCh, dstReqs)
+		}(newCh)
+	}
+}
+
+// relaySSHChannel relays the data, extended data and requests of src to dst until src is closed
+func relaySSHChannel(dst ssh.Channel, src ssh.Channel, srcReqs <-chan *ssh.Request) {
+	eof := make(chan struct{})
+	go func() {
+		defer close(eof)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _ = io.Copy(dst, src)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = io.Copy(dst.Stderr(), src.Stderr())
+		}()
+		wg.Wait()
+		// neither data nor extended data may follow the EOF
+		_ = dst.CloseWrite()
+	}()
+	for req := range srcReqs {
+		ok, err := dst.SendRequest(req.Type, req.WantReply, req.Payload)
+		if req.WantReply {
+			_ = req.Reply(ok && err == nil, nil)
+		}
+	}
+	// the requests end when src is closed, but its data might still be in flight, e.g. the output before an exit-status
+	<-eof
+	dst.Close()
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package proxy
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/gitpod-io/gitpod/ws-manager/api"
+)
+
+func newTestSigner(t *testing.T) ssh.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func listenTest(t *testing.T) net.Listener {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	return l
+}
+
+// startTestWorkspaceSSHServer mimics the SSH server of supervisor: it lets the gateway in with the gateway token,
+// answers whether keys are authorized and echoes the commands of sessions. It counts the logins of the gateway.
+func startTestWorkspaceSSHServer(t *testing.T, gatewayToken string, authorizedKey ssh.PublicKey, logins *int32) uint16 {
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(meta ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if meta.User() != sshWorkspaceUser || string(password) != gatewayToken {
+				return nil, fmt.Errorf("access denied")
+			}
+			atomic.AddInt32(logins, 1)
+			return nil, nil
+		},
+	}
+	config.AddHostKey(newTestSigner(t))
+
+	l := listenTest(t)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, chans, reqs, err := ssh.NewServerConn(conn, config)
+				if err != nil {
+					return
+				}
+				go func() {
+					for req := range reqs {
+						ok := req.Type == sshAuthorizedKeyRequest && bytes.Equal(req.Payload, authorizedKey.Marshal())
+						_ = req.Reply(ok, nil)
+					}
+				}()
+				for newCh := range chans {
+					ch, chReqs, err := newCh.Accept()
+					if err != nil {
+						continue
+					}
+					go func() {
+						defer ch.Close()
+						for req := range chReqs {
+							if req.Type != "exec" {
+								_ = req.Reply(false, nil)
+								continue
+							}
+							var payload struct{ Command string }
+							_ = ssh.Unmarshal(req.Payload, &payload)
+							_ = req.Reply(true, nil)
+							fmt.Fprintf(ch, "hello %s\n", payload.Command)
+							fmt.Fprintln(ch.Stderr(), "oops")
+							_, _ = ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{3}))
+							return
+						}
+					}()
+				}
+			}()
+		}
+	}()
+	return uint16(l.Addr().(*net.TCPAddr).Port)
+}
+
+func startTestSSHGateway(t *testing.T, authorizedKey ssh.PublicKey, logins *int32) (addr string, hostKey ssh.PublicKey) {
+	var (
+		sshPort    = startTestWorkspaceSSHServer(t, "gateway-token", authorizedKey, logins)
+		gatewayKey = newTestSigner(t)
+	)
+	gateway := &SSHGateway{
+		WorkspacePodConfig: &WorkspacePodConfig{
+			ServiceTemplate: "http://127.0.0.1:{{ .port }}",
+			SSHPort:         sshPort,
+		},
+		WorkspaceInfoProvider: &fakeWsInfoProvider{infos: []WorkspaceInfo{
+			{
+				WorkspaceID: "amaranth-smelt-9ba20cc1",
+				Auth:        &api.WorkspaceAuthentication{OwnerToken: "owner-token", SshGatewayToken: "gateway-token"},
+				Phase:       api.WorkspacePhase_RUNNING,
+			},
+			{
+				WorkspaceID: "blue-whale-b0a6f3d2",
+				Auth:        &api.WorkspaceAuthentication{OwnerToken: "owner-token", SshGatewayToken: "gateway-token"},
+				Phase:       api.WorkspacePhase_STOPPING,
+			},
+			{
+				WorkspaceID: "coral-crab-c1d2e3f4",
+				Auth:        &api.WorkspaceAuthentication{OwnerToken: "owner-token"},
+				Phase:       api.WorkspacePhase_RUNNING,
+			},
+		}},
+		hostKeys: []ssh.Signer{gatewayKey},
+	}
+	l := listenTest(t)
+	go func() {
+		_ = gateway.Serve(l)
+	}()
+	return l.Addr().String(), gatewayKey.PublicKey()
+}
+
+func TestSSHGateway(t *testing.T) {
+	var (
+		authorizedKey = newTestSigner(t)
+		unknownKey    = newTestSigner(t)
+		logins        int32
+		addr, hostKey = startTestSSHGateway(t, authorizedKey.PublicKey(), &logins)
+	)
+
+	tests := []struct {
+		Desc        string
+		User        string
+		Auth        ssh.AuthMethod
+		ExpectError bool
+	}{
+		{Desc: "owner token", User: "amaranth-smelt-9ba20cc1#owner-token", Auth: ssh.KeyboardInteractive(noQuestions)},
+		{Desc: "owner token with any key", User: "amaranth-smelt-9ba20cc1#owner-token", Auth: ssh.PublicKeys(unknownKey)},
+		{Desc: "wrong owner token", User: "amaranth-smelt-9ba20cc1#wrong-token", Auth: ssh.KeyboardInteractive(noQuestions), ExpectError: true},
+		{Desc: "authorized key", User: "amaranth-smelt-9ba20cc1", Auth: ssh.PublicKeys(authorizedKey)},
+		{Desc: "unknown key", User: "amaranth-smelt-9ba20cc1", Auth: ssh.PublicKeys(unknownKey), ExpectError: true},
+		{Desc: "unknown workspace", User: "unknown-workspace#owner-token", Auth: ssh.KeyboardInteractive(noQuestions), ExpectError: true},
+		{Desc: "stopping workspace", User: "blue-whale-b0a6f3d2#owner-token", Auth: ssh.KeyboardInteractive(noQuestions), ExpectError: true},
+		{Desc: "workspace without gateway token", User: "coral-crab-c1d2e3f4#owner-token", Auth: ssh.KeyboardInteractive(noQuestions), ExpectError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			client, err := ssh.Dial("tcp", addr, &ssh.ClientConfig{
+				User:            test.User,
+				Auth:            []ssh.AuthMethod{test.Auth},
+				HostKeyCallback: ssh.FixedHostKey(hostKey),
+			})
+			if (err != nil) != test.ExpectError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			defer client.Close()
+
+			sess, err := client.NewSession()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sess.Close()
+			var stdout, stderr bytes.Buffer
+			sess.Stdout = &stdout
+			sess.Stderr = &stderr
+			err = sess.Run("world")
+			if exitErr, ok := err.(*ssh.ExitError); !ok || exitErr.ExitStatus() != 3 {
+				t.Errorf("unexpected exit: %v", err)
+			}
+			if act := stdout.String(); act != "hello world\n" {
+				t.Errorf("unexpected stdout: %q", act)
+			}
+			if act := stderr.String(); act != "oops\n" {
+				t.Errorf("unexpected stderr: %q", act)
+			}
+		})
+	}
+}
+
+func TestSSHGatewayLogsInOncePerConnection(t *testing.T) {
+	var (
+		authorizedKey = newTestSigner(t)
+		logins        int32
+		addr, hostKey = startTestSSHGateway(t, authorizedKey.PublicKey(), &logins)
+	)
+	client, err := ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:            "amaranth-smelt-9ba20cc1",
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(newTestSigner(t), newTestSigner(t), authorizedKey)},
+		HostKeyCallback: ssh.FixedHostKey(hostKey),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Close()
+	if act := atomic.LoadInt32(&logins); act != 1 {
+		t.Errorf("gateway logged into the workspace %d times, expected once", act)
+	}
+}
+
+func TestSSHGatewayLimitsFailedConns(t *testing.T) {
+	var (
+		authorizedKey = newTestSigner(t)
+		logins        int32
+		addr, hostKey = startTestSSHGateway(t, authorizedKey.PublicKey(), &logins)
+	)
+	dial := func(user string) error {
+		client, err := ssh.Dial("tcp", addr, &ssh.ClientConfig{
+			User:            user,
+			Auth:            []ssh.AuthMethod{ssh.KeyboardInteractive(noQuestions)},
+			HostKeyCallback: ssh.FixedHostKey(hostKey),
+		})
+		if err == nil {
+			client.Close()
+		}
+		return err
+	}
+	for i := 0; i < sshMaxFailedConns; i++ {
+		if err := dial("amaranth-smelt-9ba20cc1#wrong-token"); err == nil {
+			t.Fatal("logged in with the wrong owner token")
+		}
+	}
+	// the gateway counts a failed connection once the client has hung up, which may be after dial returned
+	deadline := time.Now().Add(5 * time.Second)
+	for dial("amaranth-smelt-9ba20cc1#owner-token") == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("logged in after too many failed connections")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSSHGatewayDoesNotCountRejectedKeys(t *testing.T) {
+	var (
+		authorizedKey = newTestSigner(t)
+		logins        int32
+		addr, hostKey = startTestSSHGateway(t, authorizedKey.PublicKey(), &logins)
+	)
+	for i := 0; i <= sshMaxFailedConns; i++ {
+		client, err := ssh.Dial("tcp", addr, &ssh.ClientConfig{
+			User:            "amaranth-smelt-9ba20cc1",
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(newTestSigner(t), newTestSigner(t), authorizedKey)},
+			HostKeyCallback: ssh.FixedHostKey(hostKey),
+		})
+		if err != nil {
+			t.Fatalf("connection %d: %v", i, err)
+		}
+		client.Close()
+	}
+}
+
+func noQuestions(user, instruction string, questions []string, echos []bool) ([]string, error) {
+	return nil, nil
+}
+
+func TestParseSSHUser(t *testing.T) {
+	tests := []struct {
+		User               string
+		ExpectedWorkspace  string
+		ExpectedOwnerToken string
+	}{
+		{User: "amaranth-smelt-9ba20cc1", ExpectedWorkspace: "amaranth-smelt-9ba20cc1"},
+		{User: "amaranth-smelt-9ba20cc1#owner-token", ExpectedWorkspace: "amaranth-smelt-9ba20cc1", ExpectedOwnerToken: "owner-token"},
+		{User: "amaranth-smelt-9ba20cc1#owner#token", ExpectedWorkspace: "amaranth-smelt-9ba20cc1", ExpectedOwnerToken: "owner#token"},
+	}
+	for _, test := range tests {
+		t.Run(test.User, func(t *testing.T) {
+			workspaceID, ownerToken := parseSSHUser(test.User)
+			if workspaceID != test.ExpectedWorkspace || ownerToken != test.ExpectedOwnerToken {
+				t.Errorf("unexpected result: got %s and %s, expected %s and %s", workspaceID, ownerToken, test.ExpectedWorkspace, test.ExpectedOwnerToken)
+			}
+		})
+	}
+}
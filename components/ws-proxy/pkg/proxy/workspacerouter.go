@@ -27,6 +27,12 @@ const (
 	// Used as key for storing the path to fetch foreign content
 	foreignPathIdentifier = "foreignPath"
 
+	// Used as key for storing the path prefix of the PathBasedRouter, e.g. /ws
+	pathPrefixIdentifier = "pathPrefix"
+
+	// Used as key for storing the path prefix of a workspace behind the PathBasedRouter, e.g. /ws/coral-dragon-ilr0r6eq
+	workspacePathPrefixIdentifier = "workspacePathPrefix"
+
 	// The header that is used to communicate the "Host" from proxy -> ws-proxy in scenarios where ws-proxy is _not_ directly exposed
 	forwardedHostnameHeader = "x-wsproxy-host"
 
@@ -50,14 +56,7 @@ func HostBasedRouter(header, wsHostSuffix string, wsHostSuffixRegex string) Work
 		}
 
 		var (
-			getHostHeader = func(req *http.Request) string {
-				if header == "Host" {
-					parts := strings.Split(req.Host, ":")
-					return parts[0]
-				}
-
-				return req.Header.Get(header)
-			}
+			getHostHeader   = hostHeader(header)
 			blobserveRouter = r.MatcherFunc(matchBlobserveHostHeader(wsHostSuffix, getHostHeader)).Subrouter()
 			portRouter      = r.MatcherFunc(matchWorkspaceHostHeader(wsHostSuffix, getHostHeader, true)).Subrouter()
 			ideRouter       = r.MatcherFunc(matchWorkspaceHostHeader(allClusterWsHostSuffixRegex, getHostHeader, false)).Subrouter()
@@ -72,8 +71,141 @@ func HostBasedRouter(header, wsHostSuffix string, wsHostSuffixRegex string) Work
 	}
 }
 
+// PathBasedRouter is a WorkspaceRouter that routes based on the path on a single host, which requires neither wildcard DNS
+// nor wildcard certificates:
+//
+//	<host><pathPrefix>/<workspaceID>/... the IDE
+//	<host><pathPrefix>/blobserve/...     blobserve
+//
+// All workspaces on that host share one origin, i.e. the browser does not isolate them from each other.
+// The host must therefore never be the host of the dashboard and the API, or any workspace could act on behalf of its visitors.
+//
+// Exposed ports serve arbitrary content, which must not share the origin of the IDEs. They stay on their own host
+// <port>-<workspaceID><portHostSuffix>, and are not served at all if portHostSuffix is empty.
+//
+// The prefix is stripped before the request is routed any further, and restored in redirects and cookies by proxyPass.
+func PathBasedRouter(header, host, pathPrefix, portHostSuffix string) WorkspaceRouter {
+	return func(r *mux.Router, wsInfoProvider WorkspaceInfoProvider) (*mux.Router, *mux.Router, *mux.Router) {
+		getHostHeader := hostHeader(header)
+		root := r
+
+		portRouter := root.MatcherFunc(func(req *http.Request, m *mux.RouteMatch) bool {
+			return false
+		}).Subrouter()
+		if portHostSuffix != "" {
+			portRouter = root.MatcherFunc(matchWorkspaceHostHeader(regexp.QuoteMeta(portHostSuffix), getHostHeader, true)).Subrouter()
+		}
+
+		r = r.MatcherFunc(func(req *http.Request, m *mux.RouteMatch) bool {
+			return getHostHeader(req) == host
+		}).Subrouter()
+
+		// browsers resolve relative URLs against the last slash, hence workspaces must be addressed with a trailing slash
+		noTrailingSlash := regexp.MustCompile("^" + regexp.QuoteMeta(pathPrefix) + "/" + workspaceIDRegex + "$")
+		r.MatcherFunc(func(req *http.Request, m *mux.RouteMatch) bool {
+			return noTrailingSlash.MatchString(req.URL.Path)
+		}).HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			location := req.URL.EscapedPath() + "/"
+			if req.URL.RawQuery != "" {
+				location += "?" + req.URL.RawQuery
+			}
+			http.Redirect(w, req, location, http.StatusFound)
+		})
+
+		var (
+			blobserveRouter = r.MatcherFunc(matchBlobservePath(pathPrefix)).Subrouter()
+			ideRouter       = r.MatcherFunc(matchWorkspacePath(pathPrefix)).Subrouter()
+		)
+
+		root.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			log.Debugf("no match for path %s, host: %s", req.URL.Path, getHostHeader(req))
+			w.WriteHeader(404)
+		})
+		return ideRouter, portRouter, blobserveRouter
+	}
+}
+
+// matchWorkspacePath matches <pathPrefix>/<workspaceID>/... and strips that prefix from the request.
+// Stripping the prefix within the matcher is fine because the IDE routes end in a catch-all route,
+// i.e. once this matcher matches no other route is tried.
+func matchWorkspacePath(pathPrefix string) mux.MatcherFunc {
+	r := regexp.MustCompile("^" + regexp.QuoteMeta(pathPrefix) + "/" + workspaceIDRegex + "(/.*)?$")
+	return func(req *http.Request, m *mux.RouteMatch) bool {
+		if m.Vars[workspacePathPrefixIdentifier] != "" {
+			// the routes of a subrouter inherit its matchers, i.e. we see the request again after its prefix was stripped
+			return true
+		}
+
+		matches := r.FindStringSubmatch(req.URL.Path)
+		if matches == nil {
+			return false
+		}
+		// https://ws.gitpod.example.com/ws/coral-dragon-ilr0r6eq/index.html
+		// workspaceID: coral-dragon-ilr0r6eq
+		// workspacePathPrefix: /ws/coral-dragon-ilr0r6eq
+		path := matches[len(matches)-1]
+
+		if m.Vars == nil {
+			m.Vars = make(map[string]string)
+		}
+		m.Vars[workspaceIDIdentifier] = matches[r.SubexpIndex(workspaceIDIdentifier)]
+		m.Vars[pathPrefixIdentifier] = pathPrefix
+		m.Vars[workspacePathPrefixIdentifier] = strings.TrimSuffix(req.URL.Path, path)
+
+		stripPathPrefix(req, m.Vars[workspacePathPrefixIdentifier])
+		return true
+	}
+}
+
+// matchBlobservePath matches <pathPrefix>/blobserve/... and strips that prefix from the request
+func matchBlobservePath(pathPrefix string) mux.MatcherFunc {
+	prefix := pathPrefix + "/blobserve"
+	return func(req *http.Request, m *mux.RouteMatch) bool {
+		if m.Vars[pathPrefixIdentifier] != "" {
+			// the routes of a subrouter inherit its matchers, i.e. we see the request again after its prefix was stripped
+			return m.Vars[workspacePathPrefixIdentifier] == ""
+		}
+		if !strings.HasPrefix(req.URL.Path, prefix+"/") {
+			return false
+		}
+
+		if m.Vars == nil {
+			m.Vars = make(map[string]string)
+		}
+		m.Vars[pathPrefixIdentifier] = pathPrefix
+
+		stripPathPrefix(req, prefix)
+		return true
+	}
+}
+
+func stripPathPrefix(req *http.Request, prefix string) {
+	req.URL.Path = strings.TrimPrefix(req.URL.Path, prefix)
+	if req.URL.Path == "" {
+		req.URL.Path = "/"
+	}
+	if req.URL.RawPath != "" {
+		req.URL.RawPath = strings.TrimPrefix(req.URL.RawPath, prefix)
+		if req.URL.RawPath == "" {
+			req.URL.RawPath = "/"
+		}
+	}
+}
+
 type hostHeaderProvider func(req *http.Request) string
 
+// hostHeader returns a hostHeaderProvider which reads the host from the given header, without the port of the Host header
+func hostHeader(header string) hostHeaderProvider {
+	return func(req *http.Request) string {
+		if header == "Host" {
+			parts := strings.Split(req.Host, ":")
+			return parts[0]
+		}
+
+		return req.Header.Get(header)
+	}
+}
+
 func matchWorkspaceHostHeader(wsHostSuffix string, headerProvider hostHeaderProvider, matchPort bool) mux.MatcherFunc {
 	regexPrefix := workspaceIDRegex
 	if matchPort {
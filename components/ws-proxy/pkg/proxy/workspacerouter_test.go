@@ -75,6 +75,81 @@ func TestWorkspaceRouter(t *testing.T) {
 				URL:    "http://blobserve.ws.gitpod.dev/image:version:/foo/main.js",
 			},
 		},
+		{
+			Name:   "path-based workspace access",
+			URL:    "http://ws.gitpod.dev/ws/amaranth-smelt-9ba20cc1/services",
+			Router: PathBasedRouter("Host", "ws.gitpod.dev", "/ws", ".ws.gitpod.dev"),
+			Expected: Expectation{
+				WorkspaceID: "amaranth-smelt-9ba20cc1",
+				Status:      http.StatusOK,
+				URL:         "http://ws.gitpod.dev/services",
+			},
+		},
+		{
+			Name:   "path-based port access",
+			URL:    "http://1234-amaranth-smelt-9ba20cc1.ws.gitpod.dev/",
+			Router: PathBasedRouter("Host", "ws.gitpod.dev", "/ws", ".ws.gitpod.dev"),
+			Expected: Expectation{
+				WorkspaceID:   "amaranth-smelt-9ba20cc1",
+				WorkspacePort: "1234",
+				Status:        http.StatusOK,
+				URL:           "http://1234-amaranth-smelt-9ba20cc1.ws.gitpod.dev/",
+			},
+		},
+		{
+			Name:   "path-based port path on the shared host",
+			URL:    "http://ws.gitpod.dev/ws/amaranth-smelt-9ba20cc1/port/1234/",
+			Router: PathBasedRouter("Host", "ws.gitpod.dev", "/ws", ".ws.gitpod.dev"),
+			Expected: Expectation{
+				Status:             http.StatusNotFound,
+				AdditionalHitCount: -1,
+			},
+		},
+		{
+			Name:   "path-based port access without port host",
+			URL:    "http://1234-amaranth-smelt-9ba20cc1.ws.gitpod.dev/",
+			Router: PathBasedRouter("Host", "ws.gitpod.dev", "/ws", ""),
+			Expected: Expectation{
+				Status:             http.StatusNotFound,
+				AdditionalHitCount: -1,
+			},
+		},
+		{
+			Name:   "path-based blobserve access",
+			URL:    "http://ws.gitpod.dev/ws/blobserve/image:version:/foo/main.js",
+			Router: PathBasedRouter("Host", "ws.gitpod.dev", "/ws", ".ws.gitpod.dev"),
+			Expected: Expectation{
+				Status: http.StatusOK,
+				URL:    "http://ws.gitpod.dev/image:version:/foo/main.js",
+			},
+		},
+		{
+			Name:   "path-based access without trailing slash",
+			URL:    "http://ws.gitpod.dev/ws/amaranth-smelt-9ba20cc1",
+			Router: PathBasedRouter("Host", "ws.gitpod.dev", "/ws", ".ws.gitpod.dev"),
+			Expected: Expectation{
+				Status:             http.StatusFound,
+				AdditionalHitCount: -1,
+			},
+		},
+		{
+			Name:   "path-based access on the dashboard host",
+			URL:    "http://gitpod.dev/ws/amaranth-smelt-9ba20cc1/",
+			Router: PathBasedRouter("Host", "ws.gitpod.dev", "/ws", ".ws.gitpod.dev"),
+			Expected: Expectation{
+				Status:             http.StatusNotFound,
+				AdditionalHitCount: -1,
+			},
+		},
+		{
+			Name:   "path-based unknown path",
+			URL:    "http://ws.gitpod.dev/amaranth-smelt-9ba20cc1/",
+			Router: PathBasedRouter("Host", "ws.gitpod.dev", "/ws", ".ws.gitpod.dev"),
+			Expected: Expectation{
+				Status:             http.StatusNotFound,
+				AdditionalHitCount: -1,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -316,3 +391,66 @@ func TestMatchWorkspaceHostHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestRewriteResponseForPathPrefix(t *testing.T) {
+	const (
+		pathPrefix          = "/ws"
+		workspacePathPrefix = "/ws/amaranth-smelt-9ba20cc1/port/3000"
+	)
+	tests := []struct {
+		Name     string
+		Header   http.Header
+		Expected http.Header
+	}{
+		{
+			Name:     "absolute path",
+			Header:   http.Header{"Location": {"/login?next=%2F"}},
+			Expected: http.Header{"Location": {workspacePathPrefix + "/login?next=%2F"}},
+		},
+		{
+			Name:     "URL on the same host",
+			Header:   http.Header{"Location": {"https://ws.gitpod.dev/login"}},
+			Expected: http.Header{"Location": {"https://ws.gitpod.dev" + workspacePathPrefix + "/login"}},
+		},
+		{
+			Name:     "URL on another host",
+			Header:   http.Header{"Location": {"https://github.com/login"}},
+			Expected: http.Header{"Location": {"https://github.com/login"}},
+		},
+		{
+			Name:     "relative path",
+			Header:   http.Header{"Location": {"login"}},
+			Expected: http.Header{"Location": {"login"}},
+		},
+		{
+			Name:     "path below the path prefix",
+			Header:   http.Header{"Location": {"/ws/blobserve/image/__files__/main.js"}},
+			Expected: http.Header{"Location": {"/ws/blobserve/image/__files__/main.js"}},
+		},
+		{
+			Name:     "content location and refresh",
+			Header:   http.Header{"Content-Location": {"/index.html"}, "Refresh": {"5; url=/login"}},
+			Expected: http.Header{"Content-Location": {workspacePathPrefix + "/index.html"}, "Refresh": {"5; url=" + workspacePathPrefix + "/login"}},
+		},
+		{
+			Name:   "cookies",
+			Header: http.Header{"Set-Cookie": {"session=foo; Path=/; Domain=ws.gitpod.dev; HttpOnly", "pref=bar; Path=/app/"}},
+			Expected: http.Header{"Set-Cookie": {
+				"session=foo; Path=" + workspacePathPrefix + "; HttpOnly",
+				"pref=bar; Path=" + workspacePathPrefix + "/app",
+			}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "https://ws.gitpod.dev"+workspacePathPrefix+"/", nil)
+			resp := &http.Response{Header: test.Header, Request: req}
+
+			rewriteResponseForPathPrefix(resp, req, pathPrefix, workspacePathPrefix)
+
+			if diff := cmp.Diff(test.Expected, resp.Header); diff != "" {
+				t.Errorf("unexpected header (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2021 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/repr"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	wsk8s "github.com/gitpod-io/gitpod/common-go/kubernetes"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/gpctl/pkg/util"
+	"github.com/gitpod-io/gitpod/ws-manager/api"
+)
+
+// workspacesRestoreCmd restores a workspace from its backup history or trail
+var workspacesRestoreCmd = &cobra.Command{
+	Use:   "restore <workspaceID>",
+	Short: "restores a workspace from the backup it had at a point in time",
+	Long: `Lists the backups of a workspace. With --at only the backup a restore to that point in time would use is listed.
+With --start a new workspace instance is started whose content is initialized from the most recent backup made at or before --at.
+Its spec is derived from the instance ws-manager currently knows for the workspace, unless one is given using --spec.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		workspaceID := args[0]
+		owner, _ := cmd.Flags().GetString("owner")
+		at, err := parsePointInTime(cmd.Flag("at").Value.String())
+		if err != nil {
+			log.WithError(err).Fatal("invalid --at")
+		}
+
+		start, _ := cmd.Flags().GetBool("start")
+		specfn, _ := cmd.Flags().GetString("spec")
+		instanceID, _ := cmd.Flags().GetString("instance-id")
+		if !start && (specfn != "" || instanceID != "") {
+			log.Fatal("--spec and --instance-id require --start")
+		}
+
+		if !start {
+			csconn, csclient, err := getContentServiceClient(ctx)
+			if err != nil {
+				log.WithError(err).Fatal("cannot connect to content-service")
+			}
+			defer csconn.Close()
+
+			resp, err := csclient.ListBackups(ctx, &csapi.ListBackupsRequest{
+				OwnerId:     owner,
+				WorkspaceId: workspaceID,
+			})
+			if err != nil {
+				log.WithError(err).Fatal("error during RPC call")
+			}
+			if !cmd.Flags().Changed("at") {
+				repr.Println(resp.Backups)
+				return
+			}
+
+			// backups are listed most recent first, hence the first one made at or before --at is the one a restore would use
+			for _, b := range resp.Backups {
+				if b.Created > at.Unix() {
+					continue
+				}
+				repr.Println(b)
+				return
+			}
+			log.WithField("at", at.Format(time.RFC3339)).Fatal("no backup was made at or before that time")
+		}
+
+		conn, client, err := getWorkspacesClient(ctx)
+		if err != nil {
+			log.WithError(err).Fatal("cannot connect")
+		}
+		defer conn.Close()
+
+		var spec *api.StartWorkspaceSpec
+		if specfn != "" {
+			fc, err := os.ReadFile(specfn)
+			if err != nil {
+				log.WithError(err).Fatal("cannot read workspace spec")
+			}
+			spec = &api.StartWorkspaceSpec{}
+			if err := json.Unmarshal(fc, spec); err != nil {
+				log.WithError(err).Fatal("cannot parse workspace spec")
+			}
+		} else {
+			spec, err = deriveRestoreSpec(ctx, client, owner, workspaceID)
+			if err != nil {
+				log.WithError(err).Fatal("cannot derive workspace spec")
+			}
+		}
+		spec.Initializer = &csapi.WorkspaceInitializer{
+			Spec: &csapi.WorkspaceInitializer_Backup{
+				Backup: &csapi.FromBackupInitializer{
+					Timestamp: at.Unix(),
+				},
+			},
+		}
+
+		if instanceID == "" {
+			instanceID = uuid.New().String()
+		}
+		sresp, err := client.StartWorkspace(ctx, &api.StartWorkspaceRequest{
+			Id:            instanceID,
+			ServicePrefix: instanceID,
+			Metadata: &api.WorkspaceMetadata{
+				Owner:  owner,
+				MetaId: workspaceID,
+			},
+			Spec: spec,
+			Type: api.WorkspaceType_REGULAR,
+		})
+		if err != nil {
+			log.WithError(err).Fatal("error during RPC call")
+		}
+		log.WithField("instanceId", instanceID).WithField("url", sresp.Url).Info("workspace started")
+	},
+}
+
+// deriveRestoreSpec builds the spec of a new instance from the instance ws-manager currently knows for a workspace.
+// ws-manager forgets instances once they have stopped, so for those the spec has to be given using --spec.
+func deriveRestoreSpec(ctx context.Context, client api.WorkspaceManagerClient, owner, workspaceID string) (*api.StartWorkspaceSpec, error) {
+	resp, err := client.GetWorkspaces(ctx, &api.GetWorkspacesRequest{
+		MustMatch: &api.MetadataFilter{
+			Owner:  owner,
+			MetaId: workspaceID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Status) == 0 {
+		return nil, xerrors.Errorf("ws-manager knows no instance of workspace %s - use --spec instead", workspaceID)
+	}
+	status := resp.Status[0]
+
+	spec := &api.StartWorkspaceSpec{
+		WorkspaceImage: status.Spec.WorkspaceImage,
+		IdeImage:       status.Spec.IdeImage,
+		Timeout:        status.Spec.Timeout,
+	}
+	for _, p := range status.Spec.ExposedPorts {
+		spec.Ports = append(spec.Ports, &api.PortSpec{
+			Port:       p.Port,
+			Target:     p.Target,
+			Visibility: p.Visibility,
+		})
+	}
+
+	// The checkout location, Git identity and user env vars are not part of the workspace status,
+	// but ws-manager passes them to the workspace container as env vars.
+	cfg, namespace, err := getKubeconfig()
+	if err != nil {
+		return nil, err
+	}
+	clientSet, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := clientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", wsk8s.WorkspaceIDLabel, status.Id),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, xerrors.Errorf("cannot find pod of instance %s - use --spec instead", status.Id)
+	}
+
+	var git api.GitSpec
+	for _, c := range pods.Items[0].Spec.Containers {
+		if c.Name != "workspace" {
+			continue
+		}
+		for _, e := range c.Env {
+			switch e.Name {
+			case "GITPOD_REPO_ROOT":
+				spec.CheckoutLocation = strings.TrimPrefix(strings.TrimPrefix(e.Value, "/workspace"), "/")
+			case "THEIA_WORKSPACE_ROOT":
+				spec.WorkspaceLocation = strings.TrimPrefix(strings.TrimPrefix(e.Value, "/workspace"), "/")
+			case "GITPOD_GIT_USER_NAME":
+				git.Username = e.Value
+			case "GITPOD_GIT_USER_EMAIL":
+				git.Email = e.Value
+			case "GITPOD_WORKSPACE_CONTEXT", "GITPOD_WORKSPACE_CONTEXT_URL", "GITPOD_TASKS", "GITPOD_RESOLVED_EXTENSIONS", "GITPOD_EXTERNAL_EXTENSIONS", "GITPOD_IDE_ALIAS":
+				spec.Envvars = append(spec.Envvars, &api.EnvironmentVariable{Name: e.Name, Value: e.Value})
+			default:
+				if strings.HasPrefix(e.Name, "GITPOD_") || strings.HasPrefix(e.Name, "THEIA_") {
+					// set by ws-manager itself
+					continue
+				}
+				spec.Envvars = append(spec.Envvars, &api.EnvironmentVariable{Name: e.Name, Value: e.Value})
+			}
+		}
+	}
+	if git.Username != "" || git.Email != "" {
+		spec.Git = &git
+	}
+	return spec, nil
+}
+
+// parsePointInTime parses either an RFC3339 timestamp, or a duration which is interpreted as "that long ago"
+func parsePointInTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Now(), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return time.Time{}, xerrors.Errorf("%s is neither an RFC3339 timestamp nor a duration", s)
+	}
+	return time.Now().Add(-d), nil
+}
+
+func getContentServiceClient(ctx context.Context) (*grpc.ClientConn, csapi.WorkspaceServiceClient, error) {
+	cfg, namespace, err := getKubeconfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	clientSet, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	freePort, err := GetFreePort()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	port := fmt.Sprintf("%d:8080", freePort)
+	podName, err := util.FindAnyPodForComponent(clientSet, namespace, "content-service")
+	if err != nil {
+		return nil, nil, err
+	}
+	readychan, errchan := util.ForwardPort(ctx, cfg, namespace, podName, port)
+	select {
+	case <-readychan:
+	case err := <-errchan:
+		return nil, nil, err
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+
+	conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", freePort), grpc.WithInsecure())
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, csapi.NewWorkspaceServiceClient(conn), nil
+}
+
+func init() {
+	workspacesCmd.AddCommand(workspacesRestoreCmd)
+	workspacesRestoreCmd.Flags().String("owner", "", "owner of the workspace")
+	workspacesRestoreCmd.Flags().String("at", "", "point in time to restore to - either an RFC3339 timestamp or a duration ago, e.g. 2h (defaults to now)")
+	workspacesRestoreCmd.Flags().Bool("start", false, "start a new workspace instance initialized from the selected backup")
+	workspacesRestoreCmd.Flags().String("spec", "", "spec file of the workspace instance to start instead of deriving it from the workspace (requires --start)")
+	workspacesRestoreCmd.Flags().String("instance-id", "", "ID of the workspace instance to start, generated if empty (requires --start)")
+	_ = workspacesRestoreCmd.MarkFlagRequired("owner")
+}